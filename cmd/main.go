@@ -1,17 +1,28 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"task-management-backend/config"
+	"task-management-backend/internal/adapter/notifier"
 	"task-management-backend/internal/cache"
 	"task-management-backend/internal/repository"
 	ht "task-management-backend/internal/transport/http"
 	"task-management-backend/internal/transport/http/handlers"
 	"task-management-backend/internal/usecase/auth"
+	"task-management-backend/internal/usecase/checklist"
+	"task-management-backend/internal/usecase/project"
+	"task-management-backend/internal/usecase/reminder"
+	"task-management-backend/internal/usecase/search"
+	"task-management-backend/internal/usecase/sprint"
 	"task-management-backend/internal/usecase/task"
+	"task-management-backend/internal/usecase/template"
+	"task-management-backend/internal/usecase/timetracking"
+	"task-management-backend/internal/usecase/view"
 	"task-management-backend/middleware"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/gin-gonic/gin"
 )
@@ -29,18 +40,76 @@ func main() {
 
 	taskRepo := repository.NewTaskRepository(db)
 	userRepo := repository.NewUserRepository(db)
+	reminderRepo := repository.NewReminderRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	dependencyRepo := repository.NewDependencyRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	customFieldRepo := repository.NewCustomFieldRepository(db)
+	workLogRepo := repository.NewWorkLogRepository(db)
+	checklistRepo := repository.NewChecklistRepository(db)
+	templateRepo := repository.NewTemplateRepository(db)
+	sprintRepo := repository.NewSprintRepository(db)
+	undoRepo := repository.NewUndoRepository(db)
+	versionRepo := repository.NewTaskVersionRepository(db)
+	viewRepo := repository.NewViewRepository(db)
+	searchRepo := repository.NewSearchRepository(db)
 
 	authUC := auth.NewAuthUseCase(cfg.JwtSecret, userRepo)
-	taskUC := task.NewTaskUseCase(taskRepo, taskCache)
+	taskUC := task.NewTaskUseCase(taskRepo, userRepo, reminderRepo, dependencyRepo, projectRepo, customFieldRepo, undoRepo, versionRepo, taskCache, task.Options{
+		EnforceBlockers: cfg.EnforceBlockers,
+		UndoWindow:      time.Duration(cfg.UndoWindowMinutes) * time.Minute,
+	})
+	reminderUC := reminder.NewReminderUseCase(reminderRepo, taskRepo, notificationRepo)
+	projectUC := project.NewProjectUseCase(projectRepo, customFieldRepo, taskCache)
+	timeTrackingUC := timetracking.NewTimeTrackingUseCase(workLogRepo, taskRepo, userRepo, taskCache)
+	checklistUC := checklist.NewChecklistUseCase(checklistRepo, taskRepo, taskUC, taskCache)
+	templateUC := template.NewTemplateUseCase(templateRepo, taskRepo, projectRepo, taskCache)
+	sprintUC := sprint.NewSprintUseCase(sprintRepo, taskRepo, projectRepo, customFieldRepo, userRepo)
+	viewUC := view.NewViewUseCase(viewRepo, projectRepo, customFieldRepo, taskUC)
+	searchUC := search.NewSearchUseCase(searchRepo)
+
+	scheduler := reminder.NewScheduler(
+		reminderRepo,
+		time.Duration(cfg.ReminderInterval)*time.Second,
+		notifier.NewInAppNotifier(notificationRepo),
+		notifier.NewWebhookNotifier(10*time.Second),
+	)
+	go scheduler.Run(context.Background())
+
+	if cfg.TrashRetentionDays > 0 {
+		retention := time.Duration(cfg.TrashRetentionDays) * 24 * time.Hour
+		go task.NewTrashPurger(taskRepo, retention, time.Hour).Run(context.Background())
+	}
+
+	if cfg.AutoArchiveDays > 0 {
+		after := time.Duration(cfg.AutoArchiveDays) * 24 * time.Hour
+		go task.NewArchiver(taskRepo, taskCache, after, time.Hour).Run(context.Background())
+	}
 
 	authHandler := handlers.NewAuthHandler(authUC)
 	taskHandler := handlers.NewTaskHandler(taskUC)
+	reminderHandler := handlers.NewReminderHandler(reminderUC)
+	projectHandler := handlers.NewProjectHandler(projectUC)
+	timeTrackingHandler := handlers.NewTimeTrackingHandler(timeTrackingUC)
+	checklistHandler := handlers.NewChecklistHandler(checklistUC)
+	templateHandler := handlers.NewTemplateHandler(templateUC)
+	sprintHandler := handlers.NewSprintHandler(sprintUC)
+	viewHandler := handlers.NewViewHandler(viewUC)
+	searchHandler := handlers.NewSearchHandler(searchUC)
 
 	router := gin.Default()
 	router.Use(middleware.CORSMiddleware())
 	ht.RegisterRoutes(router, ht.RouterDeps{
 		Auth:      authHandler,
 		Task:      taskHandler,
+		Reminder:  reminderHandler,
+		Project:   projectHandler,
+		Time:      timeTrackingHandler,
+		Checklist: checklistHandler,
+		Template:  templateHandler,
+		Sprint:    sprintHandler,
+		View:      viewHandler,
+		Search:    searchHandler,
 		JwtSecret: cfg.JwtSecret,
 	})
 
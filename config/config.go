@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
+	"task-management-backend/pkg/rank"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
@@ -16,6 +18,16 @@ type Config struct {
 	JwtSecret     string `env:"JWT_SECRET"`
 	TokenDuration int    `env:"TOKEN_DURATION" envDefault:"24"`
 	CacheDuration int    `env:"CACHE_DURATION" envDefault:"24"`
+	// ReminderInterval is the reminder scheduler polling interval in seconds.
+	ReminderInterval int `env:"REMINDER_INTERVAL" envDefault:"30"`
+	// EnforceBlockers stops tasks from starting or finishing while they have open blockers.
+	EnforceBlockers bool `env:"ENFORCE_BLOCKERS" envDefault:"false"`
+	// TrashRetentionDays is how long deleted tasks stay in the trash; 0 keeps them until purged.
+	TrashRetentionDays int `env:"TRASH_RETENTION_DAYS" envDefault:"30"`
+	// AutoArchiveDays archives top-level tasks that have been done for this many days; 0 disables it.
+	AutoArchiveDays int `env:"AUTO_ARCHIVE_DAYS" envDefault:"0"`
+	// UndoWindowMinutes is how long task mutations can be undone.
+	UndoWindowMinutes int `env:"UNDO_WINDOW_MINUTES" envDefault:"10"`
 }
 
 var configuration Config
@@ -80,11 +92,303 @@ func migrate(db *sql.DB) error {
 	indexUserID := `CREATE INDEX IF NOT EXISTS idx_tasks_user_id ON tasks(user_id);`
 	indexParentID := `CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks(parent_id);`
 
+	remindersTable := `
+	CREATE TABLE IF NOT EXISTS reminders (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		user_id INTEGER NOT NULL,
+		offset_minutes INTEGER,
+		remind_at DATETIME,
+		channel TEXT NOT NULL DEFAULT 'in_app',
+		webhook_url TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'pending',
+		attempts INTEGER NOT NULL DEFAULT 0,
+		claimed_at DATETIME,
+		sent_at DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);`
+
+	notificationsTable := `
+	CREATE TABLE IF NOT EXISTS notifications (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		task_id INTEGER NOT NULL,
+		reminder_id INTEGER UNIQUE,
+		message TEXT NOT NULL,
+		read_at DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);`
+
+	dependenciesTable := `
+	CREATE TABLE IF NOT EXISTS task_dependencies (
+		blocker_id INTEGER NOT NULL,
+		blocked_id INTEGER NOT NULL,
+		user_id INTEGER NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (blocker_id, blocked_id),
+		FOREIGN KEY (blocker_id) REFERENCES tasks(id) ON DELETE CASCADE,
+		FOREIGN KEY (blocked_id) REFERENCES tasks(id) ON DELETE CASCADE
+	);`
+
+	projectsTable := `
+	CREATE TABLE IF NOT EXISTS projects (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		owner_id INTEGER NOT NULL,
+		name TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+	);`
+
+	workflowStatusesTable := `
+	CREATE TABLE IF NOT EXISTS workflow_statuses (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		project_id INTEGER NOT NULL,
+		name TEXT NOT NULL,
+		category TEXT NOT NULL,
+		position INTEGER NOT NULL,
+		UNIQUE (project_id, name),
+		FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+	);`
+
+	workflowTransitionsTable := `
+	CREATE TABLE IF NOT EXISTS workflow_transitions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		project_id INTEGER NOT NULL,
+		from_status TEXT NOT NULL,
+		to_status TEXT NOT NULL,
+		requires_reason BOOLEAN NOT NULL DEFAULT 0,
+		guards TEXT NOT NULL DEFAULT '',
+		required_fields TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+	);`
+
+	statusHistoryTable := `
+	CREATE TABLE IF NOT EXISTS task_status_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		user_id INTEGER NOT NULL,
+		project_id INTEGER,
+		from_status TEXT NOT NULL,
+		to_status TEXT NOT NULL,
+		reason TEXT NOT NULL DEFAULT '',
+		changed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+	);`
+
+	customFieldsTable := `
+	CREATE TABLE IF NOT EXISTS custom_fields (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		project_id INTEGER NOT NULL,
+		key TEXT NOT NULL,
+		name TEXT NOT NULL,
+		type TEXT NOT NULL,
+		options TEXT NOT NULL DEFAULT '[]',
+		required BOOLEAN NOT NULL DEFAULT 0,
+		position INTEGER NOT NULL DEFAULT 0,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (project_id, key),
+		FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+	);`
+
+	// one row per value; text, date and select values use text_value, numbers and users
+	// use number_value, and select values also store the option position in number_value
+	taskFieldValuesTable := `
+	CREATE TABLE IF NOT EXISTS task_field_values (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		field_id INTEGER NOT NULL,
+		text_value TEXT,
+		number_value REAL,
+		FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+		FOREIGN KEY (field_id) REFERENCES custom_fields(id) ON DELETE CASCADE
+	);`
+
+	// a work log without ended_at is a running timer
+	workLogsTable := `
+	CREATE TABLE IF NOT EXISTS work_logs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		user_id INTEGER NOT NULL,
+		started_at DATETIME NOT NULL,
+		ended_at DATETIME,
+		duration_seconds INTEGER NOT NULL DEFAULT 0,
+		note TEXT NOT NULL DEFAULT '',
+		source TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);`
+
+	checklistItemsTable := `
+	CREATE TABLE IF NOT EXISTS checklist_items (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		title TEXT NOT NULL,
+		checked BOOLEAN NOT NULL DEFAULT 0,
+		position INTEGER NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+	);`
+
+	// the task tree is stored as JSON and only ever read and written as a whole
+	taskTemplatesTable := `
+	CREATE TABLE IF NOT EXISTS task_templates (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		owner_id INTEGER NOT NULL,
+		project_id INTEGER,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		tree TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE,
+		FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE SET NULL
+	);`
+
+	sprintsTable := `
+	CREATE TABLE IF NOT EXISTS sprints (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		project_id INTEGER NOT NULL,
+		kind TEXT NOT NULL,
+		name TEXT NOT NULL,
+		goal TEXT NOT NULL DEFAULT '',
+		start_at DATETIME,
+		end_at DATETIME NOT NULL,
+		closed_at DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+	);`
+
+	// memberships are kept after a task leaves or the sprint closes, for burndown and velocity
+	sprintTasksTable := `
+	CREATE TABLE IF NOT EXISTS sprint_tasks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		sprint_id INTEGER NOT NULL,
+		task_id INTEGER NOT NULL,
+		added_at DATETIME NOT NULL,
+		removed_at DATETIME,
+		carried_over BOOLEAN NOT NULL DEFAULT 0,
+		outcome TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE,
+		FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+	);`
+
+	// undo entries hold a JSON snapshot of the task before the change; see entity.UndoEntry
+	taskUndoTable := `
+	CREATE TABLE IF NOT EXISTS task_undo (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		task_id INTEGER NOT NULL,
+		action TEXT NOT NULL,
+		before TEXT,
+		version DATETIME NOT NULL,
+		previous_version DATETIME,
+		created_at DATETIME NOT NULL,
+		undone_at DATETIME,
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);`
+
+	taskVersionsTable := `
+	CREATE TABLE IF NOT EXISTS task_versions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		user_id INTEGER NOT NULL,
+		version INTEGER NOT NULL,
+		snapshot TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		UNIQUE (task_id, version),
+		FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+	);`
+
+	savedViewsTable := `
+	CREATE TABLE IF NOT EXISTS saved_views (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		owner_id INTEGER NOT NULL,
+		project_id INTEGER,
+		name TEXT NOT NULL,
+		shared BOOLEAN NOT NULL DEFAULT 0,
+		filter TEXT NOT NULL,
+		sort TEXT NOT NULL DEFAULT '',
+		group_by TEXT NOT NULL DEFAULT '',
+		fields TEXT NOT NULL DEFAULT '',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE,
+		FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE SET NULL
+	);`
+
+	indexRemindersDue := `CREATE INDEX IF NOT EXISTS idx_reminders_status_remind_at ON reminders(status, remind_at);`
+	indexRemindersTask := `CREATE INDEX IF NOT EXISTS idx_reminders_task_id ON reminders(task_id);`
+	indexNotificationsUser := `CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at);`
+	indexDependenciesBlocked := `CREATE INDEX IF NOT EXISTS idx_task_dependencies_blocked_id ON task_dependencies(blocked_id);`
+	indexProjectsOwner := `CREATE INDEX IF NOT EXISTS idx_projects_owner_id ON projects(owner_id);`
+	indexTransitionsProject := `CREATE INDEX IF NOT EXISTS idx_workflow_transitions_project_id ON workflow_transitions(project_id);`
+	indexFieldValuesTask := `CREATE INDEX IF NOT EXISTS idx_task_field_values_task_id ON task_field_values(task_id, field_id);`
+	indexFieldValuesText := `CREATE INDEX IF NOT EXISTS idx_task_field_values_text ON task_field_values(field_id, text_value);`
+	indexFieldValuesNumber := `CREATE INDEX IF NOT EXISTS idx_task_field_values_number ON task_field_values(field_id, number_value);`
+	indexWorkLogsTask := `CREATE INDEX IF NOT EXISTS idx_work_logs_task_id ON work_logs(task_id);`
+	indexWorkLogsUser := `CREATE INDEX IF NOT EXISTS idx_work_logs_user_started_at ON work_logs(user_id, started_at);`
+	// at most one running timer per user
+	indexWorkLogsRunning := `CREATE UNIQUE INDEX IF NOT EXISTS idx_work_logs_running ON work_logs(user_id) WHERE ended_at IS NULL;`
+	indexChecklistTask := `CREATE INDEX IF NOT EXISTS idx_checklist_items_task_id ON checklist_items(task_id, position);`
+	indexTemplatesOwner := `CREATE INDEX IF NOT EXISTS idx_task_templates_owner_id ON task_templates(owner_id);`
+	indexTemplatesProject := `CREATE INDEX IF NOT EXISTS idx_task_templates_project_id ON task_templates(project_id);`
+	indexSprintsProject := `CREATE INDEX IF NOT EXISTS idx_sprints_project_id ON sprints(project_id, end_at);`
+	indexSprintTasksSprint := `CREATE INDEX IF NOT EXISTS idx_sprint_tasks_sprint_id ON sprint_tasks(sprint_id);`
+	indexSprintTasksTask := `CREATE INDEX IF NOT EXISTS idx_sprint_tasks_task_id ON sprint_tasks(task_id);`
+	indexStatusHistoryTask := `CREATE INDEX IF NOT EXISTS idx_task_status_history_task_id ON task_status_history(task_id, changed_at);`
+	indexTaskUndoUser := `CREATE INDEX IF NOT EXISTS idx_task_undo_user_id ON task_undo(user_id, created_at);`
+	indexSavedViewsOwner := `CREATE INDEX IF NOT EXISTS idx_saved_views_owner_id ON saved_views(owner_id);`
+	indexSavedViewsProject := `CREATE INDEX IF NOT EXISTS idx_saved_views_project_id ON saved_views(project_id);`
+
 	queries := []string{
 		usersTable,
 		tasksTable,
 		indexUserID,
 		indexParentID,
+		remindersTable,
+		notificationsTable,
+		indexRemindersDue,
+		indexRemindersTask,
+		indexNotificationsUser,
+		dependenciesTable,
+		indexDependenciesBlocked,
+		projectsTable,
+		workflowStatusesTable,
+		indexProjectsOwner,
+		workflowTransitionsTable,
+		statusHistoryTable,
+		indexTransitionsProject,
+		indexStatusHistoryTask,
+		customFieldsTable,
+		taskFieldValuesTable,
+		indexFieldValuesTask,
+		indexFieldValuesText,
+		indexFieldValuesNumber,
+		workLogsTable,
+		indexWorkLogsTask,
+		indexWorkLogsUser,
+		indexWorkLogsRunning,
+		checklistItemsTable,
+		indexChecklistTask,
+		taskTemplatesTable,
+		indexTemplatesOwner,
+		indexTemplatesProject,
+		sprintsTable,
+		sprintTasksTable,
+		indexSprintsProject,
+		indexSprintTasksSprint,
+		indexSprintTasksTask,
+		taskUndoTable,
+		indexTaskUndoUser,
+		taskVersionsTable,
+		savedViewsTable,
+		indexSavedViewsOwner,
+		indexSavedViewsProject,
 	}
 
 	for _, query := range queries {
@@ -93,5 +397,224 @@ func migrate(db *sql.DB) error {
 		}
 	}
 
+	// columns added after the initial schema, applied to existing databases as well
+	columns := []column{
+		{"users", "timezone", "TEXT NOT NULL DEFAULT 'UTC'"},
+		{"tasks", "start_at", "DATETIME"},
+		{"tasks", "due_at", "DATETIME"},
+		{"tasks", "priority", "TEXT NOT NULL DEFAULT 'none'"},
+		{"tasks", "rrule", "TEXT NOT NULL DEFAULT ''"},
+		{"tasks", "timezone", "TEXT NOT NULL DEFAULT ''"},
+		{"tasks", "recurrence_start", "DATETIME"},
+		{"tasks", "copy_sub_tasks", "BOOLEAN NOT NULL DEFAULT 0"},
+		{"tasks", "project_id", "INTEGER REFERENCES projects(id)"},
+		{"tasks", "original_estimate", "INTEGER"},
+		{"tasks", "remaining_estimate", "INTEGER"},
+		{"tasks", "rank", "TEXT NOT NULL DEFAULT ''"},
+		{"tasks", "deleted_at", "DATETIME"},
+		{"tasks", "archived_at", "DATETIME"},
+		{"tasks", "next_occurrence_id", "INTEGER"},
+		{"workflow_statuses", "wip_limit", "INTEGER"},
+		{"workflow_statuses", "enforce_wip_limit", "BOOLEAN NOT NULL DEFAULT 0"},
+	}
+
+	for _, col := range columns {
+		if err := addColumnIfMissing(db, col); err != nil {
+			return err
+		}
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_tasks_user_due_at ON tasks(user_id, due_at);`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_user_status_due_at ON tasks(user_id, status, due_at);`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_user_priority ON tasks(user_id, priority);`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_project_status ON tasks(project_id, status);`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_user_parent_rank ON tasks(user_id, parent_id, rank);`,
+		// the trash index only covers trashed tasks: a full index on deleted_at is chosen for
+		// "deleted_at IS NULL" when there are no statistics and turns subtask walks quadratic
+		`DROP INDEX IF EXISTS idx_tasks_deleted_at;`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_trashed ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;`,
+	}
+
+	for _, query := range indexes {
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+	}
+
+	if err := backfillRanks(db); err != nil {
+		return err
+	}
+
+	return migrateSearchIndex(db)
+}
+
+// backfillRanks gives tasks created before manual ordering a rank, keeping the default
+// newest-first order and placing them after already ranked siblings.
+func backfillRanks(db *sql.DB) error {
+	rows, err := db.Query(`SELECT id, user_id, parent_id FROM tasks WHERE rank = '' ORDER BY user_id, parent_id, created_at DESC, id DESC`)
+	if err != nil {
+		return fmt.Errorf("failed to query unranked tasks: %w", err)
+	}
+
+	type sibling struct {
+		id, userID int64
+		parentID   sql.NullInt64
+	}
+
+	var unranked []sibling
+	for rows.Next() {
+		var s sibling
+		if err := rows.Scan(&s.id, &s.userID, &s.parentID); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan unranked task: %w", err)
+		}
+
+		unranked = append(unranked, s)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read unranked tasks: %w", err)
+	}
+
+	if len(unranked) == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	var last string
+	for i, s := range unranked {
+		if i == 0 || s.userID != unranked[i-1].userID || s.parentID != unranked[i-1].parentID {
+			last = ""
+			query := `SELECT COALESCE(MAX(rank), '') FROM tasks WHERE user_id = ? AND parent_id IS ?`
+			if err := tx.QueryRow(query, s.userID, s.parentID).Scan(&last); err != nil {
+				return fmt.Errorf("failed to read sibling ranks: %w", err)
+			}
+		}
+
+		key, err := rank.Between(last, "")
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(`UPDATE tasks SET rank = ? WHERE id = ?`, key, s.id); err != nil {
+			return fmt.Errorf("failed to rank task: %w", err)
+		}
+
+		last = key
+	}
+
+	return tx.Commit()
+}
+
+// migrateSearchIndex creates the full-text index of task titles and descriptions with the
+// triggers that keep it in sync, and indexes the existing tasks. The index needs SQLite with
+// FTS5, which go-sqlite3 includes with the sqlite_fts5 build tag; without it search is
+// disabled and tasks are stored as before.
+func migrateSearchIndex(db *sql.DB) error {
+	var exists int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'tasks_fts'`).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check search index: %w", err)
+	}
+
+	if exists > 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	// external content table: the text stays in tasks, the index refers to it by rowid
+	indexTable := `
+	CREATE VIRTUAL TABLE tasks_fts USING fts5(
+		title,
+		description,
+		content = 'tasks',
+		content_rowid = 'id',
+		tokenize = 'unicode61 remove_diacritics 2'
+	);`
+	if _, err := tx.Exec(indexTable); err != nil {
+		if strings.Contains(err.Error(), "no such module: fts5") {
+			log.Printf("full-text search is disabled: SQLite was built without FTS5 (build with -tags sqlite_fts5)")
+			return nil
+		}
+
+		return fmt.Errorf("failed to create search index: %w", err)
+	}
+
+	queries := []string{
+		`CREATE TRIGGER tasks_fts_insert AFTER INSERT ON tasks BEGIN
+			INSERT INTO tasks_fts (rowid, title, description) VALUES (new.id, new.title, new.description);
+		END;`,
+		`CREATE TRIGGER tasks_fts_delete AFTER DELETE ON tasks BEGIN
+			INSERT INTO tasks_fts (tasks_fts, rowid, title, description) VALUES ('delete', old.id, old.title, old.description);
+		END;`,
+		`CREATE TRIGGER tasks_fts_update AFTER UPDATE OF title, description ON tasks BEGIN
+			INSERT INTO tasks_fts (tasks_fts, rowid, title, description) VALUES ('delete', old.id, old.title, old.description);
+			INSERT INTO tasks_fts (rowid, title, description) VALUES (new.id, new.title, new.description);
+		END;`,
+		`INSERT INTO tasks_fts (tasks_fts) VALUES ('rebuild');`,
+	}
+	for _, query := range queries {
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("failed to create search index: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+type column struct {
+	table      string
+	name       string
+	definition string
+}
+
+func addColumnIfMissing(db *sql.DB, col column) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", col.table))
+	if err != nil {
+		return fmt.Errorf("failed to read table info for %s: %w", col.table, err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			colType    string
+			notNull    int
+			defaultVal sql.NullString
+			primaryKey int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultVal, &primaryKey); err != nil {
+			return fmt.Errorf("failed to scan table info for %s: %w", col.table, err)
+		}
+
+		if name == col.name {
+			return nil
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read table info for %s: %w", col.table, err)
+	}
+
+	query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", col.table, col.name, col.definition)
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", col.table, col.name, err)
+	}
+
 	return nil
 }
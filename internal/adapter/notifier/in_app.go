@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+	"task-management-backend/pkg/constant"
+)
+
+type InAppNotifier struct {
+	repo ports.NotificationRepository
+}
+
+func NewInAppNotifier(repo ports.NotificationRepository) ports.Notifier {
+	return &InAppNotifier{repo: repo}
+}
+
+func (InAppNotifier) Channel() constant.NotificationChannel {
+	return constant.NotificationChannelInApp
+}
+
+func (n *InAppNotifier) Notify(_ context.Context, event entity.ReminderEvent) error {
+	reminderID := event.Reminder.ID
+	return n.repo.CreateForReminder(&entity.Notification{
+		UserID:     event.Reminder.UserID,
+		TaskID:     event.Reminder.TaskID,
+		ReminderID: &reminderID,
+		Message:    event.Message,
+	})
+}
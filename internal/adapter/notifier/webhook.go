@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+	"task-management-backend/pkg/constant"
+	"task-management-backend/pkg/netguard"
+	"time"
+)
+
+// webhookPayload is the JSON body of a webhook call. It only carries what the reminder is
+// about, not the task's subtasks or other data.
+type webhookPayload struct {
+	ReminderID int64      `json:"reminder_id"`
+	TaskID     int64      `json:"task_id"`
+	Title      string     `json:"title"`
+	DueAt      *time.Time `json:"due_at,omitempty"`
+}
+
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+// NewWebhookNotifier returns a notifier whose requests, including redirects, only connect
+// to public addresses. Webhook URLs are checked when reminders are created, but a host can
+// resolve to another address by the time a reminder fires. Proxies are not used, since the
+// dialer would then check the proxy instead of the webhook.
+func NewWebhookNotifier(timeout time.Duration) ports.Notifier {
+	transport := &http.Transport{
+		DialContext:         netguard.Dialer(timeout).DialContext,
+		TLSHandshakeTimeout: timeout,
+		MaxIdleConns:        10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+
+	return &WebhookNotifier{client: &http.Client{Timeout: timeout, Transport: transport}}
+}
+
+func (WebhookNotifier) Channel() constant.NotificationChannel {
+	return constant.NotificationChannelWebhook
+}
+
+// Notify posts the reminder and task IDs, the title and the due date as JSON. The
+// X-Reminder-ID header lets receivers drop
+// duplicates if a delivery is retried after a crash.
+func (n *WebhookNotifier) Notify(ctx context.Context, event entity.ReminderEvent) error {
+	if event.Reminder.WebhookURL == "" {
+		return fmt.Errorf("reminder has no webhook url")
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		ReminderID: event.Reminder.ID,
+		TaskID:     event.Task.ID,
+		Title:      event.Task.Title,
+		DueAt:      event.Task.DueAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, event.Reminder.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Reminder-ID", strconv.FormatInt(event.Reminder.ID, 10))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
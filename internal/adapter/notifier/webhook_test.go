@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"task-management-backend/internal/domain/entity"
+	"testing"
+	"time"
+)
+
+func TestWebhookPayload(t *testing.T) {
+	var body map[string]any
+	var reminderID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("body %s: %v", data, err)
+		}
+
+		reminderID = r.Header.Get("X-Reminder-ID")
+	}))
+	defer server.Close()
+
+	// the test server listens on loopback, which the guarded transport refuses
+	n := &WebhookNotifier{client: server.Client()}
+	due := time.Date(2026, time.November, 1, 9, 0, 0, 0, time.UTC)
+	err := n.Notify(context.Background(), entity.ReminderEvent{
+		Reminder: entity.Reminder{ID: 7, TaskID: 1, UserID: 3, WebhookURL: server.URL},
+		Task:     entity.ReminderTask{ID: 1, Title: "Release", DueAt: &due},
+		Message:  "Reminder: Release",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{"reminder_id": 7.0, "task_id": 1.0, "title": "Release", "due_at": "2026-11-01T09:00:00Z"}
+	if len(body) != len(want) {
+		t.Errorf("body = %v, want %v", body, want)
+	}
+
+	for key, value := range want {
+		if body[key] != value {
+			t.Errorf("%s = %v, want %v", key, body[key], value)
+		}
+	}
+
+	if reminderID != "7" {
+		t.Errorf("X-Reminder-ID = %q, want 7", reminderID)
+	}
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"task-management-backend/internal/domain/entity"
 	"task-management-backend/pkg/constant"
@@ -71,6 +72,18 @@ func (c *TaskCache) Invalidate(userID int64, statuses []constant.TaskStatus) {
 	}
 }
 
+func (c *TaskCache) InvalidateUser(userID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prefix := fmt.Sprintf("%d:", userID)
+	for key := range c.tasks {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.tasks, key)
+		}
+	}
+}
+
 func (c *TaskCache) cleanupExpired() {
 	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
@@ -0,0 +1,72 @@
+package entity
+
+import "task-management-backend/pkg/constant"
+
+// BoardQuery holds the raw parameters accepted by GET /api/board.
+type BoardQuery struct {
+	ProjectID *int64
+	// Status loads a single column, e.g. to fetch its next page.
+	Status constant.TaskStatus
+	// Swimlane is "priority" or "cf.<key>" for a select custom field.
+	Swimlane string
+	Limit    int
+	Offset   int
+}
+
+// Swimlane groups board cards by priority or a select custom field.
+type Swimlane struct {
+	Kind string
+	// Field is set for custom field swimlanes.
+	Field *CustomField
+}
+
+const (
+	SwimlanePriority    = "priority"
+	SwimlaneCustomField = "custom_field"
+)
+
+// BoardFilter is the resolved form of BoardQuery used by the repository. Offset and Limit
+// page every cell, a cell being one status within one swimlane.
+type BoardFilter struct {
+	UserID    int64
+	ProjectID *int64
+	Statuses  []constant.TaskStatus
+	Swimlane  *Swimlane
+	Offset    int
+	Limit     int
+}
+
+// BoardCell is one page of the top-level tasks with a status and swimlane value. Lane is
+// nil without swimlanes and for tasks that have no value.
+type BoardCell struct {
+	Status constant.TaskStatus
+	Lane   *string
+	Count  int
+	Tasks  []Task
+}
+
+type Board struct {
+	ProjectID *int64        `json:"project_id,omitempty"`
+	Swimlane  string        `json:"swimlane,omitempty"`
+	Columns   []BoardColumn `json:"columns"`
+	// Swimlanes repeat the columns for each swimlane value; cards are only listed here
+	// when swimlanes are requested.
+	Swimlanes []BoardSwimlane `json:"swimlanes,omitempty"`
+}
+
+type BoardColumn struct {
+	Status      constant.TaskStatus     `json:"status"`
+	Category    constant.StatusCategory `json:"category"`
+	Count       int                     `json:"count"`
+	WIPLimit    *int                    `json:"wip_limit,omitempty"`
+	WIPExceeded bool                    `json:"wip_exceeded,omitempty"`
+	Tasks       []Task                  `json:"tasks,omitempty"`
+	// NextOffset is set when the column has more tasks than the page holds.
+	NextOffset *int `json:"next_offset,omitempty"`
+}
+
+type BoardSwimlane struct {
+	// Key is the swimlane value, or null for tasks without one.
+	Key     *string       `json:"key"`
+	Columns []BoardColumn `json:"columns"`
+}
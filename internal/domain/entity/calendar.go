@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// DateLayout is the format of calendar dates in requests and responses.
+const DateLayout = "2006-01-02"
+
+// UserLocation returns the timezone configured for the user, falling back to UTC when the
+// lookup failed or the timezone is unset or unknown. It takes the results of a user lookup
+// directly, e.g. UserLocation(userRepo.GetByID(userID)).
+func UserLocation(user *User, err error) *time.Location {
+	if err != nil || user == nil || user.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// StartOfDay returns midnight of t's calendar day in t's location.
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// ChecklistItem is a lightweight step inside a task, kept in Position order.
+type ChecklistItem struct {
+	ID        int64     `json:"id" db:"id"`
+	TaskID    int64     `json:"task_id" db:"task_id"`
+	Title     string    `json:"title" db:"title"`
+	Checked   bool      `json:"checked" db:"checked"`
+	Position  int       `json:"position" db:"position"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type ChecklistSummary struct {
+	Checked int `json:"checked"`
+	Total   int `json:"total"`
+}
+
+// CreateChecklistItemRequest appends an item, or inserts it at Position when given.
+type CreateChecklistItemRequest struct {
+	Title    string `json:"title" binding:"required"`
+	Position *int   `json:"position,omitempty"`
+}
+
+// UpdateChecklistItemRequest renames, checks or moves an item. Position is the item's new
+// zero-based index.
+type UpdateChecklistItemRequest struct {
+	Title    *string `json:"title,omitempty"`
+	Checked  *bool   `json:"checked,omitempty"`
+	Position *int    `json:"position,omitempty"`
+}
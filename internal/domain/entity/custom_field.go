@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// CustomField is a typed field defined by a project. Tasks of the project expose its values
+// under custom_fields, keyed by Key.
+type CustomField struct {
+	ID        int64                    `json:"id" db:"id"`
+	ProjectID int64                    `json:"project_id" db:"project_id"`
+	Key       string                   `json:"key" db:"key"`
+	Name      string                   `json:"name" db:"name"`
+	Type      constant.CustomFieldType `json:"type" db:"type"`
+	// Options lists the allowed values of select fields in display order.
+	Options   []string  `json:"options,omitempty" db:"options"`
+	Required  bool      `json:"required" db:"required"`
+	Position  int       `json:"position" db:"position"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// FieldValue is one stored value of a custom field. Select values also keep the option's
+// position in Number so they sort in option order, and multi select fields store one value
+// per selected option.
+type FieldValue struct {
+	FieldID int64    `db:"field_id"`
+	Text    *string  `db:"text_value"`
+	Number  *float64 `db:"number_value"`
+}
+
+// Display converts stored values to the JSON form returned on tasks.
+func (f CustomField) Display(values []FieldValue) any {
+	if len(values) == 0 {
+		return nil
+	}
+
+	switch f.Type {
+	case constant.CustomFieldMultiSelect:
+		options := make([]string, 0, len(values))
+		for _, v := range values {
+			if v.Text != nil {
+				options = append(options, *v.Text)
+			}
+		}
+
+		return options
+	case constant.CustomFieldNumber:
+		return values[0].Number
+	case constant.CustomFieldUser:
+		if values[0].Number == nil {
+			return nil
+		}
+
+		return int64(*values[0].Number)
+	default:
+		return values[0].Text
+	}
+}
+
+func (f CustomField) OptionIndex(option string) int {
+	for i, o := range f.Options {
+		if o == option {
+			return i
+		}
+	}
+
+	return -1
+}
+
+type CreateCustomFieldRequest struct {
+	Key      string   `json:"key" binding:"required"`
+	Name     string   `json:"name" binding:"required"`
+	Type     string   `json:"type" binding:"required"`
+	Options  []string `json:"options,omitempty"`
+	Required bool     `json:"required,omitempty"`
+}
+
+// UpdateCustomFieldRequest changes a field's name, options or required flag. The key and
+// type cannot change once values exist.
+type UpdateCustomFieldRequest struct {
+	Name     *string  `json:"name,omitempty"`
+	Options  []string `json:"options,omitempty"`
+	Required *bool    `json:"required,omitempty"`
+}
+
+// CustomFieldFilter matches tasks by a custom field value. Op is "eq", "lt", "lte", "gt" or "gte";
+// eq on a multi select field matches tasks that have the option selected.
+type CustomFieldFilter struct {
+	Field CustomField
+	Op    string
+	Value FieldValue
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PageRequest asks for one page of root tasks. An empty Cursor starts at the first page.
+type PageRequest struct {
+	Limit  int
+	Cursor string
+	// WithTotal also counts every root task matching the filter.
+	WithTotal bool
+}
+
+type TaskPage struct {
+	Tasks []Task `json:"tasks"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      *int   `json:"total,omitempty"`
+}
+
+// TaskCursor marks the last root task of a page. Keys are the task's values of the sort
+// terms, ending with its ID, as SQLite type and text so that the next page compares them
+// exactly as stored. Sort is the SortSpec the cursor was made for.
+type TaskCursor struct {
+	Sort string      `json:"s"`
+	ID   int64       `json:"id"`
+	Keys []CursorKey `json:"k"`
+}
+
+type CursorKey struct {
+	Type  string `json:"t"`
+	Value string `json:"v,omitempty"`
+}
+
+func (c TaskCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeTaskCursor(value string) (*TaskCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	var cursor TaskCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || len(cursor.Keys) == 0 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return &cursor, nil
+}
+
+// SortSpec is the canonical form of a sort, e.g. "-priority,due_at".
+func SortSpec(sort []SortField) string {
+	parts := make([]string, 0, len(sort))
+	for _, s := range sort {
+		field := s.Field
+		if s.Desc {
+			field = "-" + field
+		}
+
+		parts = append(parts, field)
+	}
+
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+type Project struct {
+	ID        int64     `json:"id" db:"id"`
+	OwnerID   int64     `json:"owner_id" db:"owner_id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type WorkflowStatus struct {
+	ID        int64                   `json:"id" db:"id"`
+	ProjectID int64                   `json:"project_id" db:"project_id"`
+	Name      constant.TaskStatus     `json:"name" db:"name"`
+	Category  constant.StatusCategory `json:"category" db:"category"`
+	Position  int                     `json:"position" db:"position"`
+	// WIPLimit caps the top-level tasks in the status. Moving a task into a full status
+	// is rejected when EnforceWIPLimit is set and only warned about otherwise.
+	WIPLimit        *int `json:"wip_limit,omitempty" db:"wip_limit"`
+	EnforceWIPLimit bool `json:"enforce_wip_limit,omitempty" db:"enforce_wip_limit"`
+}
+
+// Workflow is the ordered set of statuses a task can take. Tasks outside a project use
+// DefaultWorkflow.
+type Workflow struct {
+	ProjectID *int64           `json:"project_id,omitempty"`
+	Statuses  []WorkflowStatus `json:"statuses"`
+}
+
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		Statuses: []WorkflowStatus{
+			{Name: constant.TaskStatusTodo, Category: constant.StatusCategoryTodo, Position: 0},
+			{Name: constant.TaskStatusInProgress, Category: constant.StatusCategoryActive, Position: 1},
+			{Name: constant.TaskStatusDone, Category: constant.StatusCategoryDone, Position: 2},
+		},
+	}
+}
+
+func (w Workflow) Has(status constant.TaskStatus) bool {
+	_, ok := w.Category(status)
+	return ok
+}
+
+func (w Workflow) Category(status constant.TaskStatus) (constant.StatusCategory, bool) {
+	s, ok := w.Status(status)
+	return s.Category, ok
+}
+
+func (w Workflow) Status(name constant.TaskStatus) (WorkflowStatus, bool) {
+	for _, s := range w.Statuses {
+		if s.Name == name {
+			return s, true
+		}
+	}
+
+	return WorkflowStatus{}, false
+}
+
+func (w Workflow) IsDone(status constant.TaskStatus) bool {
+	category, _ := w.Category(status)
+	return category == constant.StatusCategoryDone
+}
+
+// Initial is the status new tasks start in: the first status of the workflow.
+func (w Workflow) Initial() constant.TaskStatus {
+	if len(w.Statuses) == 0 {
+		return constant.TaskStatusTodo
+	}
+
+	return w.Statuses[0].Name
+}
+
+func (w Workflow) StatusesIn(category constant.StatusCategory) []constant.TaskStatus {
+	var statuses []constant.TaskStatus
+	for _, s := range w.Statuses {
+		if s.Category == category {
+			statuses = append(statuses, s.Name)
+		}
+	}
+
+	return statuses
+}
+
+type CreateProjectRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type WorkflowStatusInput struct {
+	Name            string `json:"name" binding:"required"`
+	Category        string `json:"category" binding:"required"`
+	WIPLimit        *int   `json:"wip_limit,omitempty"`
+	EnforceWIPLimit bool   `json:"enforce_wip_limit,omitempty"`
+}
+
+type UpdateWorkflowRequest struct {
+	Statuses []WorkflowStatusInput `json:"statuses" binding:"required"`
+}
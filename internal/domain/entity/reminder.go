@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+type Reminder struct {
+	ID     int64 `json:"id" db:"id"`
+	TaskID int64 `json:"task_id" db:"task_id"`
+	UserID int64 `json:"user_id" db:"user_id"`
+	// OffsetMinutes is set for reminders relative to the task due date.
+	OffsetMinutes *int                         `json:"offset_minutes,omitempty" db:"offset_minutes"`
+	RemindAt      *time.Time                   `json:"remind_at,omitempty" db:"remind_at"`
+	Channel       constant.NotificationChannel `json:"channel" db:"channel"`
+	WebhookURL    string                       `json:"webhook_url,omitempty" db:"webhook_url"`
+	Status        constant.ReminderStatus      `json:"status" db:"status"`
+	Attempts      int                          `json:"attempts" db:"attempts"`
+	SentAt        *time.Time                   `json:"sent_at,omitempty" db:"sent_at"`
+	CreatedAt     time.Time                    `json:"created_at" db:"created_at"`
+}
+
+type Notification struct {
+	ID         int64      `json:"id" db:"id"`
+	UserID     int64      `json:"user_id" db:"user_id"`
+	TaskID     int64      `json:"task_id" db:"task_id"`
+	ReminderID *int64     `json:"reminder_id,omitempty" db:"reminder_id"`
+	Message    string     `json:"message" db:"message"`
+	ReadAt     *time.Time `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ReminderTask is the part of a task a firing reminder needs.
+type ReminderTask struct {
+	ID    int64      `json:"id"`
+	Title string     `json:"title"`
+	DueAt *time.Time `json:"due_at,omitempty"`
+}
+
+// ReminderEvent is handed to a notifier when a reminder fires.
+type ReminderEvent struct {
+	Reminder Reminder
+	Task     ReminderTask
+	Message  string
+}
+
+// CreateReminderRequest sets either an offset before the due date or an absolute time.
+type CreateReminderRequest struct {
+	BeforeMinutes *int       `json:"before_minutes,omitempty"`
+	At            *time.Time `json:"at,omitempty"`
+	Channel       string     `json:"channel,omitempty"`
+	WebhookURL    string     `json:"webhook_url,omitempty"`
+}
+
+type SnoozeReminderRequest struct {
+	Minutes int `json:"minutes" binding:"required"`
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "task-management-backend/pkg/constant"
+
+// SearchQuery holds the parameters of GET /api/search. Q is the text typed by the user:
+// words match as prefixes and double quoted text as a phrase.
+type SearchQuery struct {
+	Q               string
+	ProjectID       *int64
+	IncludeArchived bool
+	Limit           int
+	Offset          int
+}
+
+// SearchHit is a task matching a search. Title and Snippet are HTML-escaped and mark the
+// matched words with <mark> and </mark>; Snippet is the part of the description around the
+// best match.
+type SearchHit struct {
+	ID        int64                 `json:"id"`
+	ProjectID *int64                `json:"project_id,omitempty"`
+	ParentID  *int64                `json:"parent_id,omitempty"`
+	Status    constant.TaskStatus   `json:"status"`
+	Priority  constant.TaskPriority `json:"priority"`
+	Title     string                `json:"title"`
+	Snippet   string                `json:"snippet"`
+	// Score is the relevance of the hit; higher is better.
+	Score float64 `json:"score"`
+	// Path lists the task's ancestors, root first.
+	Path []TaskPathItem `json:"path"`
+}
+
+type SearchResult struct {
+	Results []SearchHit `json:"results"`
+	// NextOffset is the offset of the next page, if there is one.
+	NextOffset *int `json:"next_offset,omitempty"`
+}
+
+// TaskPathItem is one ancestor in the path of a task.
+type TaskPathItem struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
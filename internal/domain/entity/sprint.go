@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// Sprint is a sprint or milestone of a project. Sprints run from StartAt to EndAt; a
+// milestone's EndAt is its target date and StartAt is optional.
+type Sprint struct {
+	ID        int64               `json:"id" db:"id"`
+	ProjectID int64               `json:"project_id" db:"project_id"`
+	Kind      constant.SprintKind `json:"kind" db:"kind"`
+	Name      string              `json:"name" db:"name"`
+	Goal      string              `json:"goal,omitempty" db:"goal"`
+	StartAt   *time.Time          `json:"start_at,omitempty" db:"start_at"`
+	EndAt     time.Time           `json:"end_at" db:"end_at"`
+	ClosedAt  *time.Time          `json:"closed_at,omitempty" db:"closed_at"`
+	CreatedAt time.Time           `json:"created_at" db:"created_at"`
+	// TaskIDs lists the current members; it is filled in when a single sprint is returned.
+	TaskIDs []int64 `json:"task_ids,omitempty" db:"-"`
+}
+
+// SprintMember is a task's membership of a sprint. RemovedAt is set when the task left the
+// sprint before it closed, and Outcome when the sprint closed.
+type SprintMember struct {
+	SprintID    int64                  `json:"sprint_id" db:"sprint_id"`
+	TaskID      int64                  `json:"task_id" db:"task_id"`
+	AddedAt     time.Time              `json:"added_at" db:"added_at"`
+	RemovedAt   *time.Time             `json:"removed_at,omitempty" db:"removed_at"`
+	CarriedOver bool                   `json:"carried_over,omitempty" db:"carried_over"`
+	Outcome     constant.SprintOutcome `json:"outcome,omitempty" db:"outcome"`
+	// Status is the task's current status and Points its story points, if requested.
+	Status constant.TaskStatus `json:"-" db:"status"`
+	Points float64             `json:"-" db:"-"`
+}
+
+type CreateSprintRequest struct {
+	Name    string     `json:"name" binding:"required"`
+	Kind    string     `json:"kind,omitempty"`
+	Goal    string     `json:"goal,omitempty"`
+	StartAt *time.Time `json:"start_at,omitempty"`
+	EndAt   *time.Time `json:"end_at" binding:"required"`
+}
+
+type UpdateSprintRequest struct {
+	Name    *string    `json:"name,omitempty"`
+	Goal    *string    `json:"goal,omitempty"`
+	StartAt *time.Time `json:"start_at,omitempty"`
+	EndAt   *time.Time `json:"end_at,omitempty"`
+}
+
+type SprintTasksRequest struct {
+	TaskIDs []int64 `json:"task_ids" binding:"required"`
+}
+
+// CloseSprintRequest says where unfinished tasks go: another open sprint of the project,
+// or back to the backlog when CarryOverTo is not set.
+type CloseSprintRequest struct {
+	CarryOverTo *int64 `json:"carry_over_to,omitempty"`
+}
+
+// SprintMetricQuery selects the unit of burndown and velocity reports. Points is the key of
+// a number custom field holding story points; tasks are counted without it.
+type SprintMetricQuery struct {
+	Points string `form:"points"`
+	// Count is the number of closed sprints velocity is computed over.
+	Count int `form:"count"`
+}
+
+// BurndownPoint is the state of a sprint at the end of a day in the user's timezone.
+// Scope minus Completed is the remaining work of the burndown; Scope and Completed
+// together are the burnup.
+type BurndownPoint struct {
+	Date      string  `json:"date"`
+	Scope     float64 `json:"scope"`
+	Completed float64 `json:"completed"`
+	Remaining float64 `json:"remaining"`
+	Ideal     float64 `json:"ideal"`
+}
+
+type Burndown struct {
+	SprintID int64           `json:"sprint_id"`
+	Unit     string          `json:"unit"`
+	Timezone string          `json:"timezone"`
+	Series   []BurndownPoint `json:"series"`
+}
+
+type SprintVelocity struct {
+	SprintID  int64     `json:"sprint_id"`
+	Name      string    `json:"name"`
+	ClosedAt  time.Time `json:"closed_at"`
+	Committed float64   `json:"committed"`
+	Completed float64   `json:"completed"`
+}
+
+type Velocity struct {
+	ProjectID int64            `json:"project_id"`
+	Unit      string           `json:"unit"`
+	Sprints   []SprintVelocity `json:"sprints"`
+	// Average is the mean completed work per sprint.
+	Average float64 `json:"average"`
+}
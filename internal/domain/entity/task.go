@@ -5,44 +5,271 @@ import (
 	"time"
 )
 
+// MaxTreeDepth bounds the subtask levels loaded below a task, so a corrupted parent chain
+// that loops cannot recurse forever.
+const MaxTreeDepth = 1000
+
 type User struct {
 	ID        int64     `json:"id" db:"id"`
 	Username  string    `json:"username" db:"username"`
 	Password  string    `json:"-" db:"password"`
+	Timezone  string    `json:"timezone" db:"timezone"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
 type Task struct {
-	ID          int64               `json:"id" db:"id"`
-	UserID      int64               `json:"user_id" db:"user_id"`
-	ParentID    *int64              `json:"parent_id,omitempty" db:"parent_id"`
-	Title       string              `json:"title" db:"title"`
-	Description string              `json:"description" db:"description"`
-	Status      constant.TaskStatus `json:"status" db:"status"`
-	CreatedAt   time.Time           `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time           `json:"updated_at" db:"updated_at"`
-	SubTasks    []Task              `json:"sub_tasks,omitempty" db:"-"`
+	ID          int64                 `json:"id" db:"id"`
+	UserID      int64                 `json:"user_id" db:"user_id"`
+	ParentID    *int64                `json:"parent_id,omitempty" db:"parent_id"`
+	ProjectID   *int64                `json:"project_id,omitempty" db:"project_id"`
+	Title       string                `json:"title" db:"title"`
+	Description string                `json:"description" db:"description"`
+	Status      constant.TaskStatus   `json:"status" db:"status"`
+	Priority    constant.TaskPriority `json:"priority" db:"priority"`
+	StartAt     *time.Time            `json:"start_at,omitempty" db:"start_at"`
+	DueAt       *time.Time            `json:"due_at,omitempty" db:"due_at"`
+	// RRule is an RFC 5545 recurrence rule; completing the task creates the next occurrence.
+	RRule           string     `json:"rrule,omitempty" db:"rrule"`
+	Timezone        string     `json:"timezone,omitempty" db:"timezone"`
+	RecurrenceStart *time.Time `json:"recurrence_start,omitempty" db:"recurrence_start"`
+	CopySubTasks    bool       `json:"copy_sub_tasks,omitempty" db:"copy_sub_tasks"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+	SubTasks        []Task     `json:"sub_tasks,omitempty" db:"-"`
+	// Path lists the task's ancestors, root first; it is set on request.
+	Path []TaskPathItem `json:"path,omitempty" db:"-"`
+	// SubTaskCount is set when the subtasks were not loaded, e.g. below the depth of a tree read.
+	SubTaskCount *int `json:"sub_task_count,omitempty" db:"-"`
+	// Truncated is set on the root of a whole-tree read that stopped at MaxTreeDepth with
+	// subtasks left out.
+	Truncated bool `json:"truncated,omitempty" db:"-"`
+	// BlockedBy lists tasks that must be done before this one; Blocking is the reverse.
+	BlockedBy []int64 `json:"blocked_by,omitempty" db:"-"`
+	Blocking  []int64 `json:"blocking,omitempty" db:"-"`
+	// NextOccurrence is set on the response that completes a recurring task.
+	NextOccurrence *Task `json:"next_occurrence,omitempty" db:"-"`
+	// CustomFields holds the values of the project's custom fields, keyed by field key.
+	CustomFields map[string]any `json:"custom_fields,omitempty" db:"-"`
+	// Estimates and logged time are in seconds. TotalTimeSpent includes all subtasks.
+	OriginalEstimate  *int64 `json:"original_estimate_seconds,omitempty" db:"original_estimate"`
+	RemainingEstimate *int64 `json:"remaining_estimate_seconds,omitempty" db:"remaining_estimate"`
+	TimeSpent         int64  `json:"time_spent_seconds,omitempty" db:"-"`
+	TotalTimeSpent    int64  `json:"total_time_spent_seconds,omitempty" db:"-"`
+	// Checklist summarises the task's checklist items; it is omitted when there are none.
+	Checklist *ChecklistSummary `json:"checklist,omitempty" db:"-"`
+	// Rank orders the task among its siblings for sort=manual; see package rank.
+	Rank string `json:"rank" db:"rank"`
+	// DeletedAt is set while the task is in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	// ArchivedAt is set while the task is archived; archived tasks are left out of lists.
+	ArchivedAt *time.Time `json:"archived_at,omitempty" db:"archived_at"`
+	// Warnings are set on update responses, e.g. when a move exceeds a WIP limit.
+	Warnings []string `json:"warnings,omitempty" db:"-"`
+	// UndoToken is set on mutation responses and reverts the mutation through POST /api/undo.
+	UndoToken *int64 `json:"undo_token,omitempty" db:"-"`
 }
 
 type CreateTaskRequest struct {
 	Title       string `json:"title" binding:"required"`
 	Description string `json:"description,omitempty"`
 	ParentID    *int64 `json:"parent_id,omitempty"`
+	// ProjectID is inherited from the parent for subtasks.
+	ProjectID *int64     `json:"project_id,omitempty"`
+	Priority  string     `json:"priority,omitempty"`
+	StartAt   *time.Time `json:"start_at,omitempty"`
+	DueAt     *time.Time `json:"due_at,omitempty"`
+	RRule     string     `json:"rrule,omitempty"`
+	Timezone  string     `json:"timezone,omitempty"`
+	// CopySubTasks copies the subtask tree into each new occurrence.
+	CopySubTasks bool `json:"copy_sub_tasks,omitempty"`
+	// CustomFields sets project custom field values by field key.
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+	// OriginalEstimate also sets the remaining estimate unless one is given.
+	OriginalEstimate  *int64 `json:"original_estimate_seconds,omitempty"`
+	RemainingEstimate *int64 `json:"remaining_estimate_seconds,omitempty"`
 }
 
 type UpdateTaskRequest struct {
 	Title       *string `json:"title,omitempty"`
 	Description *string `json:"description,omitempty"`
 	Status      *string `json:"status,omitempty"`
-	ParentID    *int64  `json:"parent_id,omitempty"`
+	// StatusReason explains the status change; some transitions require one.
+	StatusReason *string    `json:"status_reason,omitempty"`
+	ParentID     *int64     `json:"parent_id,omitempty"`
+	Priority     *string    `json:"priority,omitempty"`
+	StartAt      *time.Time `json:"start_at,omitempty"`
+	DueAt        *time.Time `json:"due_at,omitempty"`
+	ClearStartAt bool       `json:"clear_start_at,omitempty"`
+	ClearDueAt   bool       `json:"clear_due_at,omitempty"`
+	// RRule set to an empty string stops the recurrence.
+	RRule        *string `json:"rrule,omitempty"`
+	Timezone     *string `json:"timezone,omitempty"`
+	CopySubTasks *bool   `json:"copy_sub_tasks,omitempty"`
+	// CustomFields sets the listed custom field values; a null value clears the field.
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+	// Estimates are in seconds; 0 clears an estimate.
+	OriginalEstimate  *int64 `json:"original_estimate_seconds,omitempty"`
+	RemainingEstimate *int64 `json:"remaining_estimate_seconds,omitempty"`
+}
+
+// TaskQuery holds the raw list parameters accepted by GET /api/tasks.
+// Dates are kept as strings so they can be resolved in the user's timezone.
+type TaskQuery struct {
+	ProjectID *int64
+	// SprintID lists the current tasks of a sprint or milestone.
+	SprintID *int64
+	Status   constant.TaskStatus
+	// StatusCategory matches every workflow status in the category.
+	StatusCategory string
+	Overdue        bool
+	DueToday       bool
+	DueBefore      string
+	DueAfter       string
+	// Sort is a comma separated list of fields, each optionally prefixed with "-" for descending order.
+	Sort string
+	// CustomFields maps "key" or "key.gte" / "key.lte" to the raw filter value.
+	CustomFields map[string]string
+	// IncludeArchived also lists archived tasks.
+	IncludeArchived bool
+	// Filter is an expression of the filter language, see package query.
+	Filter string
+}
+
+// SortField is a single validated sort key.
+type SortField struct {
+	Field string
+	Desc  bool
+	// CustomField is set when sorting by a project custom field.
+	CustomField *CustomField
+}
+
+// SortableTaskFields are the keys accepted by the sort parameter. Priority sorts the most
+// urgent tasks first in ascending order; tasks without a date always sort last.
+var SortableTaskFields = map[string]bool{
+	"priority":   true,
+	"due_at":     true,
+	"start_at":   true,
+	"created_at": true,
+	"updated_at": true,
+	"title":      true,
+	"status":     true,
+	"manual":     true,
+}
+
+// TaskFilter is the resolved form of TaskQuery used by the repository.
+// DueFrom is inclusive and DueUntil is exclusive.
+type TaskFilter struct {
+	UserID    int64
+	ProjectID *int64
+	SprintID  *int64
+	Status    constant.TaskStatus
+	// Statuses matches any of the listed statuses, e.g. all statuses of a category.
+	Statuses []constant.TaskStatus
+	// DoneStatuses are the statuses that count as finished for the overdue filter.
+	DoneStatuses []constant.TaskStatus
+	// DueFrom, DueUntil and OverdueAt match a root task when it or any task in its tree
+	// matches all of them.
+	DueFrom  *time.Time
+	DueUntil *time.Time
+	// OverdueAt matches unfinished tasks whose due date is before this instant.
+	OverdueAt *time.Time
+	// Sort applies to root tasks and to every level of subtasks.
+	Sort            []SortField
+	CustomFields    []CustomFieldFilter
+	IncludeArchived bool
+	// Expr is the resolved filter expression, if any.
+	Expr *FilterExpr
+}
+
+// FilterExpr is a resolved expression of the filter parameter. Exactly one of And, Or, Not
+// and Condition is set.
+type FilterExpr struct {
+	And       []FilterExpr
+	Or        []FilterExpr
+	Not       *FilterExpr
+	Condition *FilterCondition
+}
+
+// FilterCondition compares a task column, or a custom field when CustomField is set.
+// Field is title, description, status, priority, project_id, due_at, start_at, created_at
+// or updated_at. Op is eq, ne, lt, lte, gt, gte, contains, in, null or not_null; in
+// matches any of Values, the other comparisons use Value. ne also matches empty columns.
+type FilterCondition struct {
+	Field       string
+	Op          string
+	Value       any
+	Values      []any
+	CustomField *CustomFieldFilter
+}
+
+func (f TaskFilter) HasDateConstraints() bool {
+	return f.DueFrom != nil || f.DueUntil != nil || f.OverdueAt != nil
+}
+
+// IsCacheable reports whether the result can be stored under the user/status cache key.
+func (f TaskFilter) IsCacheable() bool {
+	return !f.HasDateConstraints() && len(f.Sort) == 0 && f.ProjectID == nil && f.SprintID == nil && len(f.Statuses) == 0 && len(f.CustomFields) == 0 && !f.IncludeArchived && f.Expr == nil
 }
 
 type LoginRequest struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	Timezone string `json:"timezone,omitempty"`
 }
 
 type LoginResponse struct {
 	Token  string `json:"token"`
 	UserID int64  `json:"user_id"`
 }
+
+type RecurrencePreviewQuery struct {
+	RRule    string `form:"rrule" binding:"required"`
+	Start    string `form:"start" binding:"required"`
+	Timezone string `form:"timezone"`
+	Count    int    `form:"count"`
+}
+
+// MoveTaskRequest places a task in the manual order. Before names the sibling the task
+// moves in front of and After the one it follows; with neither the task moves to the top.
+type MoveTaskRequest struct {
+	// ParentID moves the task under another parent; 0 makes it a top-level task.
+	ParentID *int64 `json:"parent_id,omitempty"`
+	Before   *int64 `json:"before,omitempty"`
+	After    *int64 `json:"after,omitempty"`
+}
+
+// CloneTaskRequest selects what POST /api/tasks/:id/clone copies. Subtasks and checklists
+// are included unless turned off.
+type CloneTaskRequest struct {
+	Title *string `json:"title,omitempty"`
+	// ParentID nests the clone under another task; 0 makes it a top-level task. The
+	// clone keeps the source's parent when neither this nor ProjectID is set.
+	ParentID          *int64 `json:"parent_id,omitempty"`
+	ProjectID         *int64 `json:"project_id,omitempty"`
+	IncludeSubTasks   *bool  `json:"include_sub_tasks,omitempty"`
+	IncludeChecklists *bool  `json:"include_checklists,omitempty"`
+	// IncludeLabels and IncludeAttachments are accepted only to reject them: tasks have no
+	// labels or attachments to copy.
+	IncludeLabels      *bool `json:"include_labels,omitempty"`
+	IncludeAttachments *bool `json:"include_attachments,omitempty"`
+	// ResetStatuses starts every cloned task in the workflow's initial status.
+	ResetStatuses bool `json:"reset_statuses,omitempty"`
+}
+
+// CloneOptions is a clone request resolved against the source task and target project.
+type CloneOptions struct {
+	// Title replaces the root's title when set.
+	Title        string
+	ParentID     *int64
+	ProjectID    *int64
+	SubTasks     bool
+	Checklists   bool
+	CustomFields bool
+	// Status, when set, replaces every cloned status and resets checklists and remaining
+	// estimates.
+	Status constant.TaskStatus
+}
+
+type AddDependencyRequest struct {
+	BlockedBy int64 `json:"blocked_by" binding:"required"`
+}
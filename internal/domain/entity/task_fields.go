@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// taskFieldNames are the JSON keys of Task, which sparse fieldsets select from.
+var taskFieldNames = func() map[string]bool {
+	names := map[string]bool{}
+	t := reflect.TypeOf(Task{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+
+	return names
+}()
+
+func ValidateTaskFields(fields []string) error {
+	for _, field := range fields {
+		if !taskFieldNames[field] {
+			return fmt.Errorf("unknown task field: %s", field)
+		}
+	}
+
+	return nil
+}
+
+// SelectTaskFields returns the task's JSON object reduced to its id and the given fields.
+// Selected subtasks are reduced the same way.
+func SelectTaskFields(task Task, fields []string) (map[string]any, error) {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	selected := map[string]any{"id": task.ID}
+	for _, field := range fields {
+		if field == "sub_tasks" && len(task.SubTasks) > 0 {
+			subTasks, err := SelectTasksFields(task.SubTasks, fields)
+			if err != nil {
+				return nil, err
+			}
+
+			selected[field] = subTasks
+		} else if value, ok := object[field]; ok {
+			selected[field] = value
+		}
+	}
+
+	return selected, nil
+}
+
+func SelectTasksFields(tasks []Task, fields []string) ([]map[string]any, error) {
+	selected := make([]map[string]any, 0, len(tasks))
+	for _, task := range tasks {
+		object, err := SelectTaskFields(task, fields)
+		if err != nil {
+			return nil, err
+		}
+
+		selected = append(selected, object)
+	}
+
+	return selected, nil
+}
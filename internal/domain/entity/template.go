@@ -0,0 +1,58 @@
+package entity
+
+import "time"
+
+// TaskTemplate is a reusable task tree. Titles and descriptions may contain {{placeholders}}
+// that are filled in when the template is instantiated. A template with a ProjectID is shared
+// with everyone who can access the project.
+type TaskTemplate struct {
+	ID          int64        `json:"id" db:"id"`
+	OwnerID     int64        `json:"owner_id" db:"owner_id"`
+	ProjectID   *int64       `json:"project_id,omitempty" db:"project_id"`
+	Name        string       `json:"name" db:"name"`
+	Description string       `json:"description,omitempty" db:"description"`
+	Task        TemplateNode `json:"task" db:"tree"`
+	// Placeholders lists the variable names used anywhere in the tree.
+	Placeholders []string  `json:"placeholders" db:"-"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// TemplateNode is one task of a template. Offsets are minutes relative to the start_at given
+// when instantiating.
+type TemplateNode struct {
+	Title              string         `json:"title"`
+	Description        string         `json:"description,omitempty"`
+	Priority           string         `json:"priority,omitempty"`
+	StartOffsetMinutes *int64         `json:"start_offset_minutes,omitempty"`
+	DueOffsetMinutes   *int64         `json:"due_offset_minutes,omitempty"`
+	SubTasks           []TemplateNode `json:"sub_tasks,omitempty"`
+}
+
+// CreateTemplateRequest builds a template either from an existing task tree (TaskID) or from
+// an explicit Task tree.
+type CreateTemplateRequest struct {
+	Name        string        `json:"name" binding:"required"`
+	Description string        `json:"description,omitempty"`
+	ProjectID   *int64        `json:"project_id,omitempty"`
+	TaskID      *int64        `json:"task_id,omitempty"`
+	Task        *TemplateNode `json:"task,omitempty"`
+}
+
+type UpdateTemplateRequest struct {
+	Name        *string       `json:"name,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	Task        *TemplateNode `json:"task,omitempty"`
+	// ProjectID shares the template with a project; Unshare makes it private again.
+	ProjectID *int64 `json:"project_id,omitempty"`
+	Unshare   bool   `json:"unshare,omitempty"`
+}
+
+// InstantiateTemplateRequest creates the template's tree, under ParentID when given. Offsets
+// are applied to StartAt, which defaults to now.
+type InstantiateTemplateRequest struct {
+	ParentID  *int64            `json:"parent_id,omitempty"`
+	ProjectID *int64            `json:"project_id,omitempty"`
+	StartAt   *time.Time        `json:"start_at,omitempty"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
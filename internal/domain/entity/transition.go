@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// WorkflowTransition allows moving a task from one status to another. From and To may be
+// constant.AnyStatus.
+type WorkflowTransition struct {
+	ID             int64                      `json:"id" db:"id"`
+	ProjectID      int64                      `json:"project_id" db:"project_id"`
+	From           constant.TaskStatus        `json:"from" db:"from_status"`
+	To             constant.TaskStatus        `json:"to" db:"to_status"`
+	RequiresReason bool                       `json:"requires_reason" db:"requires_reason"`
+	Guards         []constant.TransitionGuard `json:"guards,omitempty" db:"guards"`
+	RequiredFields []string                   `json:"required_fields,omitempty" db:"required_fields"`
+}
+
+type UpdateTransitionsRequest struct {
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+// StatusChange is one entry of a task's status history.
+type StatusChange struct {
+	ID         int64               `json:"id" db:"id"`
+	TaskID     int64               `json:"task_id" db:"task_id"`
+	UserID     int64               `json:"user_id" db:"user_id"`
+	ProjectID  *int64              `json:"project_id,omitempty" db:"project_id"`
+	FromStatus constant.TaskStatus `json:"from_status" db:"from_status"`
+	ToStatus   constant.TaskStatus `json:"to_status" db:"to_status"`
+	Reason     string              `json:"reason,omitempty" db:"reason"`
+	ChangedAt  time.Time           `json:"changed_at" db:"changed_at"`
+}
+
+// TransitionError reports a rejected status change. Transitions the workflow does not allow,
+// or that lack a required reason, are 422; guards that fail on the task's current state
+// are 409.
+type TransitionError struct {
+	From         constant.TaskStatus   `json:"from"`
+	To           constant.TaskStatus   `json:"to"`
+	Message      string                `json:"error"`
+	Allowed      []constant.TaskStatus `json:"allowed_next_states"`
+	FailedGuards []string              `json:"failed_guards,omitempty"`
+}
+
+func (e *TransitionError) Error() string {
+	if len(e.FailedGuards) > 0 {
+		return fmt.Sprintf("%s: %s", e.Message, strings.Join(e.FailedGuards, "; "))
+	}
+
+	return e.Message
+}
+
+func (e *TransitionError) StatusCode() int {
+	if len(e.FailedGuards) > 0 {
+		return http.StatusConflict
+	}
+
+	return http.StatusUnprocessableEntity
+}
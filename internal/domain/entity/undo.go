@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"maps"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// TaskSnapshot holds the editable fields of a task at one point in time.
+type TaskSnapshot struct {
+	Title             string                `json:"title"`
+	Description       string                `json:"description"`
+	Status            constant.TaskStatus   `json:"status"`
+	Priority          constant.TaskPriority `json:"priority"`
+	ParentID          *int64                `json:"parent_id,omitempty"`
+	ProjectID         *int64                `json:"project_id,omitempty"`
+	StartAt           *time.Time            `json:"start_at,omitempty"`
+	DueAt             *time.Time            `json:"due_at,omitempty"`
+	RRule             string                `json:"rrule,omitempty"`
+	Timezone          string                `json:"timezone,omitempty"`
+	RecurrenceStart   *time.Time            `json:"recurrence_start,omitempty"`
+	CopySubTasks      bool                  `json:"copy_sub_tasks,omitempty"`
+	OriginalEstimate  *int64                `json:"original_estimate_seconds,omitempty"`
+	RemainingEstimate *int64                `json:"remaining_estimate_seconds,omitempty"`
+	Rank              string                `json:"rank"`
+	CustomFields      map[string]any        `json:"custom_fields,omitempty"`
+	UpdatedAt         time.Time             `json:"updated_at"`
+}
+
+func NewTaskSnapshot(task *Task) *TaskSnapshot {
+	return &TaskSnapshot{
+		Title:             task.Title,
+		Description:       task.Description,
+		Status:            task.Status,
+		Priority:          task.Priority,
+		ParentID:          task.ParentID,
+		ProjectID:         task.ProjectID,
+		StartAt:           task.StartAt,
+		DueAt:             task.DueAt,
+		RRule:             task.RRule,
+		Timezone:          task.Timezone,
+		RecurrenceStart:   task.RecurrenceStart,
+		CopySubTasks:      task.CopySubTasks,
+		OriginalEstimate:  task.OriginalEstimate,
+		RemainingEstimate: task.RemainingEstimate,
+		Rank:              task.Rank,
+		CustomFields:      maps.Clone(task.CustomFields),
+		UpdatedAt:         task.UpdatedAt,
+	}
+}
+
+// Apply copies the snapshot's fields, except custom fields and UpdatedAt, onto the task.
+func (s *TaskSnapshot) Apply(task *Task) {
+	task.Title = s.Title
+	task.Description = s.Description
+	task.Status = s.Status
+	task.Priority = s.Priority
+	task.ParentID = s.ParentID
+	task.ProjectID = s.ProjectID
+	task.StartAt = s.StartAt
+	task.DueAt = s.DueAt
+	task.RRule = s.RRule
+	task.Timezone = s.Timezone
+	task.RecurrenceStart = s.RecurrenceStart
+	task.CopySubTasks = s.CopySubTasks
+	task.OriginalEstimate = s.OriginalEstimate
+	task.RemainingEstimate = s.RemainingEstimate
+	task.Rank = s.Rank
+}
+
+// UndoEntry records how to revert one task mutation. Version is the task's updated_at right
+// after the mutation; the entry can only be undone while the task still has that version.
+// Previous is the updated_at before it, which older entries of the task were recorded at.
+type UndoEntry struct {
+	ID     int64               `json:"token" db:"id"`
+	UserID int64               `json:"-" db:"user_id"`
+	TaskID int64               `json:"task_id" db:"task_id"`
+	Action constant.UndoAction `json:"action" db:"action"`
+	// Before is the task before an update or move.
+	Before    *TaskSnapshot `json:"-" db:"before"`
+	Version   time.Time     `json:"-" db:"version"`
+	Previous  time.Time     `json:"-" db:"previous_version"`
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+	UndoneAt  *time.Time    `json:"-" db:"undone_at"`
+}
+
+// UndoRequest undoes the operation of Token, or otherwise the user's last Count operations.
+type UndoRequest struct {
+	Token *int64 `json:"token,omitempty"`
+	Count int    `json:"count,omitempty"`
+}
+
+type UndoResult struct {
+	Undone []UndoEntry `json:"undone"`
+}
+
+// UndoConflictError reports an operation that cannot be undone because its task changed
+// afterwards.
+type UndoConflictError struct {
+	Token   int64  `json:"token"`
+	TaskID  int64  `json:"task_id"`
+	Message string `json:"error"`
+}
+
+func (e *UndoConflictError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"task-management-backend/pkg/textdiff"
+	"time"
+)
+
+// TaskVersion is a numbered snapshot of a task, recorded after each change to its fields.
+// Version 1 is the oldest state that was recorded.
+type TaskVersion struct {
+	ID        int64         `json:"-" db:"id"`
+	TaskID    int64         `json:"task_id" db:"task_id"`
+	UserID    int64         `json:"-" db:"user_id"`
+	Version   int           `json:"version" db:"version"`
+	Snapshot  *TaskSnapshot `json:"snapshot" db:"snapshot"`
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+}
+
+// VersionChange is one field that differs between two versions. Custom fields are listed
+// as custom_fields.<key>, and a description change also carries its line diff.
+type VersionChange struct {
+	Field string          `json:"field"`
+	From  any             `json:"from"`
+	To    any             `json:"to"`
+	Lines []textdiff.Line `json:"lines,omitempty"`
+}
+
+type VersionDiff struct {
+	TaskID  int64           `json:"task_id"`
+	From    int             `json:"from"`
+	To      int             `json:"to"`
+	Changes []VersionChange `json:"changes"`
+}
+
+// VersionDiffQuery selects the versions to compare. To defaults to the latest version and
+// From to the one before To.
+type VersionDiffQuery struct {
+	From int `form:"from"`
+	To   int `form:"to"`
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// SavedView is a named task list query with its presentation. A view is private to its
+// owner unless Shared is set, which shares it with everyone who can access its project.
+type SavedView struct {
+	ID        int64      `json:"id" db:"id"`
+	OwnerID   int64      `json:"owner_id" db:"owner_id"`
+	ProjectID *int64     `json:"project_id,omitempty" db:"project_id"`
+	Name      string     `json:"name" db:"name"`
+	Shared    bool       `json:"shared" db:"shared"`
+	Filter    ViewFilter `json:"filter" db:"filter"`
+	// Sort uses the syntax of the sort parameter of GET /api/tasks.
+	Sort string `json:"sort,omitempty" db:"sort"`
+	// GroupBy is status, priority, project_id or cf.<key>.
+	GroupBy string `json:"group_by,omitempty" db:"group_by"`
+	// Fields lists the task fields to return; all fields are returned when empty.
+	Fields    []string  `json:"fields,omitempty" db:"fields"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ViewFilter holds the filter parameters of GET /api/tasks. The view's project is the
+// project_id filter.
+type ViewFilter struct {
+	Status          string            `json:"status,omitempty"`
+	StatusCategory  string            `json:"status_category,omitempty"`
+	SprintID        *int64            `json:"sprint_id,omitempty"`
+	Overdue         bool              `json:"overdue,omitempty"`
+	DueToday        bool              `json:"due_today,omitempty"`
+	DueBefore       string            `json:"due_before,omitempty"`
+	DueAfter        string            `json:"due_after,omitempty"`
+	CustomFields    map[string]string `json:"custom_fields,omitempty"`
+	IncludeArchived bool              `json:"include_archived,omitempty"`
+	// Query is an expression of the filter parameter.
+	Query string `json:"query,omitempty"`
+}
+
+// TaskQuery returns the list query the view runs.
+func (v *SavedView) TaskQuery() TaskQuery {
+	return TaskQuery{
+		ProjectID:       v.ProjectID,
+		SprintID:        v.Filter.SprintID,
+		Status:          constant.TaskStatus(v.Filter.Status),
+		StatusCategory:  v.Filter.StatusCategory,
+		Overdue:         v.Filter.Overdue,
+		DueToday:        v.Filter.DueToday,
+		DueBefore:       v.Filter.DueBefore,
+		DueAfter:        v.Filter.DueAfter,
+		Sort:            v.Sort,
+		CustomFields:    v.Filter.CustomFields,
+		IncludeArchived: v.Filter.IncludeArchived,
+		Filter:          v.Filter.Query,
+	}
+}
+
+type CreateViewRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	ProjectID *int64     `json:"project_id,omitempty"`
+	Shared    bool       `json:"shared,omitempty"`
+	Filter    ViewFilter `json:"filter"`
+	Sort      string     `json:"sort,omitempty"`
+	GroupBy   string     `json:"group_by,omitempty"`
+	Fields    []string   `json:"fields,omitempty"`
+}
+
+type UpdateViewRequest struct {
+	Name *string `json:"name,omitempty"`
+	// ProjectID moves the view to another project; 0 removes it from its project.
+	ProjectID *int64      `json:"project_id,omitempty"`
+	Shared    *bool       `json:"shared,omitempty"`
+	Filter    *ViewFilter `json:"filter,omitempty"`
+	Sort      *string     `json:"sort,omitempty"`
+	GroupBy   *string     `json:"group_by,omitempty"`
+	Fields    *[]string   `json:"fields,omitempty"`
+}
+
+// TaskGroup is one group of a grouped view. Key is the shared value of the grouping
+// field, or nil for tasks without one.
+type TaskGroup struct {
+	Key   any    `json:"key"`
+	Tasks []Task `json:"tasks"`
+}
+
+// ViewResult holds a view's tasks, in Groups when the view is grouped.
+type ViewResult struct {
+	View   *SavedView
+	Tasks  []Task
+	Groups []TaskGroup
+}
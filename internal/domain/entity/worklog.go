@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// WorkLog is time spent on a task. A log without EndedAt is a running timer.
+type WorkLog struct {
+	ID              int64                  `json:"id" db:"id"`
+	TaskID          int64                  `json:"task_id" db:"task_id"`
+	UserID          int64                  `json:"user_id" db:"user_id"`
+	StartedAt       time.Time              `json:"started_at" db:"started_at"`
+	EndedAt         *time.Time             `json:"ended_at,omitempty" db:"ended_at"`
+	DurationSeconds int64                  `json:"duration_seconds" db:"duration_seconds"`
+	Note            string                 `json:"note,omitempty" db:"note"`
+	Source          constant.WorkLogSource `json:"source" db:"source"`
+	CreatedAt       time.Time              `json:"created_at" db:"created_at"`
+}
+
+type StartTimerRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// CreateWorkLogRequest records time manually. StartedAt defaults to DurationSeconds before now.
+type CreateWorkLogRequest struct {
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	DurationSeconds int64      `json:"duration_seconds" binding:"required"`
+	Note            string     `json:"note,omitempty"`
+}
+
+// TimesheetQuery selects work logs by start date. From and To are inclusive YYYY-MM-DD
+// dates in the user's timezone.
+type TimesheetQuery struct {
+	From   string `form:"from" binding:"required"`
+	To     string `form:"to" binding:"required"`
+	Format string `form:"format"`
+}
+
+type TimesheetEntry struct {
+	WorkLog
+	Date      string `json:"date"`
+	TaskTitle string `json:"task_title" db:"title"`
+	ProjectID *int64 `json:"project_id,omitempty" db:"project_id"`
+}
+
+type TimesheetDay struct {
+	Date         string `json:"date"`
+	TotalSeconds int64  `json:"total_seconds"`
+}
+
+type TimesheetTask struct {
+	TaskID       int64  `json:"task_id"`
+	TaskTitle    string `json:"task_title"`
+	TotalSeconds int64  `json:"total_seconds"`
+}
+
+// Timesheet is a user's finished work logs in a date range with per-day and per-task totals.
+type Timesheet struct {
+	UserID       int64            `json:"user_id"`
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	Timezone     string           `json:"timezone"`
+	TotalSeconds int64            `json:"total_seconds"`
+	Days         []TimesheetDay   `json:"days"`
+	Tasks        []TimesheetTask  `json:"tasks"`
+	Entries      []TimesheetEntry `json:"entries"`
+}
@@ -9,4 +9,5 @@ type TaskCache interface {
 	Get(userID int64, status constant.TaskStatus) ([]entity.Task, bool)
 	Set(userID int64, status constant.TaskStatus, tasks []entity.Task)
 	Invalidate(userID int64, statuses []constant.TaskStatus)
+	InvalidateUser(userID int64)
 }
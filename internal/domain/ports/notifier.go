@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+)
+
+// Notifier delivers fired reminders over a single channel.
+type Notifier interface {
+	Channel() constant.NotificationChannel
+	Notify(ctx context.Context, event entity.ReminderEvent) error
+}
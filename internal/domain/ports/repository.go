@@ -3,20 +3,165 @@ package ports
 import (
 	"task-management-backend/internal/domain/entity"
 	"task-management-backend/pkg/constant"
+	"time"
 )
 
 type TaskRepository interface {
 	GetAllByUserID(userID int64) ([]entity.Task, error)
 	GetSubTasks(parentID int64) ([]entity.Task, error)
 	GetByID(id, userID int64) (*entity.Task, error)
+	GetTree(id, userID int64, depth int) (*entity.Task, error)
+	GetAncestors(id, userID int64) ([]entity.TaskPathItem, error)
+	GetPaths(taskIDs []int64) (map[int64][]entity.TaskPathItem, error)
 	Create(task *entity.Task) error
 	Update(task *entity.Task) error
 	Delete(id, userID int64) error
+	GetTrash(userID int64) ([]entity.Task, error)
+	Restore(id, userID int64) error
+	Purge(id, userID int64) error
+	PurgeDeletedBefore(cutoff time.Time) (int, error)
+	Archive(id, userID int64) error
+	Unarchive(id, userID int64) error
+	ArchiveDoneBefore(cutoff time.Time) ([]int64, error)
+	SetPosition(taskID, userID int64, parentID *int64, rank string) error
 	GetByUserIDAndStatus(userID int64, status constant.TaskStatus) ([]entity.Task, error)
+	GetByFilter(filter entity.TaskFilter) ([]entity.Task, error)
+	GetPage(filter entity.TaskFilter, page entity.PageRequest) (*entity.TaskPage, error)
+	PageCursor(sort []entity.SortField, taskID int64) (string, error)
+	IsAncestor(ancestorID, taskID int64) (bool, error)
+	GetNextOccurrenceID(taskID int64) (*int64, error)
+	SetNextOccurrenceID(taskID int64, nextID *int64) error
+	AddStatusChange(change *entity.StatusChange) error
+	CreateTree(root *entity.Task) error
+	CloneTree(sourceID, userID int64, opts entity.CloneOptions) (*entity.Task, error)
+	Move(taskID, userID int64, parentID, afterID, beforeID *int64) error
+	CountTopLevelInStatus(projectID int64, status constant.TaskStatus, excludeID int64) (int, error)
+	GetBoard(filter entity.BoardFilter) ([]entity.BoardCell, error)
+	GetStatusHistory(taskID int64) ([]entity.StatusChange, error)
+}
+
+type DependencyRepository interface {
+	Add(blockerID, blockedID, userID int64) error
+	Remove(blockerID, blockedID, userID int64) error
+	Reaches(fromID, toID int64) (bool, error)
+	OpenBlockers(taskID int64) ([]int64, error)
 }
 
 type UserRepository interface {
 	GetByUsername(username string) (*entity.User, error)
+	GetByID(id int64) (*entity.User, error)
 	Create(user *entity.User) error
 	Upsert(user *entity.User) (*entity.User, error)
+	UpdateTimezone(id int64, timezone string) error
+}
+
+type ReminderRepository interface {
+	Create(reminder *entity.Reminder) error
+	GetByID(id, userID int64) (*entity.Reminder, error)
+	GetByTaskID(taskID, userID int64) ([]entity.Reminder, error)
+	Delete(id, userID int64) error
+	Snooze(id, userID int64, until time.Time) error
+	Dismiss(id, userID int64) error
+	RescheduleForTask(taskID int64, dueAt *time.Time) error
+	ClaimDue(now time.Time, limit int) ([]entity.Reminder, error)
+	GetTask(taskID, userID int64) (*entity.ReminderTask, error)
+	MarkSent(id int64, sentAt time.Time) error
+	MarkFailed(id int64, retryAt *time.Time) error
+	ReleaseStale(claimedBefore time.Time) error
+}
+
+type NotificationRepository interface {
+	CreateForReminder(notification *entity.Notification) error
+	GetByUserID(userID int64) ([]entity.Notification, error)
+}
+
+type ProjectRepository interface {
+	Create(project *entity.Project, statuses []entity.WorkflowStatus) error
+	GetByID(id, ownerID int64) (*entity.Project, error)
+	GetByOwnerID(ownerID int64) ([]entity.Project, error)
+	GetWorkflowStatuses(projectID int64) ([]entity.WorkflowStatus, error)
+	GetWorkflowStatusesByOwner(ownerID int64) ([]entity.WorkflowStatus, error)
+	ReplaceWorkflowStatuses(projectID int64, statuses []entity.WorkflowStatus) error
+	GetTransitions(projectID int64) ([]entity.WorkflowTransition, error)
+	ReplaceTransitions(projectID int64, transitions []entity.WorkflowTransition) error
+}
+
+type CustomFieldRepository interface {
+	Create(field *entity.CustomField) error
+	GetByProjectID(projectID int64) ([]entity.CustomField, error)
+	GetByID(id, projectID int64) (*entity.CustomField, error)
+	Update(field *entity.CustomField) error
+	Delete(id, projectID int64) error
+	CountOptionUsage(fieldID int64) (map[string]int, error)
+	SetTaskValues(taskID int64, values map[int64][]entity.FieldValue) error
+	CopyTaskValues(fromTaskID, toTaskID int64) error
+}
+
+type WorkLogRepository interface {
+	Create(log *entity.WorkLog) error
+	GetByID(id, userID int64) (*entity.WorkLog, error)
+	GetRunning(userID int64) (*entity.WorkLog, error)
+	Stop(log *entity.WorkLog) error
+	GetByTaskID(taskID int64) ([]entity.WorkLog, error)
+	Delete(id, userID int64) error
+	GetTimesheet(userID int64, from, to time.Time) ([]entity.TimesheetEntry, error)
+}
+
+type ChecklistRepository interface {
+	GetByTaskID(taskID int64) ([]entity.ChecklistItem, error)
+	GetByID(id, taskID int64) (*entity.ChecklistItem, error)
+	Create(item *entity.ChecklistItem, position *int) error
+	Update(item *entity.ChecklistItem) error
+	Delete(id, taskID int64) error
+}
+
+type TemplateRepository interface {
+	Create(template *entity.TaskTemplate) error
+	GetVisible(userID int64, projectID *int64) ([]entity.TaskTemplate, error)
+	GetByID(id, userID int64) (*entity.TaskTemplate, error)
+	Update(template *entity.TaskTemplate) error
+	Delete(id int64) error
+}
+
+type ViewRepository interface {
+	Create(view *entity.SavedView) error
+	GetVisible(userID int64, projectID *int64) ([]entity.SavedView, error)
+	GetByID(id, userID int64) (*entity.SavedView, error)
+	GetOwned(id, ownerID int64) (*entity.SavedView, error)
+	Update(view *entity.SavedView) error
+	Delete(id, ownerID int64) error
+}
+
+type SprintRepository interface {
+	Create(sprint *entity.Sprint) error
+	GetByID(id, ownerID int64) (*entity.Sprint, error)
+	GetByProjectID(projectID int64) ([]entity.Sprint, error)
+	GetClosed(projectID int64, kind constant.SprintKind, limit int) ([]entity.Sprint, error)
+	Update(sprint *entity.Sprint) error
+	Delete(id int64) error
+	GetMembers(sprintID, pointsFieldID int64, includeRemoved bool) ([]entity.SprintMember, error)
+	GetOpenSprintOf(taskID int64, kind constant.SprintKind) (int64, error)
+	AddTasks(sprintID int64, taskIDs []int64) error
+	RemoveTask(sprintID, taskID int64) error
+	Close(sprintID int64, outcomes map[int64]constant.SprintOutcome, carryOverTo *int64) error
+	GetStatusHistory(sprintID int64) ([]entity.StatusChange, error)
+}
+
+type UndoRepository interface {
+	Create(entry *entity.UndoEntry, expiredBefore time.Time) error
+	GetByID(id, userID int64) (*entity.UndoEntry, error)
+	GetRecent(userID int64, since time.Time, limit int) ([]entity.UndoEntry, error)
+	MarkUndone(id int64) error
+	Rebase(taskID int64, from, to time.Time) error
+}
+
+type TaskVersionRepository interface {
+	Create(version *entity.TaskVersion) error
+	GetByTaskID(taskID int64) ([]entity.TaskVersion, error)
+	GetVersion(taskID int64, number int) (*entity.TaskVersion, error)
+	GetLatest(taskID int64) (*entity.TaskVersion, error)
+}
+
+type SearchRepository interface {
+	Search(userID int64, match string, query entity.SearchQuery) ([]entity.SearchHit, error)
 }
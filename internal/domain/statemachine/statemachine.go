@@ -0,0 +1,210 @@
+// Package statemachine decides whether a task may move between two workflow statuses.
+package statemachine
+
+import (
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+)
+
+// BlockerChecker reports the unfinished tasks blocking a task.
+type BlockerChecker interface {
+	OpenBlockers(taskID int64) ([]int64, error)
+}
+
+type Machine struct {
+	workflow    entity.Workflow
+	transitions []entity.WorkflowTransition
+}
+
+// New builds a machine for the workflow. Without explicit transitions the default rules
+// apply: any move is allowed, but leaving a done status requires a reason. A task whose
+// status is not in the workflow, e.g. a legacy typo, may move to any workflow status, so
+// it is never stuck.
+func New(workflow entity.Workflow, transitions []entity.WorkflowTransition) *Machine {
+	if len(transitions) == 0 {
+		transitions = defaultTransitions(workflow)
+	}
+
+	return &Machine{workflow: workflow, transitions: transitions}
+}
+
+// Transitions returns the rules in effect, including the defaults.
+func (m *Machine) Transitions() []entity.WorkflowTransition {
+	return m.transitions
+}
+
+func defaultTransitions(workflow entity.Workflow) []entity.WorkflowTransition {
+	var transitions []entity.WorkflowTransition
+	for _, from := range workflow.Statuses {
+		for _, to := range workflow.Statuses {
+			if from.Name == to.Name {
+				continue
+			}
+
+			transitions = append(transitions, entity.WorkflowTransition{
+				From:           from.Name,
+				To:             to.Name,
+				RequiresReason: from.Category == constant.StatusCategoryDone && to.Category != constant.StatusCategoryDone,
+			})
+		}
+	}
+
+	return transitions
+}
+
+func (m *Machine) find(from, to constant.TaskStatus) (entity.WorkflowTransition, bool) {
+	for _, t := range m.transitions {
+		if (t.From == from || t.From == constant.AnyStatus) && (t.To == to || t.To == constant.AnyStatus) {
+			return t, true
+		}
+	}
+
+	if !m.workflow.Has(from) {
+		return entity.WorkflowTransition{From: from, To: to}, true
+	}
+
+	return entity.WorkflowTransition{}, false
+}
+
+// Allowed lists the statuses reachable from the given status in workflow order.
+func (m *Machine) Allowed(from constant.TaskStatus) []constant.TaskStatus {
+	allowed := []constant.TaskStatus{}
+	for _, s := range m.workflow.Statuses {
+		if s.Name == from {
+			continue
+		}
+
+		if _, ok := m.find(from, s.Name); ok {
+			allowed = append(allowed, s.Name)
+		}
+	}
+
+	return allowed
+}
+
+// Check validates moving task to the target status. extraGuards are applied on top of the
+// transition's own guards, e.g. globally enforced blocker checks.
+func (m *Machine) Check(task *entity.Task, to constant.TaskStatus, reason string, blockers BlockerChecker, extraGuards ...constant.TransitionGuard) error {
+	from := task.Status
+	if from == to {
+		return nil
+	}
+
+	reject := func(message string) *entity.TransitionError {
+		return &entity.TransitionError{
+			From:    from,
+			To:      to,
+			Message: message,
+			Allowed: m.Allowed(from),
+		}
+	}
+
+	if !m.workflow.Has(to) {
+		return reject(fmt.Sprintf("unknown status %q", to))
+	}
+
+	transition, ok := m.find(from, to)
+	if !ok {
+		return reject(fmt.Sprintf("transition from %q to %q is not allowed", from, to))
+	}
+
+	if transition.RequiresReason && reason == "" {
+		return reject(fmt.Sprintf("transition from %q to %q requires a reason", from, to))
+	}
+
+	guards := append(append([]constant.TransitionGuard{}, transition.Guards...), extraGuards...)
+	var failed []string
+	seen := make(map[constant.TransitionGuard]bool)
+	for _, guard := range guards {
+		if seen[guard] {
+			continue
+		}
+
+		seen[guard] = true
+		msg, err := m.checkGuard(guard, task, transition, blockers)
+		if err != nil {
+			return err
+		}
+
+		if msg != "" {
+			failed = append(failed, msg)
+		}
+	}
+
+	if len(failed) > 0 {
+		rejection := reject(fmt.Sprintf("transition from %q to %q is blocked", from, to))
+		rejection.FailedGuards = failed
+		return rejection
+	}
+
+	return nil
+}
+
+// checkGuard returns a failure message, or an empty string when the guard passes.
+func (m *Machine) checkGuard(guard constant.TransitionGuard, task *entity.Task, transition entity.WorkflowTransition, blockers BlockerChecker) (string, error) {
+	switch guard {
+	case constant.GuardRequiredFields:
+		var missing []string
+		for _, field := range transition.RequiredFields {
+			if !hasField(task, field) {
+				missing = append(missing, field)
+			}
+		}
+
+		if len(missing) > 0 {
+			return fmt.Sprintf("required fields are missing: %v", missing), nil
+		}
+	case constant.GuardSubTasksDone:
+		if open := m.openSubTasks(task.SubTasks); len(open) > 0 {
+			return fmt.Sprintf("subtasks are not done: %v", open), nil
+		}
+	case constant.GuardNoOpenBlockers:
+		open, err := blockers.OpenBlockers(task.ID)
+		if err != nil {
+			return "", err
+		}
+
+		if len(open) > 0 {
+			return fmt.Sprintf("task is blocked by unfinished tasks: %v", open), nil
+		}
+	}
+
+	return "", nil
+}
+
+func (m *Machine) openSubTasks(subTasks []entity.Task) []int64 {
+	var open []int64
+	for _, sub := range subTasks {
+		if !m.workflow.IsDone(sub.Status) {
+			open = append(open, sub.ID)
+		}
+
+		open = append(open, m.openSubTasks(sub.SubTasks)...)
+	}
+
+	return open
+}
+
+// RequiredFieldNames are the task fields a required_fields guard can reference.
+var RequiredFieldNames = map[string]bool{
+	"description": true,
+	"priority":    true,
+	"start_at":    true,
+	"due_at":      true,
+}
+
+func hasField(task *entity.Task, field string) bool {
+	switch field {
+	case "description":
+		return task.Description != ""
+	case "priority":
+		return task.Priority != "" && task.Priority != constant.TaskPriorityNone
+	case "start_at":
+		return task.StartAt != nil
+	case "due_at":
+		return task.DueAt != nil
+	default:
+		return false
+	}
+}
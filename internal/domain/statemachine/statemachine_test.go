@@ -0,0 +1,125 @@
+package statemachine
+
+import (
+	"errors"
+	"reflect"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"testing"
+)
+
+type blockers []int64
+
+func (b blockers) OpenBlockers(int64) ([]int64, error) {
+	return b, nil
+}
+
+// check runs Check and returns the rejection, or nil when the move is allowed.
+func check(t *testing.T, m *Machine, task *entity.Task, to constant.TaskStatus, reason string, extra ...constant.TransitionGuard) *entity.TransitionError {
+	t.Helper()
+	err := m.Check(task, to, reason, blockers{7}, extra...)
+	if err == nil {
+		return nil
+	}
+
+	var rejection *entity.TransitionError
+	if !errors.As(err, &rejection) {
+		t.Fatalf("error = %v, want *entity.TransitionError", err)
+	}
+
+	return rejection
+}
+
+func TestDefaultTransitions(t *testing.T) {
+	m := New(entity.DefaultWorkflow(), nil)
+	tests := []struct {
+		name   string
+		from   constant.TaskStatus
+		to     constant.TaskStatus
+		reason string
+		want   string
+	}{
+		{"forward", constant.TaskStatusTodo, constant.TaskStatusInProgress, "", ""},
+		{"straight to done", constant.TaskStatusTodo, constant.TaskStatusDone, "", ""},
+		{"back to to do", constant.TaskStatusInProgress, constant.TaskStatusTodo, "", ""},
+		{"same status", constant.TaskStatusDone, constant.TaskStatusDone, "", ""},
+		{"leaving done needs a reason", constant.TaskStatusDone, constant.TaskStatusTodo, "", `transition from "done" to "to do" requires a reason`},
+		{"leaving done with a reason", constant.TaskStatusDone, constant.TaskStatusInProgress, "reopened", ""},
+		{"unknown target", constant.TaskStatusTodo, "blocked", "", `unknown status "blocked"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rejection := check(t, m, &entity.Task{Status: tt.from}, tt.to, tt.reason)
+			switch {
+			case tt.want == "" && rejection != nil:
+				t.Fatalf("rejected: %v", rejection)
+			case tt.want != "" && rejection == nil:
+				t.Fatalf("allowed, want %q", tt.want)
+			case tt.want != "" && rejection.Message != tt.want:
+				t.Errorf("message = %q, want %q", rejection.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnknownCurrentStatus(t *testing.T) {
+	legacy := constant.TaskStatus("in progres")
+	all := []constant.TaskStatus{constant.TaskStatusTodo, constant.TaskStatusInProgress, constant.TaskStatusDone}
+	restricted := []entity.WorkflowTransition{
+		{From: constant.TaskStatusTodo, To: constant.TaskStatusInProgress},
+	}
+
+	for name, transitions := range map[string][]entity.WorkflowTransition{"default rules": nil, "explicit rules": restricted} {
+		t.Run(name, func(t *testing.T) {
+			m := New(entity.DefaultWorkflow(), transitions)
+			if got := m.Allowed(legacy); !reflect.DeepEqual(got, all) {
+				t.Errorf("allowed = %v, want %v", got, all)
+			}
+
+			for _, to := range all {
+				if rejection := check(t, m, &entity.Task{Status: legacy}, to, ""); rejection != nil {
+					t.Errorf("move to %q rejected: %v", to, rejection)
+				}
+			}
+
+			// extra guards still apply
+			rejection := check(t, m, &entity.Task{Status: legacy}, constant.TaskStatusDone, "", constant.GuardNoOpenBlockers)
+			if rejection == nil || !reflect.DeepEqual(rejection.FailedGuards, []string{"task is blocked by unfinished tasks: [7]"}) {
+				t.Errorf("rejection = %v, want the blocker guard to fail", rejection)
+			}
+		})
+	}
+}
+
+func TestExplicitTransitions(t *testing.T) {
+	m := New(entity.DefaultWorkflow(), []entity.WorkflowTransition{
+		{From: constant.TaskStatusTodo, To: constant.TaskStatusInProgress},
+		{From: constant.TaskStatusInProgress, To: constant.TaskStatusDone, Guards: []constant.TransitionGuard{constant.GuardRequiredFields, constant.GuardSubTasksDone}, RequiredFields: []string{"description", "due_at"}},
+		{From: constant.AnyStatus, To: constant.TaskStatusTodo, RequiresReason: true},
+	})
+
+	if got, want := m.Allowed(constant.TaskStatusTodo), []constant.TaskStatus{constant.TaskStatusInProgress}; !reflect.DeepEqual(got, want) {
+		t.Errorf("allowed from to do = %v, want %v", got, want)
+	}
+
+	rejection := check(t, m, &entity.Task{Status: constant.TaskStatusTodo}, constant.TaskStatusDone, "")
+	if rejection == nil || rejection.Message != `transition from "to do" to "done" is not allowed` {
+		t.Errorf("rejection = %v, want the move to be disallowed", rejection)
+	}
+
+	if rejection := check(t, m, &entity.Task{Status: constant.TaskStatusDone}, constant.TaskStatusTodo, ""); rejection == nil {
+		t.Error("wildcard rule: move without a reason allowed")
+	}
+
+	task := &entity.Task{
+		Status:   constant.TaskStatusInProgress,
+		SubTasks: []entity.Task{{ID: 2, Status: constant.TaskStatusDone, SubTasks: []entity.Task{{ID: 3, Status: constant.TaskStatusTodo}}}},
+	}
+
+	rejection = check(t, m, task, constant.TaskStatusDone, "")
+	want := []string{"required fields are missing: [description due_at]", "subtasks are not done: [3]"}
+	if rejection == nil || !reflect.DeepEqual(rejection.FailedGuards, want) {
+		t.Errorf("rejection = %v, want failed guards %v", rejection, want)
+	}
+}
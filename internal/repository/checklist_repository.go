@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"time"
+)
+
+const checklistColumns = `id, task_id, title, checked, position, created_at, updated_at`
+
+type ChecklistRepository struct {
+	db *sql.DB
+}
+
+func NewChecklistRepository(db *sql.DB) *ChecklistRepository {
+	return &ChecklistRepository{db: db}
+}
+
+func scanChecklistItem(s rowScanner) (entity.ChecklistItem, error) {
+	var item entity.ChecklistItem
+	err := s.Scan(&item.ID, &item.TaskID, &item.Title, &item.Checked, &item.Position, &item.CreatedAt, &item.UpdatedAt)
+	return item, err
+}
+
+func (r *ChecklistRepository) GetByTaskID(taskID int64) ([]entity.ChecklistItem, error) {
+	query := `SELECT ` + checklistColumns + ` FROM checklist_items WHERE task_id = ? ORDER BY position, id`
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query checklist: %w", err)
+	}
+
+	defer rows.Close()
+
+	items := []entity.ChecklistItem{}
+	for rows.Next() {
+		item, err := scanChecklistItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan checklist item: %w", err)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (r *ChecklistRepository) GetByID(id, taskID int64) (*entity.ChecklistItem, error) {
+	query := `SELECT ` + checklistColumns + ` FROM checklist_items WHERE id = ? AND task_id = ?`
+	item, err := scanChecklistItem(r.db.QueryRow(query, id, taskID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("checklist item not found")
+		}
+
+		return nil, fmt.Errorf("failed to get checklist item: %w", err)
+	}
+
+	return &item, nil
+}
+
+// Create inserts the item at position, shifting later items down. A nil or out of range
+// position appends the item.
+func (r *ChecklistRepository) Create(item *entity.ChecklistItem, position *int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM checklist_items WHERE task_id = ?`, item.TaskID).Scan(&count); err != nil {
+		return fmt.Errorf("failed to count checklist items: %w", err)
+	}
+
+	item.Position = count
+	if position != nil && *position >= 0 && *position < count {
+		item.Position = *position
+		if _, err := tx.Exec(`UPDATE checklist_items SET position = position + 1 WHERE task_id = ? AND position >= ?`, item.TaskID, item.Position); err != nil {
+			return fmt.Errorf("failed to shift checklist items: %w", err)
+		}
+	}
+
+	now := time.Now()
+	item.CreatedAt = now
+	item.UpdatedAt = now
+	result, err := tx.Exec(`INSERT INTO checklist_items (task_id, title, checked, position, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`, item.TaskID, item.Title, item.Checked, item.Position, item.CreatedAt, item.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create checklist item: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	item.ID = id
+	return tx.Commit()
+}
+
+// Update saves the item's title and checked state and moves it to item.Position.
+func (r *ChecklistRepository) Update(item *entity.ChecklistItem) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	item.UpdatedAt = time.Now()
+	result, err := tx.Exec(`UPDATE checklist_items SET title = ?, checked = ?, updated_at = ? WHERE id = ? AND task_id = ?`, item.Title, item.Checked, item.UpdatedAt, item.ID, item.TaskID)
+	if err != nil {
+		return fmt.Errorf("failed to update checklist item: %w", err)
+	}
+
+	if err := expectOneRow(result, "checklist item not found"); err != nil {
+		return err
+	}
+
+	ids, err := checklistOrder(tx, item.TaskID)
+	if err != nil {
+		return err
+	}
+
+	order := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id != item.ID {
+			order = append(order, id)
+		}
+	}
+
+	item.Position = min(max(item.Position, 0), len(order))
+	order = append(order[:item.Position], append([]int64{item.ID}, order[item.Position:]...)...)
+	if err := writeChecklistOrder(tx, order); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes the item and closes the gap in the positions.
+func (r *ChecklistRepository) Delete(id, taskID int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM checklist_items WHERE id = ? AND task_id = ?`, id, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to delete checklist item: %w", err)
+	}
+
+	if err := expectOneRow(result, "checklist item not found"); err != nil {
+		return err
+	}
+
+	ids, err := checklistOrder(tx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if err := writeChecklistOrder(tx, ids); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func checklistOrder(tx *sql.Tx, taskID int64) ([]int64, error) {
+	rows, err := tx.Query(`SELECT id FROM checklist_items WHERE task_id = ? ORDER BY position, id`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query checklist order: %w", err)
+	}
+
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan checklist item: %w", err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func writeChecklistOrder(tx *sql.Tx, ids []int64) error {
+	for position, id := range ids {
+		if _, err := tx.Exec(`UPDATE checklist_items SET position = ? WHERE id = ?`, position, id); err != nil {
+			return fmt.Errorf("failed to reorder checklist: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// attachChecklistSummaries counts checked and total checklist items of a task tree in a
+// single query.
+func attachChecklistSummaries(db *sql.DB, tasks []entity.Task) error {
+	index, ids := indexTaskTree(tasks)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `
+		SELECT task_id, SUM(checked), COUNT(*)
+		FROM checklist_items
+		WHERE task_id ` + inIDList + `
+		GROUP BY task_id
+	`
+	rows, err := db.Query(query, idList(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query checklist summaries: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int64
+		var summary entity.ChecklistSummary
+		if err := rows.Scan(&taskID, &summary.Checked, &summary.Total); err != nil {
+			return fmt.Errorf("failed to scan checklist summary: %w", err)
+		}
+
+		index[taskID].Checklist = &summary
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"time"
+)
+
+const customFieldColumns = `id, project_id, key, name, type, options, required, position, created_at`
+
+type CustomFieldRepository struct {
+	db *sql.DB
+}
+
+func NewCustomFieldRepository(db *sql.DB) *CustomFieldRepository {
+	return &CustomFieldRepository{db: db}
+}
+
+func scanCustomField(s rowScanner) (entity.CustomField, error) {
+	var field entity.CustomField
+	var options string
+	if err := s.Scan(&field.ID, &field.ProjectID, &field.Key, &field.Name, &field.Type, &options, &field.Required, &field.Position, &field.CreatedAt); err != nil {
+		return field, err
+	}
+
+	if err := json.Unmarshal([]byte(options), &field.Options); err != nil {
+		return field, fmt.Errorf("failed to decode field options: %w", err)
+	}
+
+	return field, nil
+}
+
+func encodeOptions(options []string) (string, error) {
+	if options == nil {
+		options = []string{}
+	}
+
+	data, err := json.Marshal(options)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode field options: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// Create appends the field after the project's existing fields.
+func (r *CustomFieldRepository) Create(field *entity.CustomField) error {
+	options, err := encodeOptions(field.Options)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO custom_fields (project_id, key, name, type, options, required, position, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, (SELECT COUNT(*) FROM custom_fields WHERE project_id = ?), ?)
+	`
+	field.CreatedAt = time.Now()
+	result, err := r.db.Exec(query, field.ProjectID, field.Key, field.Name, field.Type, options, field.Required, field.ProjectID, field.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create custom field: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	field.ID = id
+	return r.db.QueryRow(`SELECT position FROM custom_fields WHERE id = ?`, id).Scan(&field.Position)
+}
+
+func (r *CustomFieldRepository) GetByProjectID(projectID int64) ([]entity.CustomField, error) {
+	query := `SELECT ` + customFieldColumns + ` FROM custom_fields WHERE project_id = ? ORDER BY position, id`
+	rows, err := r.db.Query(query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query custom fields: %w", err)
+	}
+
+	defer rows.Close()
+
+	fields := []entity.CustomField{}
+	for rows.Next() {
+		field, err := scanCustomField(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan custom field: %w", err)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func (r *CustomFieldRepository) GetByID(id, projectID int64) (*entity.CustomField, error) {
+	query := `SELECT ` + customFieldColumns + ` FROM custom_fields WHERE id = ? AND project_id = ?`
+	field, err := scanCustomField(r.db.QueryRow(query, id, projectID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("custom field not found")
+		}
+
+		return nil, fmt.Errorf("failed to get custom field: %w", err)
+	}
+
+	return &field, nil
+}
+
+// Update saves the field's name, options and required flag, and moves stored select values
+// to the options' new positions.
+func (r *CustomFieldRepository) Update(field *entity.CustomField) error {
+	options, err := encodeOptions(field.Options)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`UPDATE custom_fields SET name = ?, options = ?, required = ? WHERE id = ? AND project_id = ?`, field.Name, options, field.Required, field.ID, field.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to update custom field: %w", err)
+	}
+
+	if err := expectOneRow(result, "custom field not found"); err != nil {
+		return err
+	}
+
+	if field.Type.IsSelect() {
+		for i, option := range field.Options {
+			if _, err := tx.Exec(`UPDATE task_field_values SET number_value = ? WHERE field_id = ? AND text_value = ?`, i, field.ID, option); err != nil {
+				return fmt.Errorf("failed to reorder field values: %w", err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes the field and every value stored for it.
+func (r *CustomFieldRepository) Delete(id, projectID int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM custom_fields WHERE id = ? AND project_id = ?`, id, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to delete custom field: %w", err)
+	}
+
+	if err := expectOneRow(result, "custom field not found"); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM task_field_values WHERE field_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete field values: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// CountOptionUsage counts the tasks using each option of a select field.
+func (r *CustomFieldRepository) CountOptionUsage(fieldID int64) (map[string]int, error) {
+	rows, err := r.db.Query(`SELECT text_value, COUNT(*) FROM task_field_values WHERE field_id = ? GROUP BY text_value`, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count field values: %w", err)
+	}
+
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var option sql.NullString
+		var count int
+		if err := rows.Scan(&option, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan field value count: %w", err)
+		}
+
+		counts[option.String] = count
+	}
+
+	return counts, nil
+}
+
+// SetTaskValues replaces the values of the given fields on a task. A field mapped to no
+// values is cleared.
+func (r *CustomFieldRepository) SetTaskValues(taskID int64, values map[int64][]entity.FieldValue) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	for fieldID, fieldValues := range values {
+		if _, err := tx.Exec(`DELETE FROM task_field_values WHERE task_id = ? AND field_id = ?`, taskID, fieldID); err != nil {
+			return fmt.Errorf("failed to clear field values: %w", err)
+		}
+
+		for _, v := range fieldValues {
+			if _, err := tx.Exec(`INSERT INTO task_field_values (task_id, field_id, text_value, number_value) VALUES (?, ?, ?, ?)`, taskID, fieldID, v.Text, v.Number); err != nil {
+				return fmt.Errorf("failed to set field value: %w", err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CopyTaskValues copies every custom field value of one task to another.
+func (r *CustomFieldRepository) CopyTaskValues(fromTaskID, toTaskID int64) error {
+	query := `
+		INSERT INTO task_field_values (task_id, field_id, text_value, number_value)
+		SELECT ?, field_id, text_value, number_value FROM task_field_values WHERE task_id = ?
+	`
+	if _, err := r.db.Exec(query, toTaskID, fromTaskID); err != nil {
+		return fmt.Errorf("failed to copy field values: %w", err)
+	}
+
+	return nil
+}
+
+// attachCustomFields loads the custom field values of a task tree in a single query.
+func attachCustomFields(db *sql.DB, tasks []entity.Task) error {
+	index, ids := indexTaskTree(tasks)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `
+		SELECT v.task_id, f.id, f.key, f.type, v.text_value, v.number_value
+		FROM task_field_values v
+		JOIN custom_fields f ON f.id = v.field_id
+		WHERE v.task_id ` + inIDList + `
+		ORDER BY v.task_id, f.position, f.id, v.number_value, v.id
+	`
+	rows, err := db.Query(query, idList(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query custom field values: %w", err)
+	}
+
+	defer rows.Close()
+
+	type fieldKey struct {
+		taskID  int64
+		fieldID int64
+	}
+
+	fields := make(map[fieldKey]entity.CustomField)
+	values := make(map[fieldKey][]entity.FieldValue)
+	var order []fieldKey
+	for rows.Next() {
+		var key fieldKey
+		var field entity.CustomField
+		var value entity.FieldValue
+		if err := rows.Scan(&key.taskID, &field.ID, &field.Key, &field.Type, &value.Text, &value.Number); err != nil {
+			return fmt.Errorf("failed to scan custom field value: %w", err)
+		}
+
+		key.fieldID = field.ID
+		if _, ok := fields[key]; !ok {
+			fields[key] = field
+			order = append(order, key)
+		}
+
+		value.FieldID = field.ID
+		values[key] = append(values[key], value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, key := range order {
+		task := index[key.taskID]
+		if task.CustomFields == nil {
+			task.CustomFields = make(map[string]any)
+		}
+
+		field := fields[key]
+		task.CustomFields[field.Key] = field.Display(values[key])
+	}
+
+	return nil
+}
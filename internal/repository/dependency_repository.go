@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+type DependencyRepository struct {
+	db *sql.DB
+}
+
+func NewDependencyRepository(db *sql.DB) *DependencyRepository {
+	return &DependencyRepository{db: db}
+}
+
+func (r *DependencyRepository) Add(blockerID, blockedID, userID int64) error {
+	query := `
+		INSERT OR IGNORE INTO task_dependencies (blocker_id, blocked_id, user_id, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+	if _, err := r.db.Exec(query, blockerID, blockedID, userID, time.Now()); err != nil {
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DependencyRepository) Remove(blockerID, blockedID, userID int64) error {
+	query := `DELETE FROM task_dependencies WHERE blocker_id = ? AND blocked_id = ? AND user_id = ?`
+	result, err := r.db.Exec(query, blockerID, blockedID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+
+	return expectOneRow(result, "dependency not found")
+}
+
+// Reaches reports whether toID is reachable from fromID by following blocker -> blocked
+// edges. The recursive CTE only visits tasks downstream of fromID.
+func (r *DependencyRepository) Reaches(fromID, toID int64) (bool, error) {
+	query := `
+		WITH RECURSIVE downstream(id) AS (
+			SELECT blocked_id FROM task_dependencies WHERE blocker_id = ?
+			UNION
+			SELECT d.blocked_id FROM task_dependencies d JOIN downstream ON d.blocker_id = downstream.id
+		)
+		SELECT EXISTS (SELECT 1 FROM downstream WHERE id = ?)
+	`
+	var exists bool
+	if err := r.db.QueryRow(query, fromID, toID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check dependency path: %w", err)
+	}
+
+	return exists, nil
+}
+
+// OpenBlockers returns the IDs of tasks blocking taskID whose status is not in the done
+// category of their workflow. Tasks outside a project use the default "done" status.
+func (r *DependencyRepository) OpenBlockers(taskID int64) ([]int64, error) {
+	query := `
+		SELECT d.blocker_id
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.blocker_id
+		LEFT JOIN workflow_statuses ws ON ws.project_id = t.project_id AND ws.name = t.status
+		WHERE d.blocked_id = ? AND t.deleted_at IS NULL
+			AND COALESCE(ws.category, CASE WHEN t.status = ? THEN ? ELSE '' END) != ?
+		ORDER BY d.blocker_id
+	`
+	rows, err := r.db.Query(query, taskID, constant.TaskStatusDone, constant.StatusCategoryDone, constant.StatusCategoryDone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blockers: %w", err)
+	}
+
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan blocker: %w", err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// attachDependencies fills BlockedBy and Blocking for every task in the trees with a
+// single query. Links to tasks in the trash are left out.
+func attachDependencies(db *sql.DB, tasks []entity.Task) error {
+	index, ids := indexTaskTree(tasks)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `
+		SELECT blocker_id, blocked_id
+		FROM task_dependencies
+		WHERE (blocker_id ` + inIDList + ` OR blocked_id ` + inIDList + `)
+			AND NOT EXISTS (SELECT 1 FROM tasks t WHERE t.id IN (blocker_id, blocked_id) AND t.deleted_at IS NOT NULL)
+		ORDER BY blocker_id, blocked_id
+	`
+	list := idList(ids)
+	rows, err := db.Query(query, list, list)
+	if err != nil {
+		return fmt.Errorf("failed to query dependencies: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var blockerID, blockedID int64
+		if err := rows.Scan(&blockerID, &blockedID); err != nil {
+			return fmt.Errorf("failed to scan dependency: %w", err)
+		}
+
+		if blocked, ok := index[blockedID]; ok {
+			blocked.BlockedBy = append(blocked.BlockedBy, blockerID)
+		}
+
+		if blocker, ok := index[blockerID]; ok {
+			blocker.Blocking = append(blocker.Blocking, blockedID)
+		}
+	}
+
+	return rows.Err()
+}
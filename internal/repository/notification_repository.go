@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"time"
+)
+
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// CreateForReminder is idempotent per reminder, so a redelivered reminder never
+// produces a second in-app notification.
+func (r *NotificationRepository) CreateForReminder(notification *entity.Notification) error {
+	query := `
+		INSERT OR IGNORE INTO notifications (user_id, task_id, reminder_id, message, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	notification.CreatedAt = time.Now()
+	result, err := r.db.Exec(query, notification.UserID, notification.TaskID, notification.ReminderID, notification.Message, notification.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	notification.ID = id
+	return nil
+}
+
+func (r *NotificationRepository) GetByUserID(userID int64) ([]entity.Notification, error) {
+	query := `
+		SELECT id, user_id, task_id, reminder_id, message, read_at, created_at
+		FROM notifications
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+
+	defer rows.Close()
+
+	var notifications []entity.Notification
+	for rows.Next() {
+		var n entity.Notification
+		if err := rows.Scan(&n.ID, &n.UserID, &n.TaskID, &n.ReminderID, &n.Message, &n.ReadAt, &n.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+
+		notifications = append(notifications, n)
+	}
+
+	return notifications, nil
+}
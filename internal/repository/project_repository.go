@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+type ProjectRepository struct {
+	db *sql.DB
+}
+
+func NewProjectRepository(db *sql.DB) *ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+// Create inserts the project together with its initial workflow.
+func (r *ProjectRepository) Create(project *entity.Project, statuses []entity.WorkflowStatus) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	project.CreatedAt = time.Now()
+	result, err := tx.Exec(`INSERT INTO projects (owner_id, name, created_at) VALUES (?, ?, ?)`, project.OwnerID, project.Name, project.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	project.ID = id
+	if err := insertWorkflowStatuses(tx, id, statuses); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ProjectRepository) GetByID(id, ownerID int64) (*entity.Project, error) {
+	query := `SELECT id, owner_id, name, created_at FROM projects WHERE id = ? AND owner_id = ?`
+
+	var project entity.Project
+	err := r.db.QueryRow(query, id, ownerID).Scan(&project.ID, &project.OwnerID, &project.Name, &project.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("project not found")
+		}
+
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return &project, nil
+}
+
+func (r *ProjectRepository) GetByOwnerID(ownerID int64) ([]entity.Project, error) {
+	query := `SELECT id, owner_id, name, created_at FROM projects WHERE owner_id = ? ORDER BY name COLLATE NOCASE`
+	rows, err := r.db.Query(query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects: %w", err)
+	}
+
+	defer rows.Close()
+
+	var projects []entity.Project
+	for rows.Next() {
+		var project entity.Project
+		if err := rows.Scan(&project.ID, &project.OwnerID, &project.Name, &project.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+func (r *ProjectRepository) GetWorkflowStatuses(projectID int64) ([]entity.WorkflowStatus, error) {
+	query := `
+		SELECT id, project_id, name, category, position, wip_limit, enforce_wip_limit
+		FROM workflow_statuses
+		WHERE project_id = ?
+		ORDER BY position
+	`
+	return r.queryWorkflowStatuses(query, projectID)
+}
+
+// GetWorkflowStatusesByOwner returns the statuses of every project owned by the user.
+func (r *ProjectRepository) GetWorkflowStatusesByOwner(ownerID int64) ([]entity.WorkflowStatus, error) {
+	query := `
+		SELECT ws.id, ws.project_id, ws.name, ws.category, ws.position, ws.wip_limit, ws.enforce_wip_limit
+		FROM workflow_statuses ws
+		JOIN projects p ON p.id = ws.project_id
+		WHERE p.owner_id = ?
+		ORDER BY ws.project_id, ws.position
+	`
+	return r.queryWorkflowStatuses(query, ownerID)
+}
+
+func (r *ProjectRepository) queryWorkflowStatuses(query string, args ...any) ([]entity.WorkflowStatus, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workflow statuses: %w", err)
+	}
+
+	defer rows.Close()
+
+	var statuses []entity.WorkflowStatus
+	for rows.Next() {
+		var s entity.WorkflowStatus
+		if err := rows.Scan(&s.ID, &s.ProjectID, &s.Name, &s.Category, &s.Position, &s.WIPLimit, &s.EnforceWIPLimit); err != nil {
+			return nil, fmt.Errorf("failed to scan workflow status: %w", err)
+		}
+
+		statuses = append(statuses, s)
+	}
+
+	return statuses, nil
+}
+
+// ReplaceWorkflowStatuses swaps the whole ordered status list and drops the transition
+// rules that reference removed statuses, in one transaction. It fails when a task of the
+// project, including one in the trash, is in a removed status, or when the project has
+// transition rules and none would be left, since no rules means the permissive defaults.
+// The statuses are written before the tasks are checked, so the transaction holds the
+// write lock and no task can move into a removed status in between.
+func (r *ProjectRepository) ReplaceWorkflowStatuses(projectID int64, statuses []entity.WorkflowStatus) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM workflow_statuses WHERE project_id = ?`, projectID); err != nil {
+		return fmt.Errorf("failed to clear workflow statuses: %w", err)
+	}
+
+	if err := insertWorkflowStatuses(tx, projectID, statuses); err != nil {
+		return err
+	}
+
+	query := `
+		SELECT DISTINCT status FROM tasks
+		WHERE project_id = ? AND status NOT IN (SELECT name FROM workflow_statuses WHERE project_id = ?)
+		ORDER BY status
+	`
+	rows, err := tx.Query(query, projectID, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to check task statuses: %w", err)
+	}
+
+	var missing []string
+	for rows.Next() {
+		var status string
+		if err := rows.Scan(&status); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan task status: %w", err)
+		}
+
+		missing = append(missing, status)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read task statuses: %w", err)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("statuses still in use by tasks cannot be removed: %s", strings.Join(missing, ", "))
+	}
+
+	stale := `
+		(from_status != ? AND from_status NOT IN (SELECT name FROM workflow_statuses WHERE project_id = ?))
+		OR (to_status != ? AND to_status NOT IN (SELECT name FROM workflow_statuses WHERE project_id = ?))
+	`
+	staleArgs := []any{constant.AnyStatus, projectID, constant.AnyStatus, projectID}
+
+	var total, kept int
+	query = `SELECT COUNT(*), COUNT(*) FILTER (WHERE NOT (` + stale + `)) FROM workflow_transitions WHERE project_id = ?`
+	if err := tx.QueryRow(query, append(staleArgs, projectID)...).Scan(&total, &kept); err != nil {
+		return fmt.Errorf("failed to check workflow transitions: %w", err)
+	}
+
+	if total > 0 && kept == 0 {
+		return fmt.Errorf("removing these statuses would drop every transition rule, which would allow any move; update the transitions first")
+	}
+
+	if _, err := tx.Exec(`DELETE FROM workflow_transitions WHERE project_id = ? AND (`+stale+`)`, append([]any{projectID}, staleArgs...)...); err != nil {
+		return fmt.Errorf("failed to drop workflow transitions: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func insertWorkflowStatuses(tx *sql.Tx, projectID int64, statuses []entity.WorkflowStatus) error {
+	query := `INSERT INTO workflow_statuses (project_id, name, category, position, wip_limit, enforce_wip_limit) VALUES (?, ?, ?, ?, ?, ?)`
+	for i := range statuses {
+		statuses[i].ProjectID = projectID
+		statuses[i].Position = i
+		result, err := tx.Exec(query, projectID, statuses[i].Name, statuses[i].Category, i, statuses[i].WIPLimit, statuses[i].EnforceWIPLimit)
+		if err != nil {
+			return fmt.Errorf("failed to create workflow status: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+
+		statuses[i].ID = id
+	}
+
+	return nil
+}
+
+func (r *ProjectRepository) GetTransitions(projectID int64) ([]entity.WorkflowTransition, error) {
+	query := `
+		SELECT id, project_id, from_status, to_status, requires_reason, guards, required_fields
+		FROM workflow_transitions
+		WHERE project_id = ?
+		ORDER BY id
+	`
+	rows, err := r.db.Query(query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workflow transitions: %w", err)
+	}
+
+	defer rows.Close()
+
+	var transitions []entity.WorkflowTransition
+	for rows.Next() {
+		var t entity.WorkflowTransition
+		var guards, requiredFields string
+		if err := rows.Scan(&t.ID, &t.ProjectID, &t.From, &t.To, &t.RequiresReason, &guards, &requiredFields); err != nil {
+			return nil, fmt.Errorf("failed to scan workflow transition: %w", err)
+		}
+
+		for _, guard := range splitList(guards) {
+			t.Guards = append(t.Guards, constant.TransitionGuard(guard))
+		}
+
+		t.RequiredFields = splitList(requiredFields)
+		transitions = append(transitions, t)
+	}
+
+	return transitions, nil
+}
+
+// ReplaceTransitions swaps the project's transition rules in one transaction. An empty
+// list restores the default rules.
+func (r *ProjectRepository) ReplaceTransitions(projectID int64, transitions []entity.WorkflowTransition) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM workflow_transitions WHERE project_id = ?`, projectID); err != nil {
+		return fmt.Errorf("failed to clear workflow transitions: %w", err)
+	}
+
+	query := `
+		INSERT INTO workflow_transitions (project_id, from_status, to_status, requires_reason, guards, required_fields)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	for i := range transitions {
+		guards := make([]string, 0, len(transitions[i].Guards))
+		for _, guard := range transitions[i].Guards {
+			guards = append(guards, string(guard))
+		}
+
+		transitions[i].ProjectID = projectID
+		result, err := tx.Exec(query, projectID, transitions[i].From, transitions[i].To, transitions[i].RequiresReason, strings.Join(guards, ","), strings.Join(transitions[i].RequiredFields, ","))
+		if err != nil {
+			return fmt.Errorf("failed to create workflow transition: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+
+		transitions[i].ID = id
+	}
+
+	return tx.Commit()
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"testing"
+)
+
+func createTestProject(t *testing.T, db *sql.DB, repo *ProjectRepository, statuses ...entity.WorkflowStatus) int64 {
+	t.Helper()
+	result, err := db.Exec(`INSERT INTO projects (owner_id, name) VALUES (1, 'project')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectID, _ := result.LastInsertId()
+	if err := repo.ReplaceWorkflowStatuses(projectID, statuses); err != nil {
+		t.Fatal(err)
+	}
+
+	return projectID
+}
+
+func workflowNames(t *testing.T, repo *ProjectRepository, projectID int64) []constant.TaskStatus {
+	t.Helper()
+	statuses, err := repo.GetWorkflowStatuses(projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []constant.TaskStatus
+	for _, s := range statuses {
+		names = append(names, s.Name)
+	}
+
+	return names
+}
+
+var (
+	statusTodo   = entity.WorkflowStatus{Name: "to do", Category: constant.StatusCategoryTodo}
+	statusReview = entity.WorkflowStatus{Name: "review", Category: constant.StatusCategoryActive}
+	statusDone   = entity.WorkflowStatus{Name: "done", Category: constant.StatusCategoryDone}
+)
+
+func TestReplaceWorkflowStatusesInUse(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewProjectRepository(db)
+	projectID := createTestProject(t, db, repo, statusTodo, statusReview, statusDone)
+
+	task := &entity.Task{UserID: 1, ProjectID: &projectID, Title: "t", Status: "review", Priority: constant.TaskPriorityNone}
+	if err := NewTaskRepository(db).Create(task); err != nil {
+		t.Fatal(err)
+	}
+
+	err := repo.ReplaceWorkflowStatuses(projectID, []entity.WorkflowStatus{statusTodo, statusDone})
+	if err == nil || err.Error() != "statuses still in use by tasks cannot be removed: review" {
+		t.Fatalf("error = %v, want the status in use to be reported", err)
+	}
+
+	if got, want := workflowNames(t, repo, projectID), []constant.TaskStatus{"to do", "review", "done"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("statuses = %v, want them unchanged: %v", got, want)
+	}
+}
+
+func TestReplaceWorkflowStatusesPrunesTransitions(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewProjectRepository(db)
+	projectID := createTestProject(t, db, repo, statusTodo, statusReview, statusDone)
+
+	rules := []entity.WorkflowTransition{
+		{From: "to do", To: "review"},
+		{From: "review", To: "done"},
+		{From: constant.AnyStatus, To: "to do", RequiresReason: true},
+	}
+	if err := repo.ReplaceTransitions(projectID, rules); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.ReplaceWorkflowStatuses(projectID, []entity.WorkflowStatus{statusTodo, statusDone}); err != nil {
+		t.Fatal(err)
+	}
+
+	transitions, err := repo.GetTransitions(projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(transitions) != 1 || transitions[0].From != constant.AnyStatus || transitions[0].To != "to do" {
+		t.Errorf("transitions = %+v, want only the wildcard rule", transitions)
+	}
+}
+
+// TestReplaceWorkflowStatusesKeepsRules checks that a workflow edit cannot prune every rule
+// and so fall back to the permissive defaults.
+func TestReplaceWorkflowStatusesKeepsRules(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewProjectRepository(db)
+	projectID := createTestProject(t, db, repo, statusTodo, statusReview, statusDone)
+
+	if err := repo.ReplaceTransitions(projectID, []entity.WorkflowTransition{{From: "review", To: "done"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := repo.ReplaceWorkflowStatuses(projectID, []entity.WorkflowStatus{statusTodo, statusDone})
+	if err == nil || !strings.Contains(err.Error(), "would drop every transition rule") {
+		t.Fatalf("error = %v, want the edit to be rejected", err)
+	}
+
+	transitions, err := repo.GetTransitions(projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(transitions) != 1 {
+		t.Errorf("transitions = %+v, want the rule kept", transitions)
+	}
+
+	if got := workflowNames(t, repo, projectID); len(got) != 3 {
+		t.Errorf("statuses = %v, want them unchanged", got)
+	}
+}
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+const reminderColumns = `id, task_id, user_id, offset_minutes, remind_at, channel, webhook_url, status, attempts, sent_at, created_at`
+
+func scanReminder(s rowScanner) (entity.Reminder, error) {
+	var reminder entity.Reminder
+	err := s.Scan(&reminder.ID, &reminder.TaskID, &reminder.UserID, &reminder.OffsetMinutes, &reminder.RemindAt, &reminder.Channel, &reminder.WebhookURL, &reminder.Status, &reminder.Attempts, &reminder.SentAt, &reminder.CreatedAt)
+	return reminder, err
+}
+
+type ReminderRepository struct {
+	db *sql.DB
+}
+
+func NewReminderRepository(db *sql.DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+func (r *ReminderRepository) Create(reminder *entity.Reminder) error {
+	query := `
+		INSERT INTO reminders (task_id, user_id, offset_minutes, remind_at, channel, webhook_url, status, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	reminder.CreatedAt = time.Now()
+	reminder.Status = constant.ReminderStatusPending
+	result, err := r.db.Exec(query, reminder.TaskID, reminder.UserID, reminder.OffsetMinutes, utcTime(reminder.RemindAt), reminder.Channel, reminder.WebhookURL, reminder.Status, reminder.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create reminder: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	reminder.ID = id
+	return nil
+}
+
+func (r *ReminderRepository) GetByID(id, userID int64) (*entity.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM reminders WHERE id = ? AND user_id = ?`
+	reminder, err := scanReminder(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("reminder not found")
+		}
+
+		return nil, fmt.Errorf("failed to get reminder: %w", err)
+	}
+
+	return &reminder, nil
+}
+
+func (r *ReminderRepository) GetByTaskID(taskID, userID int64) ([]entity.Reminder, error) {
+	query := `
+		SELECT ` + reminderColumns + `
+		FROM reminders
+		WHERE task_id = ? AND user_id = ?
+		ORDER BY remind_at ASC
+	`
+	rows, err := r.db.Query(query, taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reminders: %w", err)
+	}
+
+	defer rows.Close()
+
+	return scanReminders(rows)
+}
+
+func (r *ReminderRepository) Delete(id, userID int64) error {
+	result, err := r.db.Exec(`DELETE FROM reminders WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete reminder: %w", err)
+	}
+
+	return expectOneRow(result, "reminder not found")
+}
+
+// Snooze re-arms a reminder, including one that has already fired. A snoozed reminder
+// keeps its new absolute time even if the task due date changes afterwards.
+func (r *ReminderRepository) Snooze(id, userID int64, until time.Time) error {
+	query := `
+		UPDATE reminders
+		SET remind_at = ?, offset_minutes = NULL, status = ?, attempts = 0, claimed_at = NULL, sent_at = NULL
+		WHERE id = ? AND user_id = ? AND status != ?
+	`
+	result, err := r.db.Exec(query, until.UTC(), constant.ReminderStatusPending, id, userID, constant.ReminderStatusSending)
+	if err != nil {
+		return fmt.Errorf("failed to snooze reminder: %w", err)
+	}
+
+	return expectOneRow(result, "reminder not found or currently being delivered")
+}
+
+func (r *ReminderRepository) Dismiss(id, userID int64) error {
+	query := `UPDATE reminders SET status = ?, claimed_at = NULL WHERE id = ? AND user_id = ? AND status != ?`
+	result, err := r.db.Exec(query, constant.ReminderStatusDismissed, id, userID, constant.ReminderStatusSending)
+	if err != nil {
+		return fmt.Errorf("failed to dismiss reminder: %w", err)
+	}
+
+	return expectOneRow(result, "reminder not found or currently being delivered")
+}
+
+// RescheduleForTask recomputes the fire time of pending relative reminders after a due date change.
+func (r *ReminderRepository) RescheduleForTask(taskID int64, dueAt *time.Time) error {
+	query := `
+		SELECT ` + reminderColumns + `
+		FROM reminders
+		WHERE task_id = ? AND offset_minutes IS NOT NULL AND status = ?
+	`
+	rows, err := r.db.Query(query, taskID, constant.ReminderStatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to query reminders: %w", err)
+	}
+
+	reminders, err := scanReminders(rows)
+	rows.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		var remindAt *time.Time
+		if dueAt != nil {
+			t := dueAt.Add(-time.Duration(*reminder.OffsetMinutes) * time.Minute).UTC()
+			remindAt = &t
+		}
+
+		if _, err := r.db.Exec(`UPDATE reminders SET remind_at = ? WHERE id = ? AND status = ?`, remindAt, reminder.ID, constant.ReminderStatusPending); err != nil {
+			return fmt.Errorf("failed to reschedule reminder: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ClaimDue moves due reminders from pending to sending. The conditional update guarantees
+// that each reminder is claimed by a single scheduler even when several are running.
+// Reminders of archived tasks and tasks in the trash stay pending until the task is back.
+func (r *ReminderRepository) ClaimDue(now time.Time, limit int) ([]entity.Reminder, error) {
+	query := `
+		SELECT ` + reminderColumns + `
+		FROM reminders
+		WHERE status = ? AND remind_at IS NOT NULL AND remind_at <= ?
+			AND task_id NOT IN (SELECT id FROM tasks WHERE deleted_at IS NOT NULL OR archived_at IS NOT NULL)
+		ORDER BY remind_at ASC
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, constant.ReminderStatusPending, now.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due reminders: %w", err)
+	}
+
+	candidates, err := scanReminders(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var claimed []entity.Reminder
+	for _, reminder := range candidates {
+		result, err := r.db.Exec(
+			`UPDATE reminders SET status = ?, claimed_at = ? WHERE id = ? AND status = ?`,
+			constant.ReminderStatusSending, now.UTC(), reminder.ID, constant.ReminderStatusPending,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim reminder: %w", err)
+		}
+
+		if n, err := result.RowsAffected(); err == nil && n == 1 {
+			reminder.Status = constant.ReminderStatusSending
+			claimed = append(claimed, reminder)
+		}
+	}
+
+	return claimed, nil
+}
+
+// GetTask loads the fields a reminder is delivered with. Archived tasks and tasks in the
+// trash are not found.
+func (r *ReminderRepository) GetTask(taskID, userID int64) (*entity.ReminderTask, error) {
+	var task entity.ReminderTask
+	query := `SELECT id, title, due_at FROM tasks WHERE id = ? AND user_id = ? AND deleted_at IS NULL AND archived_at IS NULL`
+	if err := r.db.QueryRow(query, taskID, userID).Scan(&task.ID, &task.Title, &task.DueAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("task not found or archived")
+		}
+
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	return &task, nil
+}
+
+func (r *ReminderRepository) MarkSent(id int64, sentAt time.Time) error {
+	query := `UPDATE reminders SET status = ?, sent_at = ?, attempts = attempts + 1 WHERE id = ? AND status = ?`
+	if _, err := r.db.Exec(query, constant.ReminderStatusSent, sentAt.UTC(), id, constant.ReminderStatusSending); err != nil {
+		return fmt.Errorf("failed to mark reminder sent: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed puts a reminder back in the queue at retryAt, or fails it permanently when retryAt is nil.
+func (r *ReminderRepository) MarkFailed(id int64, retryAt *time.Time) error {
+	var err error
+	if retryAt == nil {
+		_, err = r.db.Exec(
+			`UPDATE reminders SET status = ?, attempts = attempts + 1 WHERE id = ? AND status = ?`,
+			constant.ReminderStatusFailed, id, constant.ReminderStatusSending,
+		)
+	} else {
+		_, err = r.db.Exec(
+			`UPDATE reminders SET status = ?, remind_at = ?, claimed_at = NULL, attempts = attempts + 1 WHERE id = ? AND status = ?`,
+			constant.ReminderStatusPending, retryAt.UTC(), id, constant.ReminderStatusSending,
+		)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to mark reminder failed: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseStale returns reminders stuck in sending, e.g. after a crash, to the queue.
+func (r *ReminderRepository) ReleaseStale(claimedBefore time.Time) error {
+	query := `UPDATE reminders SET status = ?, claimed_at = NULL WHERE status = ? AND claimed_at < ?`
+	if _, err := r.db.Exec(query, constant.ReminderStatusPending, constant.ReminderStatusSending, claimedBefore.UTC()); err != nil {
+		return fmt.Errorf("failed to release stale reminders: %w", err)
+	}
+
+	return nil
+}
+
+func scanReminders(rows *sql.Rows) ([]entity.Reminder, error) {
+	var reminders []entity.Reminder
+	for rows.Next() {
+		reminder, err := scanReminder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reminder: %w", err)
+		}
+
+		reminders = append(reminders, reminder)
+	}
+
+	return reminders, nil
+}
+
+func expectOneRow(result sql.Result, notFound string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s", notFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"testing"
+	"time"
+)
+
+// TestClaimDueSkipsHiddenTasks checks that reminders of archived and trashed tasks are not
+// delivered, and that a delivered reminder loads only its task's own fields.
+func TestClaimDueSkipsHiddenTasks(t *testing.T) {
+	db := openTestDB(t)
+	tasks := NewTaskRepository(db)
+	repo := NewReminderRepository(db)
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	due := now.Add(time.Hour)
+	remindAt := now.Add(-time.Minute)
+
+	ids := map[string]int64{}
+	for _, title := range []string{"active", "archived", "trashed"} {
+		task := &entity.Task{UserID: 1, Title: title, Status: constant.TaskStatusTodo, Priority: constant.TaskPriorityNone, DueAt: &due}
+		if err := tasks.Create(task); err != nil {
+			t.Fatal(err)
+		}
+
+		ids[title] = task.ID
+		if err := repo.Create(&entity.Reminder{TaskID: task.ID, UserID: 1, RemindAt: &remindAt, Channel: constant.NotificationChannelInApp}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := db.Exec(`UPDATE tasks SET archived_at = ? WHERE id = ?`, now, ids["archived"]); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec(`UPDATE tasks SET deleted_at = ? WHERE id = ?`, now, ids["trashed"]); err != nil {
+		t.Fatal(err)
+	}
+
+	claimed, err := repo.ClaimDue(now, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(claimed) != 1 || claimed[0].TaskID != ids["active"] {
+		t.Fatalf("claimed = %+v, want only the active task's reminder", claimed)
+	}
+
+	task, err := repo.GetTask(ids["active"], 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if task.ID != ids["active"] || task.Title != "active" || task.DueAt == nil || !task.DueAt.Equal(due) {
+		t.Errorf("task = %+v, want the active task due at %s", task, due)
+	}
+
+	for _, title := range []string{"archived", "trashed"} {
+		if _, err := repo.GetTask(ids[title], 1); err == nil {
+			t.Errorf("GetTask found the %s task", title)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"database/sql"
+	"path/filepath"
+	"task-management-backend/config"
+	"testing"
+)
+
+// openTestDB returns a migrated database in a temporary file.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := config.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
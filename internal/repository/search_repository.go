@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"html"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+)
+
+// markOpen and markClose delimit matches in FTS output. They are control characters, so
+// they survive HTML escaping and are only then replaced with <mark> tags.
+const (
+	markOpen  = "\x02"
+	markClose = "\x03"
+)
+
+var markReplacer = strings.NewReplacer(markOpen, "<mark>", markClose, "</mark>")
+
+// markMatches HTML-escapes FTS output and turns its match delimiters into <mark> tags, so
+// the stored text cannot inject markup.
+func markMatches(text string) string {
+	return markReplacer.Replace(html.EscapeString(text))
+}
+
+type SearchRepository struct {
+	db *sql.DB
+}
+
+func NewSearchRepository(db *sql.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// Search runs an FTS5 match expression against the user's tasks, best matches first, and
+// loads each hit's path. Title matches weigh ten times as much as description matches.
+func (r *SearchRepository) Search(userID int64, match string, query entity.SearchQuery) ([]entity.SearchHit, error) {
+	conditions := []string{"tasks_fts MATCH ?", "t.user_id = ?", "t.deleted_at IS NULL"}
+	args := []any{match, userID}
+
+	if !query.IncludeArchived {
+		conditions = append(conditions, "t.archived_at IS NULL")
+	}
+
+	if query.ProjectID != nil {
+		conditions = append(conditions, "t.project_id = ?")
+		args = append(args, *query.ProjectID)
+	}
+
+	sqlQuery := `
+		SELECT t.id, t.project_id, t.parent_id, t.status, t.priority,
+			highlight(tasks_fts, 0, ?, ?),
+			snippet(tasks_fts, 1, ?, ?, '…', 16),
+			bm25(tasks_fts, 10.0, 1.0) AS score
+		FROM tasks_fts
+		JOIN tasks t ON t.id = tasks_fts.rowid
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY score, t.id DESC
+		LIMIT ? OFFSET ?
+	`
+	args = append([]any{markOpen, markClose, markOpen, markClose}, args...)
+	rows, err := r.db.Query(sqlQuery, append(args, query.Limit, query.Offset)...)
+	if err != nil {
+		if strings.Contains(err.Error(), "no such table: tasks_fts") {
+			return nil, fmt.Errorf("search is not available: the server was built without SQLite FTS5")
+		}
+
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+
+	defer rows.Close()
+
+	hits := []entity.SearchHit{}
+	for rows.Next() {
+		var hit entity.SearchHit
+		var snippet sql.NullString
+		if err := rows.Scan(&hit.ID, &hit.ProjectID, &hit.ParentID, &hit.Status, &hit.Priority, &hit.Title, &snippet, &hit.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan search hit: %w", err)
+		}
+
+		// bm25 is lower for better matches
+		hit.Score = -hit.Score
+		hit.Title = markMatches(hit.Title)
+		hit.Snippet = markMatches(snippet.String)
+		hits = append(hits, hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read search hits: %w", err)
+	}
+
+	ids := make([]int64, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+
+	paths, err := taskPaths(r.db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range hits {
+		hits[i].Path = paths[hits[i].ID]
+		if hits[i].Path == nil {
+			hits[i].Path = []entity.TaskPathItem{}
+		}
+	}
+
+	return hits, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"testing"
+)
+
+func TestMarkMatches(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"plain", "plain"},
+		{"\x02Deploy\x03 the pipeline", "<mark>Deploy</mark> the pipeline"},
+		{"<script>alert(\"\x02x\x03\")</script>", `&lt;script&gt;alert(&#34;<mark>x</mark>&#34;)&lt;/script&gt;`},
+		{"a & \x02b\x03 & \x02c\x03", "a &amp; <mark>b</mark> &amp; <mark>c</mark>"},
+	}
+
+	for _, tt := range tests {
+		if got := markMatches(tt.text); got != tt.want {
+			t.Errorf("markMatches(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+// TestSearchEscapesHighlights needs a build with FTS5 (-tags sqlite_fts5).
+func TestSearchEscapesHighlights(t *testing.T) {
+	db := openTestDB(t)
+	var fts bool
+	if err := db.QueryRow(`SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE name = 'tasks_fts')`).Scan(&fts); err != nil {
+		t.Fatal(err)
+	}
+
+	if !fts {
+		t.Skip("SQLite was built without FTS5")
+	}
+
+	task := &entity.Task{
+		UserID:      1,
+		Title:       `<img src=x onerror=alert(1)> deploy`,
+		Description: `run <b>deploy</b> & check`,
+		Status:      constant.TaskStatusTodo,
+		Priority:    constant.TaskPriorityNone,
+	}
+	if err := NewTaskRepository(db).Create(task); err != nil {
+		t.Fatal(err)
+	}
+
+	hits, err := NewSearchRepository(db).Search(1, "deploy", entity.SearchQuery{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(hits) != 1 {
+		t.Fatalf("hits = %d, want 1", len(hits))
+	}
+
+	if want := "&lt;img src=x onerror=alert(1)&gt; <mark>deploy</mark>"; hits[0].Title != want {
+		t.Errorf("title = %q, want %q", hits[0].Title, want)
+	}
+
+	if want := "run &lt;b&gt;<mark>deploy</mark>&lt;/b&gt; &amp; check"; hits[0].Snippet != want {
+		t.Errorf("snippet = %q, want %q", hits[0].Snippet, want)
+	}
+}
@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+const sprintColumns = `s.id, s.project_id, s.kind, s.name, s.goal, s.start_at, s.end_at, s.closed_at, s.created_at`
+
+type SprintRepository struct {
+	db *sql.DB
+}
+
+func NewSprintRepository(db *sql.DB) *SprintRepository {
+	return &SprintRepository{db: db}
+}
+
+func scanSprint(s rowScanner) (entity.Sprint, error) {
+	var sprint entity.Sprint
+	err := s.Scan(&sprint.ID, &sprint.ProjectID, &sprint.Kind, &sprint.Name, &sprint.Goal, &sprint.StartAt, &sprint.EndAt, &sprint.ClosedAt, &sprint.CreatedAt)
+	return sprint, err
+}
+
+func (r *SprintRepository) querySprints(query string, args ...any) ([]entity.Sprint, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sprints: %w", err)
+	}
+
+	defer rows.Close()
+
+	sprints := []entity.Sprint{}
+	for rows.Next() {
+		sprint, err := scanSprint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sprint: %w", err)
+		}
+
+		sprints = append(sprints, sprint)
+	}
+
+	return sprints, nil
+}
+
+func (r *SprintRepository) Create(sprint *entity.Sprint) error {
+	sprint.CreatedAt = time.Now()
+	result, err := r.db.Exec(`INSERT INTO sprints (project_id, kind, name, goal, start_at, end_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		sprint.ProjectID, sprint.Kind, sprint.Name, sprint.Goal, utcTime(sprint.StartAt), sprint.EndAt.UTC(), sprint.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create sprint: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	sprint.ID = id
+	return nil
+}
+
+// GetByID returns the sprint if it belongs to a project owned by the user.
+func (r *SprintRepository) GetByID(id, ownerID int64) (*entity.Sprint, error) {
+	query := `SELECT ` + sprintColumns + ` FROM sprints s JOIN projects p ON p.id = s.project_id WHERE s.id = ? AND p.owner_id = ?`
+	sprint, err := scanSprint(r.db.QueryRow(query, id, ownerID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("sprint not found")
+		}
+
+		return nil, fmt.Errorf("failed to get sprint: %w", err)
+	}
+
+	return &sprint, nil
+}
+
+func (r *SprintRepository) GetByProjectID(projectID int64) ([]entity.Sprint, error) {
+	query := `SELECT ` + sprintColumns + ` FROM sprints s WHERE s.project_id = ? ORDER BY s.end_at, s.id`
+	return r.querySprints(query, projectID)
+}
+
+// GetClosed returns the project's most recently closed sprints of the kind, oldest first.
+func (r *SprintRepository) GetClosed(projectID int64, kind constant.SprintKind, limit int) ([]entity.Sprint, error) {
+	query := `
+		SELECT * FROM (
+			SELECT ` + sprintColumns + `
+			FROM sprints s
+			WHERE s.project_id = ? AND s.kind = ? AND s.closed_at IS NOT NULL
+			ORDER BY s.closed_at DESC, s.id DESC
+			LIMIT ?
+		)
+		ORDER BY closed_at, id
+	`
+	return r.querySprints(query, projectID, kind, limit)
+}
+
+func (r *SprintRepository) Update(sprint *entity.Sprint) error {
+	result, err := r.db.Exec(`UPDATE sprints SET name = ?, goal = ?, start_at = ?, end_at = ? WHERE id = ?`,
+		sprint.Name, sprint.Goal, utcTime(sprint.StartAt), sprint.EndAt.UTC(), sprint.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update sprint: %w", err)
+	}
+
+	return expectOneRow(result, "sprint not found")
+}
+
+func (r *SprintRepository) Delete(id int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM sprints WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete sprint: %w", err)
+	}
+
+	if err := expectOneRow(result, "sprint not found"); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM sprint_tasks WHERE sprint_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete sprint tasks: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetMembers returns the sprint's memberships with each task's current status, including
+// tasks that were removed when includeRemoved is set. A non-zero pointsFieldID loads each
+// task's value of that number field as its points.
+func (r *SprintRepository) GetMembers(sprintID, pointsFieldID int64, includeRemoved bool) ([]entity.SprintMember, error) {
+	query := `
+		SELECT m.sprint_id, m.task_id, m.added_at, m.removed_at, m.carried_over, m.outcome, t.status,
+			COALESCE((SELECT MIN(v.number_value) FROM task_field_values v WHERE v.task_id = m.task_id AND v.field_id = ?), 0)
+		FROM sprint_tasks m
+		JOIN tasks t ON t.id = m.task_id
+		WHERE m.sprint_id = ? AND (? OR m.removed_at IS NULL) AND t.deleted_at IS NULL
+		ORDER BY m.added_at, m.id
+	`
+	rows, err := r.db.Query(query, pointsFieldID, sprintID, includeRemoved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sprint tasks: %w", err)
+	}
+
+	defer rows.Close()
+
+	var members []entity.SprintMember
+	for rows.Next() {
+		var m entity.SprintMember
+		if err := rows.Scan(&m.SprintID, &m.TaskID, &m.AddedAt, &m.RemovedAt, &m.CarriedOver, &m.Outcome, &m.Status, &m.Points); err != nil {
+			return nil, fmt.Errorf("failed to scan sprint task: %w", err)
+		}
+
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+// GetOpenSprintOf returns the open sprint of the kind that currently holds the task, or 0.
+func (r *SprintRepository) GetOpenSprintOf(taskID int64, kind constant.SprintKind) (int64, error) {
+	query := `
+		SELECT s.id FROM sprint_tasks m JOIN sprints s ON s.id = m.sprint_id
+		WHERE m.task_id = ? AND m.removed_at IS NULL AND s.kind = ? AND s.closed_at IS NULL
+		LIMIT 1
+	`
+	var sprintID int64
+	if err := r.db.QueryRow(query, taskID, kind).Scan(&sprintID); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("failed to find open sprint: %w", err)
+	}
+
+	return sprintID, nil
+}
+
+func (r *SprintRepository) AddTasks(sprintID int64, taskIDs []int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	if err := addSprintTasks(tx, sprintID, taskIDs, false, time.Now()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func addSprintTasks(tx *sql.Tx, sprintID int64, taskIDs []int64, carriedOver bool, at time.Time) error {
+	for _, taskID := range taskIDs {
+		if _, err := tx.Exec(`INSERT INTO sprint_tasks (sprint_id, task_id, added_at, carried_over) VALUES (?, ?, ?, ?)`, sprintID, taskID, at, carriedOver); err != nil {
+			return fmt.Errorf("failed to add task to sprint: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *SprintRepository) RemoveTask(sprintID, taskID int64) error {
+	result, err := r.db.Exec(`UPDATE sprint_tasks SET removed_at = ? WHERE sprint_id = ? AND task_id = ? AND removed_at IS NULL`, time.Now(), sprintID, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to remove task from sprint: %w", err)
+	}
+
+	return expectOneRow(result, "task is not in the sprint")
+}
+
+// Close marks the sprint closed, records each member's outcome and adds the carried over
+// tasks to the target sprint, all in one transaction.
+func (r *SprintRepository) Close(sprintID int64, outcomes map[int64]constant.SprintOutcome, carryOverTo *int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	now := time.Now()
+	result, err := tx.Exec(`UPDATE sprints SET closed_at = ? WHERE id = ? AND closed_at IS NULL`, now, sprintID)
+	if err != nil {
+		return fmt.Errorf("failed to close sprint: %w", err)
+	}
+
+	if err := expectOneRow(result, "sprint is already closed"); err != nil {
+		return err
+	}
+
+	var carried []int64
+	for taskID, outcome := range outcomes {
+		if _, err := tx.Exec(`UPDATE sprint_tasks SET outcome = ? WHERE sprint_id = ? AND task_id = ? AND removed_at IS NULL`, outcome, sprintID, taskID); err != nil {
+			return fmt.Errorf("failed to record sprint outcome: %w", err)
+		}
+
+		if outcome == constant.SprintOutcomeCarriedOver {
+			carried = append(carried, taskID)
+		}
+	}
+
+	if carryOverTo != nil {
+		if err := addSprintTasks(tx, *carryOverTo, carried, true, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetStatusHistory returns the status changes of every task that was ever in the sprint.
+func (r *SprintRepository) GetStatusHistory(sprintID int64) ([]entity.StatusChange, error) {
+	query := `
+		SELECT id, task_id, user_id, project_id, from_status, to_status, reason, changed_at
+		FROM task_status_history
+		WHERE task_id IN (SELECT task_id FROM sprint_tasks WHERE sprint_id = ?)
+		ORDER BY changed_at, id
+	`
+	rows, err := r.db.Query(query, sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status history: %w", err)
+	}
+
+	defer rows.Close()
+
+	var history []entity.StatusChange
+	for rows.Next() {
+		var change entity.StatusChange
+		if err := rows.Scan(&change.ID, &change.TaskID, &change.UserID, &change.ProjectID, &change.FromStatus, &change.ToStatus, &change.Reason, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status change: %w", err)
+		}
+
+		history = append(history, change)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// Archive archives the task and its subtree.
+func (r *TaskRepository) Archive(id, userID int64) error {
+	query := `
+		WITH RECURSIVE tree(id) AS (
+			SELECT id FROM tasks WHERE id = ? AND user_id = ? AND deleted_at IS NULL AND archived_at IS NULL
+			UNION
+			SELECT t.id FROM tasks t JOIN tree ON t.parent_id = tree.id WHERE t.deleted_at IS NULL AND t.archived_at IS NULL
+		)
+		UPDATE tasks SET archived_at = ? WHERE id IN (SELECT id FROM tree)
+	`
+	result, err := r.db.Exec(query, id, userID, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to archive task: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("task not found or already archived")
+	}
+
+	return nil
+}
+
+// Unarchive takes the task and its subtree out of the archive. A task whose parent is
+// archived cannot be unarchived on its own.
+func (r *TaskRepository) Unarchive(id, userID int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	var parentArchived bool
+	query := `
+		SELECT EXISTS (SELECT 1 FROM tasks p WHERE p.id = t.parent_id AND p.archived_at IS NOT NULL)
+		FROM tasks t
+		WHERE t.id = ? AND t.user_id = ? AND t.deleted_at IS NULL AND t.archived_at IS NOT NULL
+	`
+	if err := tx.QueryRow(query, id, userID).Scan(&parentArchived); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("task not found or not archived")
+		}
+
+		return fmt.Errorf("failed to get archived task: %w", err)
+	}
+
+	if parentArchived {
+		return fmt.Errorf("the parent task is archived; unarchive it first")
+	}
+
+	query = `
+		WITH RECURSIVE tree(id) AS (
+			SELECT ?
+			UNION
+			SELECT t.id FROM tasks t JOIN tree ON t.parent_id = tree.id WHERE t.deleted_at IS NULL
+		)
+		UPDATE tasks SET archived_at = NULL WHERE id IN (SELECT id FROM tree)
+	`
+	if _, err := tx.Exec(query, id); err != nil {
+		return fmt.Errorf("failed to unarchive task: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ArchiveDoneBefore archives the subtrees of top-level tasks whose status is in the done
+// category and has not changed since cutoff. It returns the IDs of the users whose tasks
+// were archived. A single statement avoids holding a read lock while waiting to write.
+// Times are compared through datetime(), since updated_at and older status changes are
+// stored with the server's local offset.
+func (r *TaskRepository) ArchiveDoneBefore(cutoff time.Time) ([]int64, error) {
+	query := `
+		WITH RECURSIVE tree(id) AS (
+			SELECT t.id
+			FROM tasks t
+			LEFT JOIN workflow_statuses ws ON ws.project_id = t.project_id AND ws.name = t.status
+			WHERE t.parent_id IS NULL AND t.deleted_at IS NULL AND t.archived_at IS NULL
+				AND COALESCE(ws.category, CASE WHEN t.status = ? THEN ? ELSE '' END) = ?
+				AND COALESCE((SELECT MAX(datetime(h.changed_at)) FROM task_status_history h WHERE h.task_id = t.id), datetime(t.updated_at)) < datetime(?)
+			UNION
+			SELECT t.id FROM tasks t JOIN tree ON t.parent_id = tree.id WHERE t.deleted_at IS NULL AND t.archived_at IS NULL
+		)
+		UPDATE tasks SET archived_at = ? WHERE id IN (SELECT id FROM tree)
+		RETURNING user_id
+	`
+	rows, err := r.db.Query(query, constant.TaskStatusDone, constant.StatusCategoryDone, constant.StatusCategoryDone, cutoff.UTC(), time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive done tasks: %w", err)
+	}
+
+	defer rows.Close()
+
+	users := make(map[int64]bool)
+	var userIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan archived task: %w", err)
+		}
+
+		if !users[userID] {
+			users[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to archive done tasks: %w", err)
+	}
+
+	return userIDs, nil
+}
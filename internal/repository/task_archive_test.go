@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"testing"
+	"time"
+)
+
+// TestArchiveDoneBeforeMixedOffsets checks that status changes stored with a local offset
+// are compared by instant rather than as text.
+func TestArchiveDoneBeforeMixedOffsets(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewTaskRepository(db)
+
+	east := time.FixedZone("UTC+7", 7*60*60)
+	cutoff := time.Date(2026, time.October, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		title     string
+		changedAt time.Time
+		archived  bool
+	}{
+		// 15:00 at +07:00 is 08:00 UTC, before the cutoff although the text sorts after it
+		{"local offset before cutoff", time.Date(2026, time.October, 10, 15, 0, 0, 0, east), true},
+		// 12:30 UTC is after the cutoff
+		{"local offset after cutoff", time.Date(2026, time.October, 10, 19, 30, 0, 0, east), false},
+		{"utc before cutoff", cutoff.Add(-time.Minute), true},
+		{"utc after cutoff", cutoff.Add(time.Minute), false},
+	}
+
+	ids := make([]int64, len(tests))
+	for i, tt := range tests {
+		task := &entity.Task{UserID: 1, Title: tt.title, Status: constant.TaskStatusDone, Priority: constant.TaskPriorityNone}
+		if err := repo.Create(task); err != nil {
+			t.Fatal(err)
+		}
+
+		ids[i] = task.ID
+		if _, err := db.Exec(`INSERT INTO task_status_history (task_id, user_id, from_status, to_status, reason, changed_at) VALUES (?, 1, 'in progress', 'done', '', ?)`, task.ID, tt.changedAt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := repo.ArchiveDoneBefore(cutoff); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, tt := range tests {
+		var archived bool
+		if err := db.QueryRow(`SELECT archived_at IS NOT NULL FROM tasks WHERE id = ?`, ids[i]).Scan(&archived); err != nil {
+			t.Fatal(err)
+		}
+
+		if archived != tt.archived {
+			t.Errorf("%s: archived = %v, want %v", tt.title, archived, tt.archived)
+		}
+	}
+}
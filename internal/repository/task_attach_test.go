@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"task-management-backend/internal/domain/entity"
+	"testing"
+)
+
+// sqliteMaxVariables is SQLite's default limit on the number of bound parameters.
+const sqliteMaxVariables = 32766
+
+// TestGetByFilterManyTasks checks that loading related data for more tasks than SQLite
+// accepts parameters still works.
+func TestGetByFilterManyTasks(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewTaskRepository(db)
+
+	count := sqliteMaxVariables + 100
+	query := `
+		WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < ?)
+		INSERT INTO tasks (user_id, parent_id, title, description, status, priority)
+		SELECT 1, CASE WHEN i % 2 = 0 THEN i - 1 END, 'task ' || i, '', 'to do', 'none' FROM n
+	`
+	if _, err := db.Exec(query, count); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec(`INSERT INTO task_dependencies (blocker_id, blocked_id, user_id) VALUES (1, ?, 1)`, count-1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec(`INSERT INTO checklist_items (task_id, title, position) VALUES (?, 'item', 0)`, count); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, err := repo.GetByFilter(entity.TaskFilter{UserID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tasks) != count/2 {
+		t.Fatalf("roots = %d, want %d", len(tasks), count/2)
+	}
+
+	var subtasks int
+	for _, task := range tasks {
+		subtasks += len(task.SubTasks)
+	}
+
+	if subtasks != count/2 {
+		t.Errorf("subtasks = %d, want %d", subtasks, count/2)
+	}
+
+	last := tasks[0]
+	if last.ID != int64(count-1) || len(last.BlockedBy) != 1 || last.SubTasks[0].Checklist == nil {
+		t.Errorf("task %d: blocked_by = %v, subtask checklist = %v", last.ID, last.BlockedBy, last.SubTasks[0].Checklist)
+	}
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+)
+
+// boardOrder orders cards within a column by their manual rank.
+const boardOrder = "rank ASC, id ASC"
+
+// swimlaneExpr is the SQL value a swimlane groups by. Select custom fields group by the
+// option position of the task's first value; only the field's numeric ID is interpolated.
+func swimlaneExpr(lane *entity.Swimlane) string {
+	if lane == nil {
+		return "NULL"
+	}
+
+	switch lane.Kind {
+	case entity.SwimlanePriority:
+		return "priority"
+	case entity.SwimlaneCustomField:
+		return fmt.Sprintf("(SELECT MIN(v.number_value) FROM task_field_values v WHERE v.task_id = tasks.id AND v.field_id = %d)", lane.Field.ID)
+	}
+
+	return "NULL"
+}
+
+// laneScanner scans a task row followed by its swimlane value.
+type laneScanner struct {
+	rows *sql.Rows
+	lane *sql.NullString
+}
+
+func (s laneScanner) Scan(dest ...any) error {
+	return s.rows.Scan(append(dest, s.lane)...)
+}
+
+// GetBoard counts the top-level tasks of every status and swimlane and loads one page of
+// each with a single windowed query.
+func (r *TaskRepository) GetBoard(filter entity.BoardFilter) ([]entity.BoardCell, error) {
+	if len(filter.Statuses) == 0 {
+		return nil, nil
+	}
+
+	conditions := []string{"user_id = ?", "parent_id IS NULL", "deleted_at IS NULL", "archived_at IS NULL", "status IN (" + placeholders(len(filter.Statuses)) + ")"}
+	args := []any{filter.UserID}
+	for _, status := range filter.Statuses {
+		args = append(args, status)
+	}
+
+	if filter.ProjectID != nil {
+		conditions = append(conditions, "project_id = ?")
+		args = append(args, *filter.ProjectID)
+	}
+
+	where := strings.Join(conditions, " AND ")
+	lane := swimlaneExpr(filter.Swimlane)
+
+	type cellKey struct {
+		status constant.TaskStatus
+		lane   sql.NullString
+	}
+
+	cells := make(map[cellKey]*entity.BoardCell)
+	var order []cellKey
+
+	rows, err := r.db.Query(`SELECT status, `+lane+`, COUNT(*) FROM tasks WHERE `+where+` GROUP BY 1, 2`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count board tasks: %w", err)
+	}
+
+	for rows.Next() {
+		var key cellKey
+		var count int
+		if err := rows.Scan(&key.status, &key.lane, &count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan board count: %w", err)
+		}
+
+		cell := &entity.BoardCell{Status: key.status, Count: count}
+		if key.lane.Valid {
+			value := key.lane.String
+			cell.Lane = &value
+		}
+
+		cells[key] = cell
+		order = append(order, key)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read board counts: %w", err)
+	}
+
+	query := `
+		SELECT ` + taskColumns + `, lane
+		FROM (
+			SELECT ` + taskColumns + `, ` + lane + ` AS lane,
+				ROW_NUMBER() OVER (PARTITION BY status, ` + lane + ` ORDER BY ` + boardOrder + `) AS position
+			FROM tasks
+			WHERE ` + where + `
+		)
+		WHERE position > ? AND position <= ?
+		ORDER BY position
+	`
+	rows, err = r.db.Query(query, append(args, filter.Offset, filter.Offset+filter.Limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query board tasks: %w", err)
+	}
+
+	defer rows.Close()
+
+	var tasks []entity.Task
+	var keys []cellKey
+	for rows.Next() {
+		var key cellKey
+		task, err := scanTask(laneScanner{rows: rows, lane: &key.lane})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan board task: %w", err)
+		}
+
+		key.status = task.Status
+		tasks = append(tasks, task)
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read board tasks: %w", err)
+	}
+
+	if err := attachSubTasks(r.db, tasks, boardOrder, false); err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	if err := attachTaskData(r.db, tasks); err != nil {
+		return nil, err
+	}
+
+	for i, key := range keys {
+		if cell, ok := cells[key]; ok {
+			cell.Tasks = append(cell.Tasks, tasks[i])
+		}
+	}
+
+	result := make([]entity.BoardCell, 0, len(order))
+	for _, key := range order {
+		result = append(result, *cells[key])
+	}
+
+	return result, nil
+}
+
+// CountTopLevelInStatus counts the project's top-level tasks in the status, leaving out
+// excludeID, for WIP limit checks.
+func (r *TaskRepository) CountTopLevelInStatus(projectID int64, status constant.TaskStatus, excludeID int64) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM tasks WHERE project_id = ? AND status = ? AND parent_id IS NULL AND deleted_at IS NULL AND archived_at IS NULL AND id != ?`
+	if err := r.db.QueryRow(query, projectID, status, excludeID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count tasks in status: %w", err)
+	}
+
+	return count, nil
+}
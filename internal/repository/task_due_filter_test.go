@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"reflect"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"testing"
+	"time"
+)
+
+// TestGetByFilterDueMatchesSubtasks checks that the due filters select roots through any
+// task in their tree.
+func TestGetByFilterDueMatchesSubtasks(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewTaskRepository(db)
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-24*time.Hour), now.Add(24*time.Hour)
+	create := func(title string, parentID *int64, status constant.TaskStatus, due *time.Time) int64 {
+		task := &entity.Task{UserID: 1, ParentID: parentID, Title: title, Status: status, Priority: constant.TaskPriorityNone, DueAt: due}
+		if err := repo.Create(task); err != nil {
+			t.Fatal(err)
+		}
+
+		return task.ID
+	}
+
+	create("overdue root", nil, constant.TaskStatusTodo, &past)
+	create("done root", nil, constant.TaskStatusDone, &past)
+
+	parent := create("grandparent", nil, constant.TaskStatusTodo, &future)
+	child := create("child", &parent, constant.TaskStatusTodo, nil)
+	create("overdue grandchild", &child, constant.TaskStatusTodo, &past)
+
+	finished := create("finished tree", nil, constant.TaskStatusTodo, nil)
+	create("done child", &finished, constant.TaskStatusDone, &past)
+
+	trashed := create("trashed tree", nil, constant.TaskStatusTodo, nil)
+	trashedChild := create("trashed child", &trashed, constant.TaskStatusTodo, &past)
+	if _, err := db.Exec(`UPDATE tasks SET deleted_at = ? WHERE id = ?`, now, trashedChild); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		filter entity.TaskFilter
+		want   []string
+	}{
+		{"overdue", entity.TaskFilter{OverdueAt: &now, DoneStatuses: []constant.TaskStatus{constant.TaskStatusDone}}, []string{"grandparent", "overdue root"}},
+		{"due range", entity.TaskFilter{DueFrom: &now}, []string{"grandparent"}},
+		{"conditions hold for one task", entity.TaskFilter{DueFrom: &now, OverdueAt: &now}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.UserID = 1
+			tasks, err := repo.GetByFilter(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, task := range tasks {
+				got = append(got, task.Title)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("titles = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"time"
+)
+
+// filterColumns are the task columns a filter expression may compare.
+var filterColumns = map[string]bool{
+	"title":       true,
+	"description": true,
+	"status":      true,
+	"priority":    true,
+	"project_id":  true,
+	"due_at":      true,
+	"start_at":    true,
+	"created_at":  true,
+	"updated_at":  true,
+}
+
+var filterOperators = map[string]string{
+	"eq":  "=",
+	"ne":  "IS NOT",
+	"lt":  "<",
+	"lte": "<=",
+	"gt":  ">",
+	"gte": ">=",
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns using '\' as the escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// filterExprCondition compiles a filter expression to a parameterized SQL condition on the
+// tasks table. Only whitelisted columns and operators are written into the SQL; all values
+// are bound as arguments.
+func filterExprCondition(expr entity.FilterExpr) (string, []any, error) {
+	switch {
+	case len(expr.And) > 0:
+		return filterExprList(expr.And, " AND ")
+	case len(expr.Or) > 0:
+		return filterExprList(expr.Or, " OR ")
+	case expr.Not != nil:
+		condition, args, err := filterExprCondition(*expr.Not)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// a comparison with NULL is unknown, which NOT leaves unknown; count it as false
+		return "NOT COALESCE(" + condition + ", 0)", args, nil
+	case expr.Condition != nil:
+		return filterCondition(*expr.Condition)
+	}
+
+	return "", nil, fmt.Errorf("empty filter expression")
+}
+
+func filterExprList(exprs []entity.FilterExpr, separator string) (string, []any, error) {
+	conditions := make([]string, 0, len(exprs))
+	var args []any
+	for _, expr := range exprs {
+		condition, exprArgs, err := filterExprCondition(expr)
+		if err != nil {
+			return "", nil, err
+		}
+
+		conditions = append(conditions, condition)
+		args = append(args, exprArgs...)
+	}
+
+	return "(" + strings.Join(conditions, separator) + ")", args, nil
+}
+
+func filterCondition(c entity.FilterCondition) (string, []any, error) {
+	if c.CustomField != nil {
+		condition, arg := customFieldCondition(*c.CustomField)
+		return condition, []any{c.CustomField.Field.ID, arg}, nil
+	}
+
+	if !filterColumns[c.Field] {
+		return "", nil, fmt.Errorf("invalid filter field: %s", c.Field)
+	}
+
+	switch c.Op {
+	case "null":
+		return c.Field + " IS NULL", nil, nil
+	case "not_null":
+		return c.Field + " IS NOT NULL", nil, nil
+	case "contains":
+		return c.Field + ` LIKE ? ESCAPE '\'`, []any{"%" + likeEscaper.Replace(fmt.Sprint(c.Value)) + "%"}, nil
+	case "in":
+		if len(c.Values) == 0 {
+			return "0", nil, nil
+		}
+
+		return c.Field + " IN (" + placeholders(len(c.Values)) + ")", c.Values, nil
+	}
+
+	operator, ok := filterOperators[c.Op]
+	if !ok {
+		return "", nil, fmt.Errorf("invalid filter operator: %s", c.Op)
+	}
+
+	value := c.Value
+	if t, ok := value.(time.Time); ok {
+		value = t.UTC()
+	}
+
+	return c.Field + " " + operator + " ?", []any{value}, nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"testing"
+	"time"
+)
+
+func cond(field, op string, value any) entity.FilterExpr {
+	return entity.FilterExpr{Condition: &entity.FilterCondition{Field: field, Op: op, Value: value}}
+}
+
+func TestFilterConditionOperators(t *testing.T) {
+	operators := []struct {
+		op   string
+		sql  string
+		args bool
+	}{
+		{"eq", "%s = ?", true},
+		{"ne", "%s IS NOT ?", true},
+		{"lt", "%s < ?", true},
+		{"lte", "%s <= ?", true},
+		{"gt", "%s > ?", true},
+		{"gte", "%s >= ?", true},
+		{"null", "%s IS NULL", false},
+		{"not_null", "%s IS NOT NULL", false},
+	}
+
+	columns := make([]string, 0, len(filterColumns))
+	for column := range filterColumns {
+		columns = append(columns, column)
+	}
+
+	sort.Strings(columns)
+	for _, column := range columns {
+		for _, op := range operators {
+			t.Run(column+" "+op.op, func(t *testing.T) {
+				sql, args, err := filterExprCondition(cond(column, op.op, "v"))
+				if err != nil {
+					t.Fatalf("error = %v", err)
+				}
+
+				if want := strings.ReplaceAll(op.sql, "%s", column); sql != want {
+					t.Errorf("sql = %q, want %q", sql, want)
+				}
+
+				var want []any
+				if op.args {
+					want = []any{"v"}
+				}
+
+				if !reflect.DeepEqual(args, want) {
+					t.Errorf("args = %v, want %v", args, want)
+				}
+			})
+		}
+	}
+}
+
+func TestFilterExprCondition(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	day := time.Date(2026, time.November, 1, 0, 0, 0, 0, jakarta)
+
+	tests := []struct {
+		name string
+		expr entity.FilterExpr
+		sql  string
+		args []any
+	}{
+		{
+			name: "contains escapes wildcards",
+			expr: cond("title", "contains", `50%_off\`),
+			sql:  `title LIKE ? ESCAPE '\'`,
+			args: []any{`%50\%\_off\\%`},
+		},
+		{
+			name: "in",
+			expr: entity.FilterExpr{Condition: &entity.FilterCondition{Field: "priority", Op: "in", Values: []any{"high", "urgent"}}},
+			sql:  "priority IN (?,?)",
+			args: []any{"high", "urgent"},
+		},
+		{
+			name: "empty in matches nothing",
+			expr: entity.FilterExpr{Condition: &entity.FilterCondition{Field: "status", Op: "in"}},
+			sql:  "0",
+		},
+		{
+			name: "times are bound in UTC",
+			expr: cond("due_at", "gte", day),
+			sql:  "due_at >= ?",
+			args: []any{day.UTC()},
+		},
+		{
+			name: "and",
+			expr: entity.FilterExpr{And: []entity.FilterExpr{cond("status", "eq", "done"), cond("project_id", "eq", int64(3))}},
+			sql:  "(status = ? AND project_id = ?)",
+			args: []any{"done", int64(3)},
+		},
+		{
+			name: "or of and",
+			expr: entity.FilterExpr{Or: []entity.FilterExpr{
+				{And: []entity.FilterExpr{cond("status", "eq", "done"), cond("title", "eq", "a")}},
+				cond("due_at", "null", nil),
+			}},
+			sql:  "((status = ? AND title = ?) OR due_at IS NULL)",
+			args: []any{"done", "a"},
+		},
+		{
+			name: "not treats unknown as false",
+			expr: entity.FilterExpr{Not: &entity.FilterExpr{Or: []entity.FilterExpr{cond("due_at", "lt", "x"), cond("start_at", "lt", "y")}}},
+			sql:  "NOT COALESCE((due_at < ? OR start_at < ?), 0)",
+			args: []any{"x", "y"},
+		},
+		{
+			name: "text custom field",
+			expr: entity.FilterExpr{Condition: &entity.FilterCondition{CustomField: &entity.CustomFieldFilter{
+				Field: entity.CustomField{ID: 7, Type: constant.CustomFieldText},
+				Op:    "eq",
+				Value: entity.FieldValue{Text: ptr("backend")},
+			}}},
+			sql:  "EXISTS (SELECT 1 FROM task_field_values v WHERE v.task_id = tasks.id AND v.field_id = ? AND v.text_value = ?)",
+			args: []any{int64(7), ptr("backend")},
+		},
+		{
+			name: "number custom field range",
+			expr: entity.FilterExpr{Condition: &entity.FilterCondition{CustomField: &entity.CustomFieldFilter{
+				Field: entity.CustomField{ID: 8, Type: constant.CustomFieldNumber},
+				Op:    "gte",
+				Value: entity.FieldValue{Number: ptr(5.0)},
+			}}},
+			sql:  "EXISTS (SELECT 1 FROM task_field_values v WHERE v.task_id = tasks.id AND v.field_id = ? AND v.number_value >= ?)",
+			args: []any{int64(8), ptr(5.0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := filterExprCondition(tt.expr)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			if sql != tt.sql {
+				t.Errorf("sql = %q, want %q", sql, tt.sql)
+			}
+
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %#v, want %#v", args, tt.args)
+			}
+		})
+	}
+}
+
+func TestFilterExprConditionRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		expr entity.FilterExpr
+		err  string
+	}{
+		{"unknown column", cond("user_id", "eq", 1), "invalid filter field: user_id"},
+		{"deleted column", cond("deleted_at", "null", nil), "invalid filter field: deleted_at"},
+		{"injected column", cond("title = title OR 1", "eq", 1), "invalid filter field: title = title OR 1"},
+		{"unknown operator", cond("title", "like", "a"), "invalid filter operator: like"},
+		{"injected operator", cond("title", "= ? OR 1 = ?", "a"), "invalid filter operator: = ? OR 1 = ?"},
+		{"empty expression", entity.FilterExpr{}, "empty filter expression"},
+		{"nested rejection", entity.FilterExpr{And: []entity.FilterExpr{cond("title", "eq", "a"), {Not: &entity.FilterExpr{}}}}, "empty filter expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := filterExprCondition(tt.expr)
+			if err == nil || err.Error() != tt.err {
+				t.Fatalf("error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+// TestFilterExprConditionRuns checks that compiled conditions are valid SQL with the
+// intended NULL handling.
+func TestFilterExprConditionRuns(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewTaskRepository(db)
+	due := time.Date(2026, time.November, 1, 9, 0, 0, 0, time.UTC)
+	for _, task := range []*entity.Task{
+		{UserID: 1, Title: "100% done", Status: constant.TaskStatusDone, Priority: constant.TaskPriorityHigh, DueAt: &due},
+		{UserID: 1, Title: "plain", Status: constant.TaskStatusTodo, Priority: constant.TaskPriorityNone},
+	} {
+		if err := repo.Create(task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		expr entity.FilterExpr
+		want []string
+	}{
+		{"contains percent", cond("title", "contains", "%"), []string{"100% done"}},
+		{"ne matches null", cond("due_at", "ne", due), []string{"plain"}},
+		{"not of null comparison", entity.FilterExpr{Not: ptr(cond("due_at", "lt", due.Add(time.Hour)))}, []string{"plain"}},
+		{"time range", entity.FilterExpr{And: []entity.FilterExpr{cond("due_at", "gte", due.Add(-time.Hour)), cond("due_at", "lt", due.Add(time.Hour))}}, []string{"100% done"}},
+		{"in", entity.FilterExpr{Condition: &entity.FilterCondition{Field: "priority", Op: "in", Values: []any{"high", "urgent"}}}, []string{"100% done"}},
+		{"empty in", entity.FilterExpr{Condition: &entity.FilterCondition{Field: "priority", Op: "in"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, args, err := filterExprCondition(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rows, err := db.Query(`SELECT title FROM tasks WHERE `+condition+` ORDER BY id`, args...)
+			if err != nil {
+				t.Fatalf("query %q: %v", condition, err)
+			}
+
+			defer rows.Close()
+
+			var got []string
+			for rows.Next() {
+				var title string
+				if err := rows.Scan(&title); err != nil {
+					t.Fatal(err)
+				}
+
+				got = append(got, title)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("titles = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
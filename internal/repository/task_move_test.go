@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"reflect"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"testing"
+	"time"
+)
+
+// TestMoveSkipsTrashedSiblings checks that tasks in the trash neither bound a move nor
+// get re-ranked with the list they were deleted from.
+func TestMoveSkipsTrashedSiblings(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewTaskRepository(db)
+
+	ids := map[string]int64{}
+	for _, title := range []string{"a", "trashed", "b", "moved"} {
+		task := &entity.Task{UserID: 1, Title: title, Status: constant.TaskStatusTodo, Priority: constant.TaskPriorityNone}
+		if err := repo.Create(task); err != nil {
+			t.Fatal(err)
+		}
+
+		ids[title] = task.ID
+	}
+
+	// a and b share a rank, as after concurrent moves, so the move re-ranks the list
+	for title, key := range map[string]string{"a": "a0", "trashed": "a0", "b": "a0", "moved": "a5"} {
+		if _, err := db.Exec(`UPDATE tasks SET rank = ? WHERE id = ?`, key, ids[title]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := db.Exec(`UPDATE tasks SET deleted_at = ? WHERE id = ?`, time.Now().UTC(), ids["trashed"]); err != nil {
+		t.Fatal(err)
+	}
+
+	after, before := ids["a"], ids["b"]
+	if err := repo.Move(ids["moved"], 1, nil, &after, &before); err != nil {
+		t.Fatal(err)
+	}
+
+	var trashedRank string
+	if err := db.QueryRow(`SELECT rank FROM tasks WHERE id = ?`, ids["trashed"]).Scan(&trashedRank); err != nil {
+		t.Fatal(err)
+	}
+
+	if trashedRank != "a0" {
+		t.Errorf("trashed rank = %q, want it left at %q", trashedRank, "a0")
+	}
+
+	rows, err := db.Query(`SELECT title FROM tasks WHERE deleted_at IS NULL ORDER BY rank, id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer rows.Close()
+
+	var got []string
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			t.Fatal(err)
+		}
+
+		got = append(got, title)
+	}
+
+	if want := []string{"a", "moved", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
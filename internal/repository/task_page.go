@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+)
+
+// GetPage returns one page of the filter's root tasks with their subtrees. Pages are read
+// with a keyset condition on the sort terms, so they stay stable while tasks are added or
+// removed elsewhere in the list.
+func (r *TaskRepository) GetPage(filter entity.TaskFilter, page entity.PageRequest) (*entity.TaskPage, error) {
+	conditions, args, err := filterConditions(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := taskOrderTerms(filter.Sort)
+	result := &entity.TaskPage{Tasks: []entity.Task{}}
+
+	if page.WithTotal {
+		var total int
+		query := `SELECT COUNT(*) FROM tasks WHERE ` + strings.Join(conditions, " AND ")
+		if err := r.db.QueryRow(query, args...).Scan(&total); err != nil {
+			return nil, fmt.Errorf("failed to count tasks: %w", err)
+		}
+
+		result.Total = &total
+	}
+
+	if page.Cursor != "" {
+		values, err := cursorValues(page.Cursor, filter.Sort, len(terms))
+		if err != nil {
+			return nil, err
+		}
+
+		condition, keysetArgs := keysetCondition(terms, values)
+		conditions = append(conditions, condition)
+		args = append(args, keysetArgs...)
+	}
+
+	orderBy := taskOrderBy(filter.Sort)
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY ` + orderBy + `
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, append(args, page.Limit+1)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks by filter: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+
+		result.Tasks = append(result.Tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tasks: %w", err)
+	}
+
+	if len(result.Tasks) > page.Limit {
+		result.Tasks = result.Tasks[:page.Limit]
+		if result.NextCursor, err = r.PageCursor(filter.Sort, result.Tasks[page.Limit-1].ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := attachSubTasks(r.db, result.Tasks, orderBy, filter.IncludeArchived); err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	if err := attachTaskData(r.db, result.Tasks); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// PageCursor returns the cursor of the page that ends with the task.
+func (r *TaskRepository) PageCursor(sort []entity.SortField, taskID int64) (string, error) {
+	terms := taskOrderTerms(sort)
+	columns := make([]string, 0, 2*len(terms))
+	for _, term := range terms {
+		columns = append(columns, "typeof("+term.expr+")", "CAST("+term.expr+" AS TEXT)")
+	}
+
+	raw := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range raw {
+		dest[i] = &raw[i]
+	}
+
+	query := `SELECT ` + strings.Join(columns, ", ") + ` FROM tasks WHERE id = ?`
+	if err := r.db.QueryRow(query, taskID).Scan(dest...); err != nil {
+		return "", fmt.Errorf("failed to read cursor: %w", err)
+	}
+
+	cursor := entity.TaskCursor{Sort: entity.SortSpec(sort), ID: taskID}
+	for i := 0; i < len(raw); i += 2 {
+		cursor.Keys = append(cursor.Keys, entity.CursorKey{Type: raw[i].String, Value: raw[i+1].String})
+	}
+
+	return cursor.Encode(), nil
+}
+
+// cursorValues decodes a cursor into arguments for the sort terms.
+func cursorValues(value string, sort []entity.SortField, terms int) ([]any, error) {
+	cursor, err := entity.DecodeTaskCursor(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.Sort != entity.SortSpec(sort) || len(cursor.Keys) != terms {
+		return nil, fmt.Errorf("cursor does not match the sort")
+	}
+
+	values := make([]any, len(cursor.Keys))
+	for i, key := range cursor.Keys {
+		switch key.Type {
+		case "null":
+			values[i] = nil
+		case "integer":
+			values[i], err = strconv.ParseInt(key.Value, 10, 64)
+		case "real":
+			values[i], err = strconv.ParseFloat(key.Value, 64)
+		case "text":
+			values[i] = key.Value
+		default:
+			err = fmt.Errorf("unsupported type %q", key.Type)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor")
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"testing"
+	"time"
+)
+
+// TestGetPageKeysetTies pages through sorts whose keys repeat and include NULLs, and checks
+// that every task is listed exactly once and in the order of a single unpaged query.
+func TestGetPageKeysetTies(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewTaskRepository(db)
+
+	early := time.Date(2026, time.October, 19, 9, 0, 0, 0, time.UTC)
+	late := early.Add(48 * time.Hour)
+	dues := []*time.Time{nil, &early, &late, &early, nil, &late, &early, nil, &early, nil, &late}
+	ranks := []string{"a1", "a0", "a1", "a1", "a0", "a2", "a0", "a1", "a2", "a0", "a1"}
+	titles := []string{"b", "A", "a", "B", "c", "a", "C", "b", "A", "c", "B"}
+	for i, due := range dues {
+		task := &entity.Task{UserID: 1, Title: titles[i], Status: constant.TaskStatusTodo, Priority: constant.TaskPriorityNone, DueAt: due}
+		if err := repo.Create(task); err != nil {
+			t.Fatal(err)
+		}
+
+		// ranks are unique when created; concurrent moves can leave them tied
+		if _, err := db.Exec(`UPDATE tasks SET rank = ? WHERE id = ?`, ranks[i], task.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		sort []entity.SortField
+	}{
+		{"due ascending", []entity.SortField{{Field: "due_at"}}},
+		{"due descending", []entity.SortField{{Field: "due_at", Desc: true}}},
+		{"manual", []entity.SortField{{Field: "manual"}}},
+		{"manual descending", []entity.SortField{{Field: "manual", Desc: true}}},
+		{"due then manual", []entity.SortField{{Field: "due_at", Desc: true}, {Field: "manual"}}},
+		{"title ignores case", []entity.SortField{{Field: "title"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := entity.TaskFilter{UserID: 1, Sort: tt.sort}
+			all, err := repo.GetByFilter(filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(all) != len(dues) {
+				t.Fatalf("unpaged query returned %d tasks, want %d", len(all), len(dues))
+			}
+
+			var paged []entity.Task
+			seen := make(map[int64]bool)
+			page := entity.PageRequest{Limit: 2}
+			for i := 0; ; i++ {
+				if i > len(dues) {
+					t.Fatal("paging did not end")
+				}
+
+				result, err := repo.GetPage(filter, page)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, task := range result.Tasks {
+					if seen[task.ID] {
+						t.Fatalf("task %d is listed twice", task.ID)
+					}
+
+					seen[task.ID] = true
+				}
+
+				paged = append(paged, result.Tasks...)
+				if result.NextCursor == "" {
+					break
+				}
+
+				page.Cursor = result.NextCursor
+			}
+
+			if len(paged) != len(all) {
+				t.Fatalf("pages listed %d tasks, want %d", len(paged), len(all))
+			}
+
+			for i := range all {
+				if paged[i].ID != all[i].ID {
+					t.Fatalf("task %d of the pages is %d, want %d", i, paged[i].ID, all[i].ID)
+				}
+			}
+
+			if tt.sort[0].Field == "due_at" {
+				nulls := false
+				for _, task := range paged {
+					if task.DueAt == nil {
+						nulls = true
+					} else if nulls {
+						t.Fatalf("task %d with a due date comes after a task without one", task.ID)
+					}
+				}
+			}
+		})
+	}
+}
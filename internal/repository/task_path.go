@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+)
+
+// maxPathDepth bounds walks up the task tree and sums over a subtree, so a corrupted parent
+// chain that loops cannot recurse forever. Loading subtasks is bounded by
+// entity.MaxTreeDepth instead.
+const maxPathDepth = 1000
+
+// taskPaths returns the ancestors of each task, root first, in one recursive query. Root
+// tasks have an empty path and are left out of the map.
+func taskPaths(db *sql.DB, taskIDs []int64) (map[int64][]entity.TaskPathItem, error) {
+	paths := make(map[int64][]entity.TaskPathItem)
+	if len(taskIDs) == 0 {
+		return paths, nil
+	}
+
+	query := `
+		WITH RECURSIVE ancestors (task_id, id, parent_id, title, depth) AS (
+			SELECT t.id, p.id, p.parent_id, p.title, 1
+			FROM tasks t JOIN tasks p ON p.id = t.parent_id
+			WHERE t.id ` + inIDList + `
+			UNION ALL
+			SELECT a.task_id, p.id, p.parent_id, p.title, a.depth + 1
+			FROM ancestors a JOIN tasks p ON p.id = a.parent_id
+			WHERE a.depth < ?
+		)
+		SELECT task_id, id, title FROM ancestors ORDER BY task_id, depth DESC
+	`
+	rows, err := db.Query(query, idList(taskIDs), maxPathDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task paths: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int64
+		var item entity.TaskPathItem
+		if err := rows.Scan(&taskID, &item.ID, &item.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan task path: %w", err)
+		}
+
+		paths[taskID] = append(paths[taskID], item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read task paths: %w", err)
+	}
+
+	return paths, nil
+}
+
+// GetAncestors returns the chain of the task's ancestors, root first.
+func (r *TaskRepository) GetAncestors(id, userID int64) ([]entity.TaskPathItem, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM tasks WHERE id = ? AND user_id = ? AND deleted_at IS NULL)`
+	if err := r.db.QueryRow(query, id, userID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("task not found")
+	}
+
+	paths, err := taskPaths(r.db, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if paths[id] == nil {
+		return []entity.TaskPathItem{}, nil
+	}
+
+	return paths[id], nil
+}
+
+func (r *TaskRepository) GetPaths(taskIDs []int64) (map[int64][]entity.TaskPathItem, error) {
+	return taskPaths(r.db, taskIDs)
+}
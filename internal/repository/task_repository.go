@@ -2,12 +2,69 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"task-management-backend/internal/domain/entity"
 	"task-management-backend/pkg/constant"
+	"task-management-backend/pkg/rank"
 	"time"
 )
 
+const taskColumns = `id, user_id, parent_id, project_id, title, description, status, priority, start_at, due_at, rrule, timezone, recurrence_start, copy_sub_tasks, original_estimate, remaining_estimate, rank, created_at, updated_at, deleted_at, archived_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTask(s rowScanner) (entity.Task, error) {
+	var task entity.Task
+	err := s.Scan(&task.ID, &task.UserID, &task.ParentID, &task.ProjectID, &task.Title, &task.Description, &task.Status, &task.Priority, &task.StartAt, &task.DueAt, &task.RRule, &task.Timezone, &task.RecurrenceStart, &task.CopySubTasks, &task.OriginalEstimate, &task.RemainingEstimate, &task.Rank, &task.CreatedAt, &task.UpdatedAt, &task.DeletedAt, &task.ArchivedAt)
+	return task, err
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// inIDList matches a column against the IDs of one idList argument. Unlike placeholders it
+// takes a single bound parameter, so it works for any number of IDs.
+const inIDList = "IN (SELECT value FROM json_each(?))"
+
+// idList encodes IDs as the JSON array argument of inIDList.
+func idList(ids any) string {
+	data, _ := json.Marshal(ids)
+	return string(data)
+}
+
+// utcTime normalises optional timestamps so that stored values compare correctly as text.
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	u := t.UTC()
+	return &u
+}
+
+// indexTaskTree maps every task of the tree to its ID and lists the IDs, for loaders that
+// fill in related data with a single query.
+func indexTaskTree(tasks []entity.Task) (map[int64]*entity.Task, []any) {
+	index := make(map[int64]*entity.Task)
+	var ids []any
+	var collect func(tasks []entity.Task)
+	collect = func(tasks []entity.Task) {
+		for i := range tasks {
+			index[tasks[i].ID] = &tasks[i]
+			ids = append(ids, tasks[i].ID)
+			collect(tasks[i].SubTasks)
+		}
+	}
+	collect(tasks)
+
+	return index, ids
+}
+
 type TaskRepository struct {
 	db *sql.DB
 }
@@ -18,10 +75,10 @@ func NewTaskRepository(db *sql.DB) *TaskRepository {
 
 func (r *TaskRepository) GetAllByUserID(userID int64) ([]entity.Task, error) {
 	query := `
-		SELECT id, user_id, parent_id, title, description, status, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE user_id = ? AND parent_id IS NULL
-		ORDER BY created_at DESC
+		WHERE user_id = ? AND parent_id IS NULL AND deleted_at IS NULL AND archived_at IS NULL
+		ORDER BY ` + defaultTaskOrder + `
 	`
 	rows, err := r.db.Query(query, userID)
 	if err != nil {
@@ -30,71 +87,119 @@ func (r *TaskRepository) GetAllByUserID(userID int64) ([]entity.Task, error) {
 
 	defer rows.Close()
 
-	var tasks []entity.Task
-	for rows.Next() {
-		var task entity.Task
-		err := rows.Scan(&task.ID, &task.UserID, &task.ParentID, &task.Title, &task.Description, &task.Status, &task.CreatedAt, &task.UpdatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan task: %w", err)
-		}
+	return r.scanTasksWithSubTasks(rows, defaultTaskOrder, false)
+}
 
-		subTasks, err := r.GetSubTasks(task.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get subtasks: %w", err)
-		}
+func (r *TaskRepository) GetSubTasks(parentID int64) ([]entity.Task, error) {
+	return r.getSubTasks(parentID, defaultTaskOrder)
+}
 
-		task.SubTasks = subTasks
-		tasks = append(tasks, task)
+func (r *TaskRepository) getSubTasks(parentID int64, orderBy string) ([]entity.Task, error) {
+	parent := []entity.Task{{ID: parentID}}
+	if err := attachSubTasks(r.db, parent, orderBy, true); err != nil {
+		return nil, err
 	}
 
-	return tasks, nil
+	return parent[0].SubTasks, nil
 }
 
-func (r *TaskRepository) GetSubTasks(parentID int64) ([]entity.Task, error) {
+// attachSubTasks loads all descendants of tasks with one recursive query and nests them
+// under their parents, ordering siblings by orderBy. Archived subtrees are skipped unless
+// includeArchived is set.
+func attachSubTasks(db *sql.DB, tasks []entity.Task, orderBy string, includeArchived bool) error {
+	return attachSubTasksToDepth(db, tasks, orderBy, includeArchived, -1)
+}
+
+// attachSubTasksToDepth is attachSubTasks limited to depth levels below tasks; a negative
+// depth loads all levels up to entity.MaxTreeDepth.
+func attachSubTasksToDepth(db *sql.DB, tasks []entity.Task, orderBy string, includeArchived bool, depth int) error {
+	if len(tasks) == 0 || depth == 0 {
+		return nil
+	}
+
+	active := "deleted_at IS NULL"
+	if !includeArchived {
+		active += " AND archived_at IS NULL"
+	}
+
+	ids := make([]any, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
 	query := `
-		SELECT id, user_id, parent_id, title, description, status, created_at, updated_at
+		WITH RECURSIVE tree(id, level) AS (
+			SELECT id, 1 FROM tasks WHERE parent_id ` + inIDList + ` AND ` + active + `
+			UNION
+			SELECT t.id, tree.level + 1 FROM tasks t JOIN tree ON t.parent_id = tree.id WHERE ` + active + ` AND tree.level < ?
+		)
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE parent_id = ?
-		ORDER BY created_at DESC
+		WHERE id IN (SELECT id FROM tree)
+		ORDER BY ` + orderBy + `
 	`
-	rows, err := r.db.Query(query, parentID)
+	if depth < 0 {
+		depth = entity.MaxTreeDepth
+	}
+
+	rows, err := db.Query(query, idList(ids), depth)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query subtasks: %w", err)
+		return fmt.Errorf("failed to query subtasks: %w", err)
 	}
 
 	defer rows.Close()
 
-	var tasks []entity.Task
+	children := make(map[int64][]entity.Task)
 	for rows.Next() {
-		var task entity.Task
-		err := rows.Scan(&task.ID, &task.UserID, &task.ParentID, &task.Title, &task.Description, &task.Status, &task.CreatedAt, &task.UpdatedAt)
+		task, err := scanTask(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan subtask: %w", err)
+			return fmt.Errorf("failed to scan subtask: %w", err)
 		}
 
-		subTasks, err := r.GetSubTasks(task.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get nested subtasks: %w", err)
-		}
+		children[*task.ParentID] = append(children[*task.ParentID], task)
+	}
 
-		task.SubTasks = subTasks
-		tasks = append(tasks, task)
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read subtasks: %w", err)
 	}
 
-	return tasks, nil
+	nestSubTasks(tasks, children)
+	return nil
+}
+
+// attachTaskData fills in the related data shown with every task of the trees.
+func attachTaskData(db *sql.DB, tasks []entity.Task) error {
+	if err := attachDependencies(db, tasks); err != nil {
+		return err
+	}
+
+	if err := attachCustomFields(db, tasks); err != nil {
+		return err
+	}
+
+	if err := attachTimeSpent(db, tasks); err != nil {
+		return err
+	}
+
+	return attachChecklistSummaries(db, tasks)
+}
+
+// nestSubTasks fills in SubTasks from children, which lists tasks by parent ID.
+func nestSubTasks(tasks []entity.Task, children map[int64][]entity.Task) {
+	for i := range tasks {
+		tasks[i].SubTasks = children[tasks[i].ID]
+		nestSubTasks(tasks[i].SubTasks, children)
+	}
 }
 
 func (r *TaskRepository) GetByID(id, userID int64) (*entity.Task, error) {
 	query := `
-		SELECT id, user_id, parent_id, title, description, status, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE id = ? AND user_id = ?
+		WHERE id = ? AND user_id = ? AND deleted_at IS NULL
 	`
 
-	var task entity.Task
-	err := r.db.QueryRow(query, id, userID).Scan(
-		&task.ID, &task.UserID, &task.ParentID, &task.Title, &task.Description, &task.Status, &task.CreatedAt, &task.UpdatedAt,
-	)
+	task, err := scanTask(r.db.QueryRow(query, id, userID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("task not found")
@@ -103,25 +208,50 @@ func (r *TaskRepository) GetByID(id, userID int64) (*entity.Task, error) {
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
 
-	subTasks, err := r.GetSubTasks(task.ID)
-	if err != nil {
+	tasks := []entity.Task{task}
+	if err := attachSubTasks(r.db, tasks, defaultTaskOrder, true); err != nil {
 		return nil, fmt.Errorf("failed to get subtasks: %w", err)
 	}
 
-	task.SubTasks = subTasks
+	if err := attachTaskData(r.db, tasks); err != nil {
+		return nil, err
+	}
 
-	return &task, nil
+	return &tasks[0], nil
 }
 
 func (r *TaskRepository) Create(task *entity.Task) error {
+	return insertTask(r.db, task)
+}
+
+// execer is satisfied by *sql.DB and *sql.Tx.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// insertTask creates the task. Tasks without a rank are placed first among their
+// siblings, matching the default newest-first order.
+func insertTask(db execer, task *entity.Task) error {
 	query := `
-		INSERT INTO tasks (user_id, parent_id, title, description, status, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO tasks (user_id, parent_id, project_id, title, description, status, priority, start_at, due_at, rrule, timezone, recurrence_start, copy_sub_tasks, original_estimate, remaining_estimate, rank, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
+	if task.Rank == "" {
+		first, err := firstRank(db, task.UserID, task.ParentID)
+		if err != nil {
+			return err
+		}
+
+		if task.Rank, err = rank.Between("", first); err != nil {
+			return err
+		}
+	}
+
 	now := time.Now()
 	task.CreatedAt = now
 	task.UpdatedAt = now
-	result, err := r.db.Exec(query, task.UserID, task.ParentID, task.Title, task.Description, task.Status, task.CreatedAt, task.UpdatedAt)
+	result, err := db.Exec(query, task.UserID, task.ParentID, task.ProjectID, task.Title, task.Description, task.Status, task.Priority, utcTime(task.StartAt), utcTime(task.DueAt), task.RRule, task.Timezone, utcTime(task.RecurrenceStart), task.CopySubTasks, task.OriginalEstimate, task.RemainingEstimate, task.Rank, task.CreatedAt, task.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create task: %w", err)
 	}
@@ -135,14 +265,385 @@ func (r *TaskRepository) Create(task *entity.Task) error {
 	return nil
 }
 
+// firstRank returns the lowest rank among the siblings under parentID, or "" when there
+// are none. Tasks in the trash are not siblings; archived tasks are, since they keep their
+// place in lists that include them.
+func firstRank(db execer, userID int64, parentID *int64) (string, error) {
+	var first string
+	query := `SELECT COALESCE(MIN(rank), '') FROM tasks WHERE user_id = ? AND parent_id IS ? AND deleted_at IS NULL AND rank != ''`
+	if err := db.QueryRow(query, userID, parentID).Scan(&first); err != nil {
+		return "", fmt.Errorf("failed to read sibling ranks: %w", err)
+	}
+
+	return first, nil
+}
+
+// CreateTree inserts root and its SubTasks tree in one transaction, filling in IDs and
+// parent IDs, and records each task's initial status. Subtasks keep their slice order in
+// the manual order.
+func (r *TaskRepository) CreateTree(root *entity.Task) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	var insert func(task *entity.Task) error
+	insert = func(task *entity.Task) error {
+		if err := insertTask(tx, task); err != nil {
+			return err
+		}
+
+		if err := insertStatusChange(tx, &entity.StatusChange{TaskID: task.ID, UserID: task.UserID, ProjectID: task.ProjectID, ToStatus: task.Status}); err != nil {
+			return err
+		}
+
+		ranks, err := rank.Sequence("", len(task.SubTasks))
+		if err != nil {
+			return err
+		}
+
+		for i := range task.SubTasks {
+			parentID := task.ID
+			task.SubTasks[i].ParentID = &parentID
+			task.SubTasks[i].Rank = ranks[i]
+			if err := insert(&task.SubTasks[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := insert(root); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CloneTree copies the task sourceID, with its subtree when opts.SubTasks is set, in one
+// transaction. The source tree is read with a single recursive query and related rows are
+// copied with one statement each through a temporary old to new ID map.
+func (r *TaskRepository) CloneTree(sourceID, userID int64, opts entity.CloneOptions) (*entity.Task, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	// parents come before their children, and siblings keep their creation order
+	query := `
+		WITH RECURSIVE tree(id, depth) AS (
+			SELECT id, 0 FROM tasks WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, tree.depth + 1 FROM tasks t JOIN tree ON t.parent_id = tree.id WHERE ? AND t.deleted_at IS NULL
+		)
+		SELECT ` + taskColumns + `
+		FROM tasks JOIN tree USING (id)
+		ORDER BY tree.depth, id
+	`
+	rows, err := tx.Query(query, sourceID, userID, opts.SubTasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task tree: %w", err)
+	}
+
+	var sources []entity.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+
+		sources = append(sources, task)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read task tree: %w", err)
+	}
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("task not found")
+	}
+
+	if _, err := tx.Exec(`CREATE TEMP TABLE clone_map (old_id INTEGER PRIMARY KEY, new_id INTEGER NOT NULL)`); err != nil {
+		return nil, fmt.Errorf("failed to create clone map: %w", err)
+	}
+
+	newIDs := make(map[int64]int64, len(sources))
+	clones := make([]entity.Task, len(sources))
+	for i, source := range sources {
+		clone := source
+		clone.ProjectID = opts.ProjectID
+		if i == 0 {
+			clone.ParentID = opts.ParentID
+			clone.Rank = ""
+			if opts.Title != "" {
+				clone.Title = opts.Title
+			}
+		} else {
+			parentID := newIDs[*source.ParentID]
+			clone.ParentID = &parentID
+		}
+
+		if opts.Status != "" {
+			clone.Status = opts.Status
+			clone.RemainingEstimate = clone.OriginalEstimate
+		}
+
+		if err := insertTask(tx, &clone); err != nil {
+			return nil, err
+		}
+
+		if err := insertStatusChange(tx, &entity.StatusChange{TaskID: clone.ID, UserID: clone.UserID, ProjectID: clone.ProjectID, ToStatus: clone.Status}); err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.Exec(`INSERT INTO clone_map (old_id, new_id) VALUES (?, ?)`, source.ID, clone.ID); err != nil {
+			return nil, fmt.Errorf("failed to map cloned task: %w", err)
+		}
+
+		newIDs[source.ID] = clone.ID
+		clones[i] = clone
+	}
+
+	if opts.Checklists {
+		now := time.Now()
+		query := `
+			INSERT INTO checklist_items (task_id, title, checked, position, created_at, updated_at)
+			SELECT m.new_id, c.title, CASE WHEN ? THEN 0 ELSE c.checked END, c.position, ?, ?
+			FROM checklist_items c JOIN clone_map m ON c.task_id = m.old_id
+		`
+		if _, err := tx.Exec(query, opts.Status != "", now, now); err != nil {
+			return nil, fmt.Errorf("failed to clone checklists: %w", err)
+		}
+	}
+
+	if opts.CustomFields {
+		query := `
+			INSERT INTO task_field_values (task_id, field_id, text_value, number_value)
+			SELECT m.new_id, v.field_id, v.text_value, v.number_value
+			FROM task_field_values v JOIN clone_map m ON v.task_id = m.old_id
+		`
+		if _, err := tx.Exec(query); err != nil {
+			return nil, fmt.Errorf("failed to clone custom field values: %w", err)
+		}
+	}
+
+	// dependencies between two cloned tasks are kept; links leaving the tree are not
+	query = `
+		INSERT INTO task_dependencies (blocker_id, blocked_id, user_id)
+		SELECT blocker.new_id, blocked.new_id, d.user_id
+		FROM task_dependencies d
+		JOIN clone_map blocker ON d.blocker_id = blocker.old_id
+		JOIN clone_map blocked ON d.blocked_id = blocked.old_id
+	`
+	if _, err := tx.Exec(query); err != nil {
+		return nil, fmt.Errorf("failed to clone dependencies: %w", err)
+	}
+
+	if _, err := tx.Exec(`DROP TABLE temp.clone_map`); err != nil {
+		return nil, fmt.Errorf("failed to drop clone map: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit clone: %w", err)
+	}
+
+	// newest siblings first, as in defaultTaskOrder
+	children := make(map[int64][]entity.Task)
+	for i := len(clones) - 1; i > 0; i-- {
+		children[*clones[i].ParentID] = append(children[*clones[i].ParentID], clones[i])
+	}
+
+	tasks := clones[:1]
+	nestSubTasks(tasks, children)
+
+	if err := attachTaskData(r.db, tasks); err != nil {
+		return nil, err
+	}
+
+	return &tasks[0], nil
+}
+
+// Move puts the task under parentID and ranks it between the siblings afterID and
+// beforeID. When only one neighbour is given the other is the adjacent sibling, and with
+// neither the task moves to the top. Only the moved task's row is updated unless its
+// neighbours share a rank, in which case the sibling list is re-ranked once.
+func (r *TaskRepository) Move(taskID, userID int64, parentID, afterID, beforeID *int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	after, before, err := moveNeighbours(tx, taskID, userID, parentID, afterID, beforeID)
+	if err != nil {
+		return err
+	}
+
+	if after != "" && after == before {
+		if err := rerankSiblings(tx, taskID, userID, parentID); err != nil {
+			return err
+		}
+
+		if after, before, err = moveNeighbours(tx, taskID, userID, parentID, afterID, beforeID); err != nil {
+			return err
+		}
+	}
+
+	key, err := rank.Between(after, before)
+	if err != nil {
+		return fmt.Errorf("failed to rank task: %w", err)
+	}
+
+	query := `UPDATE tasks SET parent_id = ?, rank = ?, updated_at = ? WHERE id = ? AND user_id = ? AND deleted_at IS NULL`
+	result, err := tx.Exec(query, parentID, key, time.Now(), taskID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to move task: %w", err)
+	}
+
+	if err := expectOneRow(result, "task not found"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetPosition puts the task back under parentID with the given rank, e.g. to undo a move.
+func (r *TaskRepository) SetPosition(taskID, userID int64, parentID *int64, rank string) error {
+	query := `UPDATE tasks SET parent_id = ?, rank = ?, updated_at = ? WHERE id = ? AND user_id = ? AND deleted_at IS NULL`
+	result, err := r.db.Exec(query, parentID, rank, time.Now(), taskID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to move task: %w", err)
+	}
+
+	return expectOneRow(result, "task not found")
+}
+
+// moveNeighbours resolves the ranks the moved task goes between; "" is the start or end of
+// the list. Like firstRank it skips tasks in the trash but not archived tasks.
+func moveNeighbours(tx *sql.Tx, taskID, userID int64, parentID, afterID, beforeID *int64) (string, string, error) {
+	siblingRank := func(id int64) (string, error) {
+		var key string
+		query := `SELECT rank FROM tasks WHERE id = ? AND user_id = ? AND parent_id IS ? AND deleted_at IS NULL`
+		if err := tx.QueryRow(query, id, userID, parentID).Scan(&key); err != nil {
+			if err == sql.ErrNoRows {
+				return "", fmt.Errorf("task %d is not in the target list", id)
+			}
+
+			return "", fmt.Errorf("failed to read sibling rank: %w", err)
+		}
+
+		return key, nil
+	}
+
+	// adjacent finds the nearest sibling rank beyond key in the given direction
+	adjacent := func(key string, next bool) (string, error) {
+		query := `SELECT COALESCE(MAX(rank), '') FROM tasks WHERE user_id = ? AND parent_id IS ? AND deleted_at IS NULL AND id != ? AND rank < ?`
+		args := []any{userID, parentID, taskID, key}
+		if next {
+			query = `SELECT COALESCE(MIN(rank), '') FROM tasks WHERE user_id = ? AND parent_id IS ? AND deleted_at IS NULL AND id != ? AND rank > ?`
+		}
+
+		var found string
+		if err := tx.QueryRow(query, args...).Scan(&found); err != nil {
+			return "", fmt.Errorf("failed to read sibling ranks: %w", err)
+		}
+
+		return found, nil
+	}
+
+	var after, before string
+	var err error
+	switch {
+	case afterID != nil && beforeID != nil:
+		if after, err = siblingRank(*afterID); err != nil {
+			return "", "", err
+		}
+
+		if before, err = siblingRank(*beforeID); err != nil {
+			return "", "", err
+		}
+
+		if after > before {
+			return "", "", fmt.Errorf("task %d comes after task %d", *afterID, *beforeID)
+		}
+	case afterID != nil:
+		if after, err = siblingRank(*afterID); err != nil {
+			return "", "", err
+		}
+
+		before, err = adjacent(after, true)
+	case beforeID != nil:
+		if before, err = siblingRank(*beforeID); err != nil {
+			return "", "", err
+		}
+
+		after, err = adjacent(before, false)
+	default:
+		query := `SELECT COALESCE(MIN(rank), '') FROM tasks WHERE user_id = ? AND parent_id IS ? AND deleted_at IS NULL AND id != ?`
+		err = tx.QueryRow(query, userID, parentID, taskID).Scan(&before)
+	}
+
+	if err != nil {
+		return "", "", err
+	}
+
+	return after, before, nil
+}
+
+// rerankSiblings spaces out the ranks of the tasks under parentID, keeping their order. It
+// is only needed when concurrent moves gave two siblings the same rank.
+func rerankSiblings(tx *sql.Tx, taskID, userID int64, parentID *int64) error {
+	rows, err := tx.Query(`SELECT id FROM tasks WHERE user_id = ? AND parent_id IS ? AND deleted_at IS NULL AND id != ? ORDER BY rank, id`, userID, parentID, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to query siblings: %w", err)
+	}
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan sibling: %w", err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read siblings: %w", err)
+	}
+
+	keys, err := rank.Sequence("", len(ids))
+	if err != nil {
+		return err
+	}
+
+	for i, id := range ids {
+		if _, err := tx.Exec(`UPDATE tasks SET rank = ? WHERE id = ?`, keys[i], id); err != nil {
+			return fmt.Errorf("failed to rank task: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (r *TaskRepository) Update(task *entity.Task) error {
 	query := `
 		UPDATE tasks
-		SET title = ?, description = ?, status = ?, parent_id = ?, updated_at = ?
-		WHERE id = ? AND user_id = ?
+		SET title = ?, description = ?, status = ?, priority = ?, parent_id = ?, project_id = ?, start_at = ?, due_at = ?, rrule = ?, timezone = ?, recurrence_start = ?, copy_sub_tasks = ?, original_estimate = ?, remaining_estimate = ?, updated_at = ?
+		WHERE id = ? AND user_id = ? AND deleted_at IS NULL
 	`
 	task.UpdatedAt = time.Now()
-	result, err := r.db.Exec(query, task.Title, task.Description, task.Status, task.ParentID, task.UpdatedAt, task.ID, task.UserID)
+	result, err := r.db.Exec(query, task.Title, task.Description, task.Status, task.Priority, task.ParentID, task.ProjectID, utcTime(task.StartAt), utcTime(task.DueAt), task.RRule, task.Timezone, utcTime(task.RecurrenceStart), task.CopySubTasks, task.OriginalEstimate, task.RemainingEstimate, task.UpdatedAt, task.ID, task.UserID)
 	if err != nil {
 		return fmt.Errorf("failed to update task: %w", err)
 	}
@@ -159,31 +660,61 @@ func (r *TaskRepository) Update(task *entity.Task) error {
 	return nil
 }
 
-func (r *TaskRepository) Delete(id, userID int64) error {
-	query := `DELETE FROM tasks WHERE id = ? AND user_id = ?`
-	result, err := r.db.Exec(query, id, userID)
-	if err != nil {
-		return fmt.Errorf("failed to delete task: %w", err)
-	}
+// GetNextOccurrenceID returns the occurrence created when the recurring task was completed,
+// or nil if there is none or it has been purged.
+func (r *TaskRepository) GetNextOccurrenceID(taskID int64) (*int64, error) {
+	query := `
+		SELECT n.id
+		FROM tasks t JOIN tasks n ON n.id = t.next_occurrence_id
+		WHERE t.id = ?
+	`
+	var id int64
+	if err := r.db.QueryRow(query, taskID).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return nil, fmt.Errorf("failed to get next occurrence: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("task not found")
+	return &id, nil
+}
+
+// SetNextOccurrenceID links a recurring task to the occurrence its completion created. It
+// leaves updated_at alone, since the link is bookkeeping rather than an edit.
+func (r *TaskRepository) SetNextOccurrenceID(taskID int64, nextID *int64) error {
+	if _, err := r.db.Exec(`UPDATE tasks SET next_occurrence_id = ? WHERE id = ?`, nextID, taskID); err != nil {
+		return fmt.Errorf("failed to set next occurrence: %w", err)
 	}
 
 	return nil
 }
 
+// IsAncestor walks up the parent chain of taskID with a recursive CTE and reports
+// whether ancestorID is on it.
+func (r *TaskRepository) IsAncestor(ancestorID, taskID int64) (bool, error) {
+	query := `
+		WITH RECURSIVE ancestors(id, parent_id) AS (
+			SELECT id, parent_id FROM tasks WHERE id = ?
+			UNION
+			SELECT t.id, t.parent_id FROM tasks t JOIN ancestors a ON t.id = a.parent_id
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = ?)
+	`
+	var exists bool
+	if err := r.db.QueryRow(query, taskID, ancestorID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check ancestors: %w", err)
+	}
+
+	return exists, nil
+}
+
 func (r *TaskRepository) GetByUserIDAndStatus(userID int64, status constant.TaskStatus) ([]entity.Task, error) {
 	query := `
-		SELECT id, user_id, parent_id, title, description, status, created_at, updated_at
+		SELECT ` + taskColumns + `
 		FROM tasks
-		WHERE user_id = ? AND status = ? AND parent_id IS NULL
-		ORDER BY created_at DESC
+		WHERE user_id = ? AND status = ? AND parent_id IS NULL AND deleted_at IS NULL AND archived_at IS NULL
+		ORDER BY ` + defaultTaskOrder + `
 	`
 	rows, err := r.db.Query(query, userID, status)
 	if err != nil {
@@ -192,22 +723,198 @@ func (r *TaskRepository) GetByUserIDAndStatus(userID int64, status constant.Task
 
 	defer rows.Close()
 
-	var tasks []entity.Task
-	for rows.Next() {
-		var task entity.Task
-		err := rows.Scan(&task.ID, &task.UserID, &task.ParentID, &task.Title, &task.Description, &task.Status, &task.CreatedAt, &task.UpdatedAt)
+	return r.scanTasksWithSubTasks(rows, defaultTaskOrder, false)
+}
+
+func (r *TaskRepository) GetByFilter(filter entity.TaskFilter) ([]entity.Task, error) {
+	conditions, args, err := filterConditions(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	orderBy := taskOrderBy(filter.Sort)
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY ` + orderBy + `
+	`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks by filter: %w", err)
+	}
+
+	defer rows.Close()
+
+	return r.scanTasksWithSubTasks(rows, orderBy, filter.IncludeArchived)
+}
+
+// filterConditions returns the WHERE conditions selecting the root tasks of the filter.
+func filterConditions(filter entity.TaskFilter) ([]string, []any, error) {
+	conditions := []string{"user_id = ?", "parent_id IS NULL", "deleted_at IS NULL"}
+	args := []any{filter.UserID}
+
+	if filter.ProjectID != nil {
+		conditions = append(conditions, "project_id = ?")
+		args = append(args, *filter.ProjectID)
+	}
+
+	if !filter.IncludeArchived {
+		conditions = append(conditions, "archived_at IS NULL")
+	}
+
+	if filter.SprintID != nil {
+		conditions = append(conditions, "id IN (SELECT task_id FROM sprint_tasks WHERE sprint_id = ? AND removed_at IS NULL)")
+		args = append(args, *filter.SprintID)
+	}
+
+	if filter.Status != constant.TaskStatusDefault && filter.Status != constant.TaskStatusAll {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "status IN ("+placeholders(len(filter.Statuses))+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+
+	var due []string
+	var dueArgs []any
+	if filter.DueFrom != nil {
+		due = append(due, "due_at >= ?")
+		dueArgs = append(dueArgs, filter.DueFrom.UTC())
+	}
+
+	if filter.DueUntil != nil {
+		due = append(due, "due_at < ?")
+		dueArgs = append(dueArgs, filter.DueUntil.UTC())
+	}
+
+	if filter.OverdueAt != nil {
+		due = append(due, "due_at < ?")
+		dueArgs = append(dueArgs, filter.OverdueAt.UTC())
+		if len(filter.DoneStatuses) > 0 {
+			due = append(due, "status NOT IN ("+placeholders(len(filter.DoneStatuses))+")")
+			for _, status := range filter.DoneStatuses {
+				dueArgs = append(dueArgs, status)
+			}
+		}
+	}
+
+	if len(due) > 0 {
+		// a root matches when it or any task in its tree is due, walking up from the
+		// matching tasks so the due_at index narrows the search
+		archived := ""
+		if !filter.IncludeArchived {
+			archived = " AND archived_at IS NULL"
+		}
+
+		conditions = append(conditions, `id IN (
+			WITH RECURSIVE due(id, parent_id) AS (
+				SELECT id, parent_id FROM tasks WHERE user_id = ? AND deleted_at IS NULL`+archived+` AND `+strings.Join(due, " AND ")+`
+				UNION
+				SELECT p.id, p.parent_id FROM tasks p JOIN due ON p.id = due.parent_id
+			)
+			SELECT id FROM due WHERE parent_id IS NULL
+		)`)
+		args = append(append(args, filter.UserID), dueArgs...)
+	}
+
+	for _, cf := range filter.CustomFields {
+		condition, arg := customFieldCondition(cf)
+		conditions = append(conditions, condition)
+		args = append(args, cf.Field.ID, arg)
+	}
+
+	if filter.Expr != nil {
+		condition, exprArgs, err := filterExprCondition(*filter.Expr)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan task: %w", err)
+			return nil, nil, err
 		}
 
-		subTasks, err := r.GetSubTasks(task.ID)
+		conditions = append(conditions, condition)
+		args = append(args, exprArgs...)
+	}
+
+	return conditions, args, nil
+}
+
+func (r *TaskRepository) scanTasksWithSubTasks(rows *sql.Rows, orderBy string, includeArchived bool) ([]entity.Task, error) {
+	var tasks []entity.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get subtasks: %w", err)
+			return nil, fmt.Errorf("failed to scan task: %w", err)
 		}
 
-		task.SubTasks = subTasks
 		tasks = append(tasks, task)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tasks: %w", err)
+	}
+
+	if err := attachSubTasks(r.db, tasks, orderBy, includeArchived); err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	if err := attachTaskData(r.db, tasks); err != nil {
+		return nil, err
+	}
+
 	return tasks, nil
 }
+
+func (r *TaskRepository) AddStatusChange(change *entity.StatusChange) error {
+	return insertStatusChange(r.db, change)
+}
+
+func insertStatusChange(db execer, change *entity.StatusChange) error {
+	query := `
+		INSERT INTO task_status_history (task_id, user_id, project_id, from_status, to_status, reason, changed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	// stored in UTC so that changes compare and sort correctly as text
+	change.ChangedAt = time.Now().UTC()
+	result, err := db.Exec(query, change.TaskID, change.UserID, change.ProjectID, change.FromStatus, change.ToStatus, change.Reason, change.ChangedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record status change: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	change.ID = id
+	return nil
+}
+
+func (r *TaskRepository) GetStatusHistory(taskID int64) ([]entity.StatusChange, error) {
+	query := `
+		SELECT id, task_id, user_id, project_id, from_status, to_status, reason, changed_at
+		FROM task_status_history
+		WHERE task_id = ?
+		ORDER BY changed_at, id
+	`
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status history: %w", err)
+	}
+
+	defer rows.Close()
+
+	history := []entity.StatusChange{}
+	for rows.Next() {
+		var change entity.StatusChange
+		if err := rows.Scan(&change.ID, &change.TaskID, &change.UserID, &change.ProjectID, &change.FromStatus, &change.ToStatus, &change.Reason, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status change: %w", err)
+		}
+
+		history = append(history, change)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+)
+
+const defaultTaskOrder = "created_at DESC, id DESC"
+
+// priorityOrderExpr ranks priorities so that ascending order lists the most urgent first.
+var priorityOrderExpr = func() string {
+	var b strings.Builder
+	b.WriteString("CASE priority")
+	for i := len(constant.TaskPriorities) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", constant.TaskPriorities[i], len(constant.TaskPriorities)-1-i)
+	}
+
+	fmt.Fprintf(&b, " ELSE %d END", len(constant.TaskPriorities))
+	return b.String()
+}()
+
+// sortableTaskColumns maps entity.SortableTaskFields to SQL expressions. Only these
+// expressions are ever interpolated into ORDER BY clauses.
+var sortableTaskColumns = map[string]string{
+	"priority":   priorityOrderExpr,
+	"due_at":     "due_at",
+	"start_at":   "start_at",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"title":      "title COLLATE NOCASE",
+	"status":     "status",
+	"manual":     "rank",
+}
+
+// nullableTaskColumns are sorted with NULLs last regardless of direction.
+var nullableTaskColumns = map[string]bool{
+	"due_at":   true,
+	"start_at": true,
+}
+
+// orderTerm is one expression of an ORDER BY clause.
+type orderTerm struct {
+	expr string
+	desc bool
+}
+
+// defaultOrderTerms are the terms of defaultTaskOrder.
+var defaultOrderTerms = []orderTerm{{"created_at", true}, {"id", true}}
+
+// taskOrderTerms returns the ORDER BY terms of the sort. The last term is always the ID,
+// which makes the order total.
+func taskOrderTerms(sort []entity.SortField) []orderTerm {
+	if len(sort) == 0 {
+		return defaultOrderTerms
+	}
+
+	terms := make([]orderTerm, 0, len(sort)+1)
+	for _, s := range sort {
+		expr, ok := sortableTaskColumns[s.Field]
+		nullable := nullableTaskColumns[s.Field]
+		if s.CustomField != nil {
+			expr, ok, nullable = customFieldOrderExpr(*s.CustomField), true, true
+		}
+
+		if !ok {
+			continue
+		}
+
+		if nullable {
+			terms = append(terms, orderTerm{expr: expr + " IS NULL"})
+		}
+
+		terms = append(terms, orderTerm{expr: expr, desc: s.Desc})
+	}
+
+	return append(terms, orderTerm{expr: "id"})
+}
+
+func taskOrderBy(sort []entity.SortField) string {
+	terms := taskOrderTerms(sort)
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		direction := "ASC"
+		if term.desc {
+			direction = "DESC"
+		}
+
+		parts = append(parts, term.expr+" "+direction)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// keysetCondition matches the rows that sort after the given values of the terms:
+// (t1 > v1) OR (t1 IS v1 AND t2 > v2) OR ..., with < for descending terms. IS compares
+// NULLs as equal; a strict comparison with a NULL value never matches, which is right
+// because NULLs only meet NULLs behind an "IS NULL" term. Terms are parenthesized since
+// SQLite binds < and > tighter than IS, which would read "due_at IS NULL > ?" as
+// "due_at IS (NULL > ?)".
+func keysetCondition(terms []orderTerm, values []any) (string, []any) {
+	var alternatives []string
+	var args []any
+	for i, term := range terms {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, "("+terms[j].expr+") IS ?")
+			args = append(args, values[j])
+		}
+
+		operator := " > ?"
+		if term.desc {
+			operator = " < ?"
+		}
+
+		parts = append(parts, "("+term.expr+")"+operator)
+		args = append(args, values[i])
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+// customFieldValueColumn is the task_field_values column compared and sorted for the field.
+// Select fields sort by option position.
+func customFieldValueColumn(field entity.CustomField) string {
+	if field.Type.IsNumeric() || field.Type.IsSelect() {
+		return "number_value"
+	}
+
+	return "text_value"
+}
+
+// customFieldOrderExpr sorts by the task's value of the field. Only the field's numeric ID
+// is interpolated.
+func customFieldOrderExpr(field entity.CustomField) string {
+	expr := fmt.Sprintf("(SELECT MIN(v.%s) FROM task_field_values v WHERE v.task_id = tasks.id AND v.field_id = %d)", customFieldValueColumn(field), field.ID)
+	if field.Type == constant.CustomFieldText {
+		expr += " COLLATE NOCASE"
+	}
+
+	return expr
+}
+
+// customFieldCondition returns an EXISTS condition taking the field ID and the value as
+// arguments.
+func customFieldCondition(filter entity.CustomFieldFilter) (string, any) {
+	column := "text_value"
+	var arg any = filter.Value.Text
+	if filter.Field.Type.IsNumeric() {
+		column = "number_value"
+		arg = filter.Value.Number
+	}
+
+	operator := "="
+	switch filter.Op {
+	case "lt":
+		operator = "<"
+	case "lte":
+		operator = "<="
+	case "gt":
+		operator = ">"
+	case "gte":
+		operator = ">="
+	}
+
+	return "EXISTS (SELECT 1 FROM task_field_values v WHERE v.task_id = tasks.id AND v.field_id = ? AND v." + column + " " + operator + " ?)", arg
+}
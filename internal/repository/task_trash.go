@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"time"
+)
+
+// Delete moves the task and its subtree to the trash. Every task of the subtree gets the
+// same deleted_at, which is how Restore finds the tasks that were deleted together.
+func (r *TaskRepository) Delete(id, userID int64) error {
+	query := `
+		WITH RECURSIVE tree(id) AS (
+			SELECT id FROM tasks WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+			UNION
+			SELECT t.id FROM tasks t JOIN tree ON t.parent_id = tree.id WHERE t.deleted_at IS NULL
+		)
+		UPDATE tasks SET deleted_at = ? WHERE id IN (SELECT id FROM tree)
+	`
+	result, err := r.db.Exec(query, id, userID, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("task not found")
+	}
+
+	return nil
+}
+
+// GetTrash returns the user's deleted tasks that were deleted on their own, i.e. not
+// together with their parent, most recently deleted first.
+func (r *TaskRepository) GetTrash(userID int64) ([]entity.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks t
+		WHERE user_id = ? AND deleted_at IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM tasks p WHERE p.id = t.parent_id AND p.deleted_at = t.deleted_at)
+		ORDER BY deleted_at DESC, id DESC
+	`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trash: %w", err)
+	}
+
+	defer rows.Close()
+
+	tasks := []entity.Task{}
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
+
+// Restore takes a deleted task out of the trash together with the subtree that was deleted
+// with it. A task whose parent is still in the trash cannot be restored on its own.
+func (r *TaskRepository) Restore(id, userID int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	var deletedAt time.Time
+	var parentDeleted bool
+	query := `
+		SELECT t.deleted_at, EXISTS (SELECT 1 FROM tasks p WHERE p.id = t.parent_id AND p.deleted_at IS NOT NULL)
+		FROM tasks t
+		WHERE t.id = ? AND t.user_id = ? AND t.deleted_at IS NOT NULL
+	`
+	if err := tx.QueryRow(query, id, userID).Scan(&deletedAt, &parentDeleted); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("task not found in trash")
+		}
+
+		return fmt.Errorf("failed to get deleted task: %w", err)
+	}
+
+	if parentDeleted {
+		return fmt.Errorf("the parent task is in the trash; restore it first")
+	}
+
+	query = `
+		WITH RECURSIVE tree(id) AS (
+			SELECT ?
+			UNION
+			SELECT t.id FROM tasks t JOIN tree ON t.parent_id = tree.id WHERE t.deleted_at = ?
+		)
+		UPDATE tasks SET deleted_at = NULL, updated_at = ? WHERE id IN (SELECT id FROM tree)
+	`
+	if _, err := tx.Exec(query, id, deletedAt, time.Now()); err != nil {
+		return fmt.Errorf("failed to restore task: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// Purge permanently deletes a task in the trash and everything below it.
+func (r *TaskRepository) Purge(id, userID int64) error {
+	ids, err := r.treeIDs(`SELECT id FROM tasks WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		return fmt.Errorf("task not found in trash")
+	}
+
+	_, err = r.purgeTasks(ids)
+	return err
+}
+
+// PurgeDeletedBefore permanently deletes every task that went to the trash before cutoff
+// and returns how many tasks were removed.
+func (r *TaskRepository) PurgeDeletedBefore(cutoff time.Time) (int, error) {
+	ids, err := r.treeIDs(`SELECT id FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < ?`, cutoff.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	return r.purgeTasks(ids)
+}
+
+// treeIDs returns the IDs of the tasks selected by rootQuery and of all their descendants.
+func (r *TaskRepository) treeIDs(rootQuery string, args ...any) ([]any, error) {
+	query := `
+		WITH RECURSIVE tree(id) AS (
+			` + rootQuery + `
+			UNION
+			SELECT t.id FROM tasks t JOIN tree ON t.parent_id = tree.id
+		)
+		SELECT id FROM tree
+	`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task tree: %w", err)
+	}
+
+	defer rows.Close()
+
+	var ids []any
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// purgeTasks deletes the tasks and their related rows in one transaction. Foreign keys are
+// not enforced, so related tables are cleaned up explicitly. The IDs are resolved before
+// the transaction starts, so it only writes and never waits to upgrade a read lock.
+func (r *TaskRepository) purgeTasks(ids []any) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	list := idList(ids)
+	query := `DELETE FROM task_dependencies WHERE blocker_id ` + inIDList + ` OR blocked_id ` + inIDList
+	if _, err := tx.Exec(query, list, list); err != nil {
+		return 0, fmt.Errorf("failed to delete task dependencies: %w", err)
+	}
+
+	related := []struct{ query, name string }{
+		{`DELETE FROM task_field_values WHERE task_id ` + inIDList, "field values"},
+		{`DELETE FROM work_logs WHERE task_id ` + inIDList, "work logs"},
+		{`DELETE FROM checklist_items WHERE task_id ` + inIDList, "checklist"},
+		{`DELETE FROM sprint_tasks WHERE task_id ` + inIDList, "sprint memberships"},
+		{`DELETE FROM reminders WHERE task_id ` + inIDList, "reminders"},
+		{`DELETE FROM task_status_history WHERE task_id ` + inIDList, "status history"},
+		{`DELETE FROM task_versions WHERE task_id ` + inIDList, "versions"},
+		{`DELETE FROM notifications WHERE task_id ` + inIDList, "notifications"},
+		{`DELETE FROM task_undo WHERE task_id ` + inIDList, "undo entries"},
+		{`UPDATE tasks SET next_occurrence_id = NULL WHERE next_occurrence_id ` + inIDList, "occurrence links"},
+	}
+
+	for _, rel := range related {
+		if _, err := tx.Exec(rel.query, list); err != nil {
+			return 0, fmt.Errorf("failed to delete task %s: %w", rel.name, err)
+		}
+	}
+
+	result, err := tx.Exec(`DELETE FROM tasks WHERE id `+inIDList, list)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete tasks: %w", err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(count), tx.Commit()
+}
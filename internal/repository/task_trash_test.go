@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"testing"
+	"time"
+)
+
+// TestPurgeDeletesRelatedRows checks that purging a tree leaves no rows pointing at it.
+func TestPurgeDeletesRelatedRows(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewTaskRepository(db)
+
+	create := func(title string, parentID *int64) int64 {
+		task := &entity.Task{UserID: 1, ParentID: parentID, Title: title, Status: constant.TaskStatusTodo, Priority: constant.TaskPriorityNone}
+		if err := repo.Create(task); err != nil {
+			t.Fatal(err)
+		}
+
+		return task.ID
+	}
+
+	root := create("root", nil)
+	child := create("child", &root)
+	kept := create("kept", nil)
+
+	now := time.Now().UTC()
+	for _, id := range []int64{root, child} {
+		if _, err := db.Exec(`INSERT INTO notifications (user_id, task_id, message) VALUES (1, ?, 'due')`, id); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := db.Exec(`INSERT INTO task_undo (user_id, task_id, action, version, created_at) VALUES (1, ?, 'update', ?, ?)`, id, now, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := repo.SetNextOccurrenceID(kept, &root); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec(`UPDATE tasks SET deleted_at = ? WHERE id IN (?, ?)`, now, root, child); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.Purge(root, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, query := range []string{
+		`SELECT COUNT(*) FROM tasks WHERE id IN (?, ?)`,
+		`SELECT COUNT(*) FROM notifications WHERE task_id IN (?, ?)`,
+		`SELECT COUNT(*) FROM task_undo WHERE task_id IN (?, ?)`,
+		`SELECT COUNT(*) FROM tasks WHERE next_occurrence_id IN (?, ?)`,
+	} {
+		var count int
+		if err := db.QueryRow(query, root, child).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 0 {
+			t.Errorf("%s: %d rows left", query, count)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+)
+
+// GetTree returns the task with its subtasks down to depth levels; a negative depth loads
+// the whole tree, down to entity.MaxTreeDepth. The tasks on the last loaded level get
+// SubTaskCount, and their TotalTimeSpent still covers their whole subtree. A whole-tree
+// read that leaves subtasks out below MaxTreeDepth sets Truncated on the task.
+func (r *TaskRepository) GetTree(id, userID int64, depth int) (*entity.Task, error) {
+	all := depth < 0
+	if all {
+		depth = entity.MaxTreeDepth
+	}
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+	`
+	task, err := scanTask(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("task not found")
+		}
+
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	tasks := []entity.Task{task}
+	if err := attachSubTasksToDepth(r.db, tasks, defaultTaskOrder, true, depth); err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	if err := attachTaskData(r.db, tasks); err != nil {
+		return nil, err
+	}
+
+	var cut []*entity.Task
+	var collect func(tasks []entity.Task, level int)
+	collect = func(tasks []entity.Task, level int) {
+		for i := range tasks {
+			if level == depth {
+				cut = append(cut, &tasks[i])
+			} else {
+				collect(tasks[i].SubTasks, level+1)
+			}
+		}
+	}
+	collect(tasks, 0)
+
+	if err := attachCutOffCounts(r.db, cut); err != nil {
+		return nil, err
+	}
+
+	for _, task := range cut {
+		if all && *task.SubTaskCount > 0 {
+			tasks[0].Truncated = true
+		}
+	}
+
+	rollUpTimeSpent(tasks)
+	return &tasks[0], nil
+}
+
+// attachCutOffCounts sets SubTaskCount on tasks whose subtasks were not loaded and adds the
+// time logged on those subtasks to their TotalTimeSpent.
+func attachCutOffCounts(db *sql.DB, tasks []*entity.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]*entity.Task, len(tasks))
+	ids := make([]any, len(tasks))
+	for i, task := range tasks {
+		index[task.ID] = task
+		ids[i] = task.ID
+		task.SubTaskCount = new(int)
+	}
+
+	list := idList(ids)
+	query := `SELECT parent_id, COUNT(*) FROM tasks WHERE parent_id ` + inIDList + ` AND deleted_at IS NULL GROUP BY parent_id`
+	rows, err := db.Query(query, list)
+	if err != nil {
+		return fmt.Errorf("failed to count subtasks: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var parentID int64
+		var count int
+		if err := rows.Scan(&parentID, &count); err != nil {
+			return fmt.Errorf("failed to scan subtask count: %w", err)
+		}
+
+		*index[parentID].SubTaskCount = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read subtask counts: %w", err)
+	}
+
+	query = `
+		WITH RECURSIVE tree(root, id, level) AS (
+			SELECT parent_id, id, 1 FROM tasks WHERE parent_id ` + inIDList + ` AND deleted_at IS NULL
+			UNION ALL
+			SELECT tree.root, t.id, tree.level + 1 FROM tasks t JOIN tree ON t.parent_id = tree.id
+			WHERE t.deleted_at IS NULL AND tree.level < ?
+		)
+		SELECT tree.root, SUM(w.duration_seconds)
+		FROM tree JOIN work_logs w ON w.task_id = tree.id
+		WHERE w.ended_at IS NOT NULL
+		GROUP BY tree.root
+	`
+	timeRows, err := db.Query(query, list, maxPathDepth)
+	if err != nil {
+		return fmt.Errorf("failed to query subtask time spent: %w", err)
+	}
+
+	defer timeRows.Close()
+
+	for timeRows.Next() {
+		var taskID, seconds int64
+		if err := timeRows.Scan(&taskID, &seconds); err != nil {
+			return fmt.Errorf("failed to scan subtask time spent: %w", err)
+		}
+
+		index[taskID].TotalTimeSpent += seconds
+	}
+
+	return timeRows.Err()
+}
+
+// rollUpTimeSpent recomputes TotalTimeSpent of the loaded tree from the bottom up. Tasks
+// whose subtasks were cut off keep their total.
+func rollUpTimeSpent(tasks []entity.Task) int64 {
+	var total int64
+	for i := range tasks {
+		if tasks[i].SubTaskCount == nil {
+			tasks[i].TotalTimeSpent = tasks[i].TimeSpent + rollUpTimeSpent(tasks[i].SubTasks)
+		}
+
+		total += tasks[i].TotalTimeSpent
+	}
+
+	return total
+}
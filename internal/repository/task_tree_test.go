@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"testing"
+	"time"
+)
+
+// TestGetTreeTruncated checks that a whole-tree read deeper than MaxTreeDepth is flagged
+// instead of silently cut.
+func TestGetTreeTruncated(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewTaskRepository(db)
+
+	// a chain of MaxTreeDepth+1 subtasks below the root
+	ids := make([]int64, entity.MaxTreeDepth+2)
+	var parentID *int64
+	for i := range ids {
+		task := &entity.Task{UserID: 1, ParentID: parentID, Title: "level", Status: constant.TaskStatusTodo, Priority: constant.TaskPriorityNone}
+		if err := repo.Create(task); err != nil {
+			t.Fatal(err)
+		}
+
+		ids[i] = task.ID
+		parentID = &ids[i]
+	}
+
+	deepest := func(task *entity.Task) (*entity.Task, int) {
+		level := 0
+		for len(task.SubTasks) > 0 {
+			task = &task.SubTasks[0]
+			level++
+		}
+
+		return task, level
+	}
+
+	tree, err := repo.GetTree(ids[0], 1, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last, level := deepest(tree)
+	if !tree.Truncated || level != entity.MaxTreeDepth || last.SubTaskCount == nil || *last.SubTaskCount != 1 {
+		t.Errorf("truncated = %v, levels = %d, last sub_task_count = %v, want true, %d, 1", tree.Truncated, level, last.SubTaskCount, entity.MaxTreeDepth)
+	}
+
+	if _, err := db.Exec(`UPDATE tasks SET deleted_at = ? WHERE id = ?`, time.Now().UTC(), ids[len(ids)-1]); err != nil {
+		t.Fatal(err)
+	}
+
+	if tree, err = repo.GetTree(ids[0], 1, -1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, level := deepest(tree); tree.Truncated || level != entity.MaxTreeDepth {
+		t.Errorf("truncated = %v, levels = %d, want false, %d", tree.Truncated, level, entity.MaxTreeDepth)
+	}
+
+	// an explicit depth leaves subtasks out without flagging the tree
+	if tree, err = repo.GetTree(ids[0], 1, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, level := deepest(tree); tree.Truncated || level != 2 {
+		t.Errorf("depth 2: truncated = %v, levels = %d, want false, 2", tree.Truncated, level)
+	}
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"time"
+)
+
+const taskVersionColumns = `id, task_id, user_id, version, snapshot, created_at`
+
+type TaskVersionRepository struct {
+	db *sql.DB
+}
+
+func NewTaskVersionRepository(db *sql.DB) *TaskVersionRepository {
+	return &TaskVersionRepository{db: db}
+}
+
+func scanTaskVersion(s rowScanner) (entity.TaskVersion, error) {
+	var version entity.TaskVersion
+	var snapshot string
+	if err := s.Scan(&version.ID, &version.TaskID, &version.UserID, &version.Version, &snapshot, &version.CreatedAt); err != nil {
+		return version, err
+	}
+
+	if err := json.Unmarshal([]byte(snapshot), &version.Snapshot); err != nil {
+		return version, fmt.Errorf("failed to decode task snapshot: %w", err)
+	}
+
+	return version, nil
+}
+
+// Create stores the snapshot as the task's next version number.
+func (r *TaskVersionRepository) Create(version *entity.TaskVersion) error {
+	data, err := json.Marshal(version.Snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode task snapshot: %w", err)
+	}
+
+	version.CreatedAt = time.Now()
+	query := `
+		INSERT INTO task_versions (task_id, user_id, version, snapshot, created_at)
+		SELECT ?, ?, COALESCE(MAX(version), 0) + 1, ?, ? FROM task_versions WHERE task_id = ?
+		RETURNING id, version
+	`
+	err = r.db.QueryRow(query, version.TaskID, version.UserID, string(data), version.CreatedAt, version.TaskID).Scan(&version.ID, &version.Version)
+	if err != nil {
+		return fmt.Errorf("failed to create task version: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTaskID returns the task's versions, newest first.
+func (r *TaskVersionRepository) GetByTaskID(taskID int64) ([]entity.TaskVersion, error) {
+	query := `SELECT ` + taskVersionColumns + ` FROM task_versions WHERE task_id = ? ORDER BY version DESC`
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task versions: %w", err)
+	}
+
+	defer rows.Close()
+
+	versions := []entity.TaskVersion{}
+	for rows.Next() {
+		version, err := scanTaskVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task version: %w", err)
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, rows.Err()
+}
+
+func (r *TaskVersionRepository) GetVersion(taskID int64, number int) (*entity.TaskVersion, error) {
+	query := `SELECT ` + taskVersionColumns + ` FROM task_versions WHERE task_id = ? AND version = ?`
+	version, err := scanTaskVersion(r.db.QueryRow(query, taskID, number))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("version %d not found", number)
+		}
+
+		return nil, fmt.Errorf("failed to get task version: %w", err)
+	}
+
+	return &version, nil
+}
+
+// GetLatest returns the task's newest version, or nil if none was recorded.
+func (r *TaskVersionRepository) GetLatest(taskID int64) (*entity.TaskVersion, error) {
+	query := `SELECT ` + taskVersionColumns + ` FROM task_versions WHERE task_id = ? ORDER BY version DESC LIMIT 1`
+	version, err := scanTaskVersion(r.db.QueryRow(query, taskID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get task version: %w", err)
+	}
+
+	return &version, nil
+}
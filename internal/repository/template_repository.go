@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"time"
+)
+
+const templateColumns = `id, owner_id, project_id, name, description, tree, created_at, updated_at`
+
+type TemplateRepository struct {
+	db *sql.DB
+}
+
+func NewTemplateRepository(db *sql.DB) *TemplateRepository {
+	return &TemplateRepository{db: db}
+}
+
+func scanTemplate(s rowScanner) (entity.TaskTemplate, error) {
+	var template entity.TaskTemplate
+	var tree string
+	if err := s.Scan(&template.ID, &template.OwnerID, &template.ProjectID, &template.Name, &template.Description, &tree, &template.CreatedAt, &template.UpdatedAt); err != nil {
+		return template, err
+	}
+
+	if err := json.Unmarshal([]byte(tree), &template.Task); err != nil {
+		return template, fmt.Errorf("failed to decode template tree: %w", err)
+	}
+
+	return template, nil
+}
+
+func (r *TemplateRepository) Create(template *entity.TaskTemplate) error {
+	tree, err := json.Marshal(template.Task)
+	if err != nil {
+		return fmt.Errorf("failed to encode template tree: %w", err)
+	}
+
+	now := time.Now()
+	template.CreatedAt = now
+	template.UpdatedAt = now
+	result, err := r.db.Exec(`INSERT INTO task_templates (owner_id, project_id, name, description, tree, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		template.OwnerID, template.ProjectID, template.Name, template.Description, string(tree), template.CreatedAt, template.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	template.ID = id
+	return nil
+}
+
+// GetVisible returns the user's own templates and the templates shared with projects the
+// user owns.
+func (r *TemplateRepository) GetVisible(userID int64, projectID *int64) ([]entity.TaskTemplate, error) {
+	query := `
+		SELECT ` + templateColumns + `
+		FROM task_templates
+		WHERE (owner_id = ? OR project_id IN (SELECT id FROM projects WHERE owner_id = ?))
+	`
+	args := []any{userID, userID}
+	if projectID != nil {
+		query += ` AND project_id = ?`
+		args = append(args, *projectID)
+	}
+
+	rows, err := r.db.Query(query+` ORDER BY name COLLATE NOCASE, id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query templates: %w", err)
+	}
+
+	defer rows.Close()
+
+	templates := []entity.TaskTemplate{}
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+// GetByID returns the template if the user owns it or owns the project it is shared with.
+func (r *TemplateRepository) GetByID(id, userID int64) (*entity.TaskTemplate, error) {
+	query := `
+		SELECT ` + templateColumns + `
+		FROM task_templates
+		WHERE id = ? AND (owner_id = ? OR project_id IN (SELECT id FROM projects WHERE owner_id = ?))
+	`
+	template, err := scanTemplate(r.db.QueryRow(query, id, userID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("template not found")
+		}
+
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	return &template, nil
+}
+
+func (r *TemplateRepository) Update(template *entity.TaskTemplate) error {
+	tree, err := json.Marshal(template.Task)
+	if err != nil {
+		return fmt.Errorf("failed to encode template tree: %w", err)
+	}
+
+	template.UpdatedAt = time.Now()
+	result, err := r.db.Exec(`UPDATE task_templates SET project_id = ?, name = ?, description = ?, tree = ?, updated_at = ? WHERE id = ?`,
+		template.ProjectID, template.Name, template.Description, string(tree), template.UpdatedAt, template.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+
+	return expectOneRow(result, "template not found")
+}
+
+func (r *TemplateRepository) Delete(id int64) error {
+	result, err := r.db.Exec(`DELETE FROM task_templates WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	return expectOneRow(result, "template not found")
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"time"
+)
+
+const undoColumns = `id, user_id, task_id, action, before, version, previous_version, created_at, undone_at`
+
+type UndoRepository struct {
+	db *sql.DB
+}
+
+func NewUndoRepository(db *sql.DB) *UndoRepository {
+	return &UndoRepository{db: db}
+}
+
+func scanUndoEntry(s rowScanner) (entity.UndoEntry, error) {
+	var entry entity.UndoEntry
+	var before sql.NullString
+	if err := s.Scan(&entry.ID, &entry.UserID, &entry.TaskID, &entry.Action, &before, &entry.Version, &entry.Previous, &entry.CreatedAt, &entry.UndoneAt); err != nil {
+		return entry, err
+	}
+
+	if before.Valid {
+		if err := json.Unmarshal([]byte(before.String), &entry.Before); err != nil {
+			return entry, fmt.Errorf("failed to decode task snapshot: %w", err)
+		}
+	}
+
+	return entry, nil
+}
+
+// Create stores the entry and drops the user's entries from before expiredBefore.
+func (r *UndoRepository) Create(entry *entity.UndoEntry, expiredBefore time.Time) error {
+	var before any
+	if entry.Before != nil {
+		data, err := json.Marshal(entry.Before)
+		if err != nil {
+			return fmt.Errorf("failed to encode task snapshot: %w", err)
+		}
+
+		before = string(data)
+	}
+
+	entry.CreatedAt = time.Now()
+	result, err := r.db.Exec(`INSERT INTO task_undo (user_id, task_id, action, before, version, previous_version, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		entry.UserID, entry.TaskID, entry.Action, before, entry.Version.UTC(), entry.Previous.UTC(), entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create undo entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	entry.ID = id
+	if _, err := r.db.Exec(`DELETE FROM task_undo WHERE user_id = ? AND created_at < ?`, entry.UserID, expiredBefore); err != nil {
+		return fmt.Errorf("failed to delete expired undo entries: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UndoRepository) GetByID(id, userID int64) (*entity.UndoEntry, error) {
+	query := `SELECT ` + undoColumns + ` FROM task_undo WHERE id = ? AND user_id = ?`
+	entry, err := scanUndoEntry(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("undo token not found")
+		}
+
+		return nil, fmt.Errorf("failed to get undo entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// GetRecent returns the user's entries created since the time that were not undone yet,
+// newest first.
+func (r *UndoRepository) GetRecent(userID int64, since time.Time, limit int) ([]entity.UndoEntry, error) {
+	query := `
+		SELECT ` + undoColumns + `
+		FROM task_undo
+		WHERE user_id = ? AND created_at >= ? AND undone_at IS NULL
+		ORDER BY id DESC
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, userID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query undo entries: %w", err)
+	}
+
+	defer rows.Close()
+
+	var entries []entity.UndoEntry
+	for rows.Next() {
+		entry, err := scanUndoEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan undo entry: %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *UndoRepository) MarkUndone(id int64) error {
+	result, err := r.db.Exec(`UPDATE task_undo SET undone_at = ? WHERE id = ? AND undone_at IS NULL`, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark undo entry: %w", err)
+	}
+
+	return expectOneRow(result, "operation was already undone")
+}
+
+// Rebase moves the task's pending entries recorded at version from to version to, after an
+// undo brought the task back to that state with a new updated_at.
+func (r *UndoRepository) Rebase(taskID int64, from, to time.Time) error {
+	_, err := r.db.Exec(`UPDATE task_undo SET version = ? WHERE task_id = ? AND version = ? AND undone_at IS NULL`, to.UTC(), taskID, from.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to rebase undo entries: %w", err)
+	}
+
+	return nil
+}
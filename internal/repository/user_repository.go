@@ -17,14 +17,14 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 
 func (r *UserRepository) GetByUsername(username string) (*entity.User, error) {
 	query := `
-		SELECT id, username, password, created_at
+		SELECT id, username, password, timezone, created_at
 		FROM users
 		WHERE username = ?
 	`
 
 	var user entity.User
 	err := r.db.QueryRow(query, username).Scan(
-		&user.ID, &user.Username, &user.Password, &user.CreatedAt,
+		&user.ID, &user.Username, &user.Password, &user.Timezone, &user.CreatedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -36,16 +36,40 @@ func (r *UserRepository) GetByUsername(username string) (*entity.User, error) {
 	return &user, nil
 }
 
+func (r *UserRepository) GetByID(id int64) (*entity.User, error) {
+	query := `
+		SELECT id, username, password, timezone, created_at
+		FROM users
+		WHERE id = ?
+	`
+
+	var user entity.User
+	err := r.db.QueryRow(query, id).Scan(
+		&user.ID, &user.Username, &user.Password, &user.Timezone, &user.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &user, nil
+}
+
 func (r *UserRepository) Create(user *entity.User) error {
 	query := `
-		INSERT INTO users (username, password, created_at)
-		VALUES (?, ?, ?)
+		INSERT INTO users (username, password, timezone, created_at)
+		VALUES (?, ?, ?, ?)
 	`
 
 	now := time.Now()
 	user.CreatedAt = now
+	if user.Timezone == "" {
+		user.Timezone = "UTC"
+	}
 
-	result, err := r.db.Exec(query, user.Username, user.Password, user.CreatedAt)
+	result, err := r.db.Exec(query, user.Username, user.Password, user.Timezone, user.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
@@ -75,3 +99,12 @@ func (r *UserRepository) Upsert(user *entity.User) (*entity.User, error) {
 
 	return user, nil
 }
+
+func (r *UserRepository) UpdateTimezone(id int64, timezone string) error {
+	query := `UPDATE users SET timezone = ? WHERE id = ?`
+	if _, err := r.db.Exec(query, timezone, id); err != nil {
+		return fmt.Errorf("failed to update timezone: %w", err)
+	}
+
+	return nil
+}
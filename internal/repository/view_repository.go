@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"time"
+)
+
+const viewColumns = `id, owner_id, project_id, name, shared, filter, sort, group_by, fields, created_at, updated_at`
+
+// viewVisible matches the views a user owns and the views shared with projects the user
+// owns; it takes the user ID twice.
+const viewVisible = `(owner_id = ? OR (shared AND project_id IN (SELECT id FROM projects WHERE owner_id = ?)))`
+
+type ViewRepository struct {
+	db *sql.DB
+}
+
+func NewViewRepository(db *sql.DB) *ViewRepository {
+	return &ViewRepository{db: db}
+}
+
+func scanView(s rowScanner) (entity.SavedView, error) {
+	var view entity.SavedView
+	var filter, fields string
+	if err := s.Scan(&view.ID, &view.OwnerID, &view.ProjectID, &view.Name, &view.Shared, &filter, &view.Sort, &view.GroupBy, &fields, &view.CreatedAt, &view.UpdatedAt); err != nil {
+		return view, err
+	}
+
+	if err := json.Unmarshal([]byte(filter), &view.Filter); err != nil {
+		return view, fmt.Errorf("failed to decode view filter: %w", err)
+	}
+
+	if fields != "" {
+		view.Fields = strings.Split(fields, ",")
+	}
+
+	return view, nil
+}
+
+func (r *ViewRepository) Create(view *entity.SavedView) error {
+	filter, err := json.Marshal(view.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to encode view filter: %w", err)
+	}
+
+	now := time.Now()
+	view.CreatedAt = now
+	view.UpdatedAt = now
+	result, err := r.db.Exec(`INSERT INTO saved_views (owner_id, project_id, name, shared, filter, sort, group_by, fields, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		view.OwnerID, view.ProjectID, view.Name, view.Shared, string(filter), view.Sort, view.GroupBy, strings.Join(view.Fields, ","), view.CreatedAt, view.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create view: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	view.ID = id
+	return nil
+}
+
+// GetVisible returns the views the user can run, optionally only those of a project.
+func (r *ViewRepository) GetVisible(userID int64, projectID *int64) ([]entity.SavedView, error) {
+	query := `SELECT ` + viewColumns + ` FROM saved_views WHERE ` + viewVisible
+	args := []any{userID, userID}
+	if projectID != nil {
+		query += ` AND project_id = ?`
+		args = append(args, *projectID)
+	}
+
+	rows, err := r.db.Query(query+` ORDER BY name COLLATE NOCASE, id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query views: %w", err)
+	}
+
+	defer rows.Close()
+
+	views := []entity.SavedView{}
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan view: %w", err)
+		}
+
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
+// GetByID returns the view if the user owns it or it is shared with a project the user owns.
+func (r *ViewRepository) GetByID(id, userID int64) (*entity.SavedView, error) {
+	query := `SELECT ` + viewColumns + ` FROM saved_views WHERE id = ? AND ` + viewVisible
+	view, err := scanView(r.db.QueryRow(query, id, userID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("view not found")
+		}
+
+		return nil, fmt.Errorf("failed to get view: %w", err)
+	}
+
+	return &view, nil
+}
+
+// GetOwned returns the view only if the user owns it; views shared with the user cannot be
+// changed through it.
+func (r *ViewRepository) GetOwned(id, ownerID int64) (*entity.SavedView, error) {
+	query := `SELECT ` + viewColumns + ` FROM saved_views WHERE id = ? AND owner_id = ?`
+	view, err := scanView(r.db.QueryRow(query, id, ownerID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("view not found")
+		}
+
+		return nil, fmt.Errorf("failed to get view: %w", err)
+	}
+
+	return &view, nil
+}
+
+func (r *ViewRepository) Update(view *entity.SavedView) error {
+	filter, err := json.Marshal(view.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to encode view filter: %w", err)
+	}
+
+	view.UpdatedAt = time.Now()
+	result, err := r.db.Exec(`UPDATE saved_views SET project_id = ?, name = ?, shared = ?, filter = ?, sort = ?, group_by = ?, fields = ?, updated_at = ? WHERE id = ? AND owner_id = ?`,
+		view.ProjectID, view.Name, view.Shared, string(filter), view.Sort, view.GroupBy, strings.Join(view.Fields, ","), view.UpdatedAt, view.ID, view.OwnerID)
+	if err != nil {
+		return fmt.Errorf("failed to update view: %w", err)
+	}
+
+	return expectOneRow(result, "view not found")
+}
+
+func (r *ViewRepository) Delete(id, ownerID int64) error {
+	result, err := r.db.Exec(`DELETE FROM saved_views WHERE id = ? AND owner_id = ?`, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete view: %w", err)
+	}
+
+	return expectOneRow(result, "view not found")
+}
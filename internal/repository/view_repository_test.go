@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"task-management-backend/internal/domain/entity"
+	"testing"
+)
+
+// TestSharedViewIsReadOnly checks that a view shared into another user's project can be
+// read by that user but not changed or deleted.
+func TestSharedViewIsReadOnly(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewViewRepository(db)
+
+	const owner, projectOwner = 1, 2
+	result, err := db.Exec(`INSERT INTO projects (owner_id, name) VALUES (?, 'shared')`, projectOwner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectID, _ := result.LastInsertId()
+	view := &entity.SavedView{OwnerID: owner, ProjectID: &projectID, Name: "mine", Shared: true}
+	if err := repo.Create(view); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.GetByID(view.ID, projectOwner); err != nil {
+		t.Fatalf("shared view is not visible: %v", err)
+	}
+
+	if _, err := repo.GetOwned(view.ID, projectOwner); err == nil {
+		t.Error("GetOwned returned a view the user does not own")
+	}
+
+	foreign := *view
+	foreign.OwnerID = projectOwner
+	foreign.Name = "taken"
+	if err := repo.Update(&foreign); err == nil {
+		t.Error("Update changed a view the user does not own")
+	}
+
+	if err := repo.Delete(view.ID, projectOwner); err == nil {
+		t.Error("Delete removed a view the user does not own")
+	}
+
+	got, err := repo.GetOwned(view.ID, owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Name != "mine" {
+		t.Errorf("name = %q, want %q", got.Name, "mine")
+	}
+
+	if err := repo.Delete(view.ID, owner); err != nil {
+		t.Errorf("owner cannot delete the view: %v", err)
+	}
+}
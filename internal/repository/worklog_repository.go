@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+const workLogColumns = `id, task_id, user_id, started_at, ended_at, duration_seconds, note, source, created_at`
+
+type WorkLogRepository struct {
+	db *sql.DB
+}
+
+func NewWorkLogRepository(db *sql.DB) *WorkLogRepository {
+	return &WorkLogRepository{db: db}
+}
+
+func scanWorkLog(s rowScanner) (entity.WorkLog, error) {
+	var log entity.WorkLog
+	err := s.Scan(&log.ID, &log.TaskID, &log.UserID, &log.StartedAt, &log.EndedAt, &log.DurationSeconds, &log.Note, &log.Source, &log.CreatedAt)
+	return log, err
+}
+
+// Create inserts a work log. Inserting a second running timer for a user fails on the
+// partial unique index.
+func (r *WorkLogRepository) Create(log *entity.WorkLog) error {
+	query := `
+		INSERT INTO work_logs (task_id, user_id, started_at, ended_at, duration_seconds, note, source, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	log.CreatedAt = time.Now()
+	result, err := r.db.Exec(query, log.TaskID, log.UserID, log.StartedAt.UTC(), utcTime(log.EndedAt), log.DurationSeconds, log.Note, log.Source, log.CreatedAt)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return fmt.Errorf("a timer is already running")
+		}
+
+		return fmt.Errorf("failed to create work log: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	log.ID = id
+	return nil
+}
+
+func (r *WorkLogRepository) GetByID(id, userID int64) (*entity.WorkLog, error) {
+	query := `SELECT ` + workLogColumns + ` FROM work_logs WHERE id = ? AND user_id = ?`
+	log, err := scanWorkLog(r.db.QueryRow(query, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("work log not found")
+		}
+
+		return nil, fmt.Errorf("failed to get work log: %w", err)
+	}
+
+	return &log, nil
+}
+
+// GetRunning returns the user's running timer, or nil when none is running.
+func (r *WorkLogRepository) GetRunning(userID int64) (*entity.WorkLog, error) {
+	query := `SELECT ` + workLogColumns + ` FROM work_logs WHERE user_id = ? AND ended_at IS NULL`
+	log, err := scanWorkLog(r.db.QueryRow(query, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get running timer: %w", err)
+	}
+
+	return &log, nil
+}
+
+// Stop ends a running timer. It fails if the timer was already stopped.
+func (r *WorkLogRepository) Stop(log *entity.WorkLog) error {
+	query := `UPDATE work_logs SET ended_at = ?, duration_seconds = ? WHERE id = ? AND ended_at IS NULL`
+	result, err := r.db.Exec(query, utcTime(log.EndedAt), log.DurationSeconds, log.ID)
+	if err != nil {
+		return fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	return expectOneRow(result, "timer is not running")
+}
+
+func (r *WorkLogRepository) GetByTaskID(taskID int64) ([]entity.WorkLog, error) {
+	query := `SELECT ` + workLogColumns + ` FROM work_logs WHERE task_id = ? ORDER BY started_at, id`
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query work logs: %w", err)
+	}
+
+	defer rows.Close()
+
+	logs := []entity.WorkLog{}
+	for rows.Next() {
+		log, err := scanWorkLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan work log: %w", err)
+		}
+
+		logs = append(logs, log)
+	}
+
+	return logs, nil
+}
+
+func (r *WorkLogRepository) Delete(id, userID int64) error {
+	result, err := r.db.Exec(`DELETE FROM work_logs WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete work log: %w", err)
+	}
+
+	return expectOneRow(result, "work log not found")
+}
+
+// GetTimesheet returns the user's finished work logs that started in [from, to).
+func (r *WorkLogRepository) GetTimesheet(userID int64, from, to time.Time) ([]entity.TimesheetEntry, error) {
+	query := `
+		SELECT w.id, w.task_id, w.user_id, w.started_at, w.ended_at, w.duration_seconds, w.note, w.source, w.created_at, t.title, t.project_id
+		FROM work_logs w
+		JOIN tasks t ON t.id = w.task_id
+		WHERE w.user_id = ? AND w.ended_at IS NOT NULL AND w.started_at >= ? AND w.started_at < ? AND t.deleted_at IS NULL
+		ORDER BY w.started_at, w.id
+	`
+	rows, err := r.db.Query(query, userID, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query timesheet: %w", err)
+	}
+
+	defer rows.Close()
+
+	entries := []entity.TimesheetEntry{}
+	for rows.Next() {
+		var e entity.TimesheetEntry
+		if err := rows.Scan(&e.ID, &e.TaskID, &e.UserID, &e.StartedAt, &e.EndedAt, &e.DurationSeconds, &e.Note, &e.Source, &e.CreatedAt, &e.TaskTitle, &e.ProjectID); err != nil {
+			return nil, fmt.Errorf("failed to scan timesheet entry: %w", err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// attachTimeSpent loads the logged time of a task tree in a single query and rolls it up
+// from subtasks to their parents.
+func attachTimeSpent(db *sql.DB, tasks []entity.Task) error {
+	index, ids := indexTaskTree(tasks)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `
+		SELECT task_id, SUM(duration_seconds)
+		FROM work_logs
+		WHERE task_id ` + inIDList + ` AND ended_at IS NOT NULL
+		GROUP BY task_id
+	`
+	rows, err := db.Query(query, idList(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query time spent: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID, seconds int64
+		if err := rows.Scan(&taskID, &seconds); err != nil {
+			return fmt.Errorf("failed to scan time spent: %w", err)
+		}
+
+		index[taskID].TimeSpent = seconds
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var rollUp func(tasks []entity.Task) int64
+	rollUp = func(tasks []entity.Task) int64 {
+		var total int64
+		for i := range tasks {
+			tasks[i].TotalTimeSpent = tasks[i].TimeSpent + rollUp(tasks[i].SubTasks)
+			total += tasks[i].TotalTimeSpent
+		}
+
+		return total
+	}
+	rollUp(tasks)
+
+	return nil
+}
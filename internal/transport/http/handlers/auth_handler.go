@@ -25,7 +25,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authUC.Login(req.Username, req.Password)
+	response, err := h.authUC.Login(req.Username, req.Password, req.Timezone)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
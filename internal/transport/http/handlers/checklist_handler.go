@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/usecase/checklist"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChecklistHandler struct {
+	checklistUC *checklist.ChecklistUseCase
+}
+
+func NewChecklistHandler(checklistUC *checklist.ChecklistUseCase) *ChecklistHandler {
+	return &ChecklistHandler{
+		checklistUC: checklistUC,
+	}
+}
+
+// checklistParams reads the task ID and, when present, the checklist item ID from the path.
+func checklistParams(c *gin.Context) (taskID, itemID int64, ok bool) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return 0, 0, false
+	}
+
+	if c.Param("itemId") == "" {
+		return taskID, 0, true
+	}
+
+	itemID, err = strconv.ParseInt(c.Param("itemId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid checklist item ID"})
+		return 0, 0, false
+	}
+
+	return taskID, itemID, true
+}
+
+func (h *ChecklistHandler) GetItems(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, _, ok := checklistParams(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.checklistUC.GetItems(userID.(int64), taskID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+func (h *ChecklistHandler) CreateItem(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, _, ok := checklistParams(c)
+	if !ok {
+		return
+	}
+
+	var req entity.CreateChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.checklistUC.CreateItem(userID.(int64), taskID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"item": item})
+}
+
+func (h *ChecklistHandler) UpdateItem(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, itemID, ok := checklistParams(c)
+	if !ok {
+		return
+	}
+
+	var req entity.UpdateChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.checklistUC.UpdateItem(userID.(int64), taskID, itemID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"item": item})
+}
+
+func (h *ChecklistHandler) DeleteItem(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, itemID, ok := checklistParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.checklistUC.DeleteItem(userID.(int64), taskID, itemID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Checklist item deleted successfully"})
+}
+
+func (h *ChecklistHandler) PromoteItem(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, itemID, ok := checklistParams(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.checklistUC.PromoteItem(userID.(int64), taskID, itemID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"task": task})
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/usecase/search"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchUC *search.SearchUseCase
+}
+
+func NewSearchHandler(searchUC *search.SearchUseCase) *SearchHandler {
+	return &SearchHandler{
+		searchUC: searchUC,
+	}
+}
+
+func (h *SearchHandler) Search(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	query := entity.SearchQuery{
+		Q:               c.Query("q"),
+		IncludeArchived: c.Query("include_archived") == "true",
+	}
+
+	if value := c.Query("project_id"); value != "" {
+		projectID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+			return
+		}
+
+		query.ProjectID = &projectID
+	}
+
+	for param, target := range map[string]*int{"limit": &query.Limit, "offset": &query.Offset} {
+		if value := c.Query(param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+				return
+			}
+
+			*target = n
+		}
+	}
+
+	result, err := h.searchUC.Search(userID.(int64), query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
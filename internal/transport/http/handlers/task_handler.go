@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"task-management-backend/internal/domain/entity"
 	"task-management-backend/internal/usecase/task"
 	"task-management-backend/pkg/constant"
+	"task-management-backend/pkg/query"
 
 	"github.com/gin-gonic/gin"
 )
@@ -28,17 +32,196 @@ func (h *TaskHandler) GetTasks(c *gin.Context) {
 	}
 
 	uid := userID.(int64)
-	statusQuery := c.Query("status")
-	status := constant.TaskStatus(statusQuery)
-	tasks, err := h.taskUC.GetTasks(uid, status)
+	query := entity.TaskQuery{
+		Status:          constant.TaskStatus(c.Query("status")),
+		StatusCategory:  c.Query("status_category"),
+		Overdue:         c.Query("overdue") == "true",
+		DueToday:        c.Query("due_today") == "true",
+		DueBefore:       c.Query("due_before"),
+		DueAfter:        c.Query("due_after"),
+		Sort:            c.Query("sort"),
+		IncludeArchived: c.Query("include_archived") == "true",
+		Filter:          c.Query("filter"),
+	}
+
+	for param, values := range c.Request.URL.Query() {
+		if key, ok := strings.CutPrefix(param, "cf."); ok && len(values) > 0 {
+			if query.CustomFields == nil {
+				query.CustomFields = make(map[string]string)
+			}
+
+			query.CustomFields[key] = values[0]
+		}
+	}
+
+	if projectParam := c.Query("project_id"); projectParam != "" {
+		projectID, err := strconv.ParseInt(projectParam, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+			return
+		}
+
+		query.ProjectID = &projectID
+	}
+
+	if sprintParam := c.Query("sprint_id"); sprintParam != "" {
+		sprintID, err := strconv.ParseInt(sprintParam, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sprint ID"})
+			return
+		}
+
+		query.SprintID = &sprintID
+	}
+
+	// limit or cursor switch to paginated responses
+	if c.Query("limit") != "" || c.Query("cursor") != "" {
+		page := entity.PageRequest{
+			Cursor:    c.Query("cursor"),
+			WithTotal: c.Query("include_total") == "true",
+		}
+
+		if limitParam := c.Query("limit"); limitParam != "" {
+			limit, err := strconv.Atoi(limitParam)
+			if err != nil || limit < 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+				return
+			}
+
+			page.Limit = limit
+		}
+
+		result, err := h.taskUC.GetTaskPage(uid, query, page)
+		if err != nil {
+			listError(c, err)
+			return
+		}
+
+		if c.Query("include_path") == "true" {
+			if result.Tasks, err = h.taskUC.WithPaths(result.Tasks); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	tasks, err := h.taskUC.GetTasks(uid, query)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		listError(c, err)
 		return
 	}
 
+	if c.Query("include_path") == "true" {
+		if tasks, err = h.taskUC.WithPaths(tasks); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{"tasks": tasks})
 }
 
+// listError replies with a list query error, adding the position of filter errors.
+func listError(c *gin.Context, err error) {
+	var filterErr *query.Error
+	if errors.As(err, &filterErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "position": filterErr.Pos})
+		return
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
+func (h *TaskHandler) GetTask(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	// depth is the number of subtask levels to load; the whole tree is loaded by default
+	depth := -1
+	if value := c.Query("depth"); value != "" && value != "all" {
+		depth, err = strconv.Atoi(value)
+		if err != nil || depth < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid depth, expected a non-negative number or all"})
+			return
+		}
+	}
+
+	var fields []string
+	if value := c.Query("fields"); value != "" {
+		for _, field := range strings.Split(value, ",") {
+			fields = append(fields, strings.TrimSpace(field))
+		}
+
+		if err := entity.ValidateTaskFields(fields); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	task, err := h.taskUC.GetTask(userID.(int64), taskID, depth)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("include_path") == "true" {
+		tasks, err := h.taskUC.WithPaths([]entity.Task{*task})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		task = &tasks[0]
+	}
+
+	if len(fields) == 0 {
+		c.JSON(http.StatusOK, gin.H{"task": task})
+		return
+	}
+
+	selected, err := entity.SelectTaskFields(*task, fields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"task": selected})
+}
+
+func (h *TaskHandler) GetAncestors(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	ancestors, err := h.taskUC.GetAncestors(userID.(int64), taskID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ancestors": ancestors})
+}
+
 func (h *TaskHandler) CreateTask(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
@@ -53,7 +236,7 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		return
 	}
 
-	task, err := h.taskUC.CreateTask(uid, req.Title, req.Description, req.ParentID)
+	task, err := h.taskUC.CreateTask(uid, req)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -84,14 +267,14 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 
-	var status *constant.TaskStatus
-	if req.Status != nil {
-		s := constant.TaskStatus(*req.Status)
-		status = &s
-	}
-
-	task, err := h.taskUC.UpdateTask(uid, taskID, req.Title, req.Description, status, req.ParentID)
+	task, err := h.taskUC.UpdateTask(uid, taskID, req)
 	if err != nil {
+		var transitionErr *entity.TransitionError
+		if errors.As(err, &transitionErr) {
+			c.JSON(transitionErr.StatusCode(), transitionErr)
+			return
+		}
+
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -115,10 +298,480 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 		return
 	}
 
-	if err := h.taskUC.DeleteTask(uid, taskID); err != nil {
+	task, err := h.taskUC.DeleteTask(uid, taskID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := gin.H{"message": "Task deleted successfully"}
+	if task.UndoToken != nil {
+		response["undo_token"] = *task.UndoToken
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *TaskHandler) Undo(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req entity.UndoRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.taskUC.Undo(userID.(int64), req)
+	if err != nil {
+		var conflictErr *entity.UndoConflictError
+		if errors.As(err, &conflictErr) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   conflictErr.Message,
+				"token":   conflictErr.Token,
+				"task_id": conflictErr.TaskID,
+				"undone":  result.Undone,
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *TaskHandler) ArchiveTask(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	task, err := h.taskUC.ArchiveTask(userID.(int64), taskID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"task": task})
+}
+
+func (h *TaskHandler) UnarchiveTask(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	task, err := h.taskUC.UnarchiveTask(userID.(int64), taskID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"task": task})
+}
+
+func (h *TaskHandler) GetTrash(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	tasks, err := h.taskUC.GetTrash(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("include_path") == "true" {
+		if tasks, err = h.taskUC.WithPaths(tasks); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tasks": tasks})
+}
+
+func (h *TaskHandler) RestoreTask(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	task, err := h.taskUC.RestoreTask(userID.(int64), taskID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"task": task})
+}
+
+func (h *TaskHandler) PurgeTask(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	if err := h.taskUC.PurgeTask(userID.(int64), taskID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task permanently deleted"})
+}
+
+func (h *TaskHandler) PreviewRecurrence(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var query entity.RecurrencePreviewQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	occurrences, err := h.taskUC.PreviewRecurrence(userID.(int64), query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"occurrences": occurrences})
+}
+
+func (h *TaskHandler) AddDependency(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	uid := userID.(int64)
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var req entity.AddDependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.taskUC.AddDependency(uid, taskID, req.BlockedBy)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"task": task})
+}
+
+func (h *TaskHandler) RemoveDependency(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	uid := userID.(int64)
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	blockerID, err := strconv.ParseInt(c.Param("blockerId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blocking task ID"})
+		return
+	}
+
+	task, err := h.taskUC.RemoveDependency(uid, taskID, blockerID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"task": task})
+}
+
+func (h *TaskHandler) GetStatusHistory(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	history, err := h.taskUC.GetStatusHistory(userID.(int64), taskID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"history": history})
+}
+
+func (h *TaskHandler) CloneTask(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var req entity.CloneTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.taskUC.CloneTask(userID.(int64), taskID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"task": task})
+}
+
+func (h *TaskHandler) MoveTask(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var req entity.MoveTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.taskUC.MoveTask(userID.(int64), taskID, req)
+	if err != nil {
+		var transitionErr *entity.TransitionError
+		if errors.As(err, &transitionErr) {
+			c.JSON(transitionErr.StatusCode(), transitionErr)
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"task": task})
+}
+
+func (h *TaskHandler) GetBoard(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	query := entity.BoardQuery{
+		Status:   constant.TaskStatus(c.Query("status")),
+		Swimlane: c.Query("swimlane"),
+	}
+
+	if projectParam := c.Query("project_id"); projectParam != "" {
+		projectID, err := strconv.ParseInt(projectParam, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+			return
+		}
+
+		query.ProjectID = &projectID
+	}
+
+	for param, target := range map[string]*int{"limit": &query.Limit, "offset": &query.Offset} {
+		if value := c.Query(param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+				return
+			}
+
+			*target = n
+		}
+	}
+
+	board, err := h.taskUC.GetBoard(userID.(int64), query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"board": board})
+}
+
+func (h *TaskHandler) GetVersions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	versions, err := h.taskUC.GetVersions(userID.(int64), taskID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"versions": versions})
+}
+
+func (h *TaskHandler) GetVersion(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	number, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+
+	version, err := h.taskUC.GetVersion(userID.(int64), taskID, number)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"version": version})
+}
+
+func (h *TaskHandler) DiffVersions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var query entity.VersionDiffQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	diff, err := h.taskUC.DiffVersions(userID.(int64), taskID, query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"diff": diff})
+}
+
+func (h *TaskHandler) RevertToVersion(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	number, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+
+	task, err := h.taskUC.RevertToVersion(userID.(int64), taskID, number)
+	if err != nil {
+		var transitionErr *entity.TransitionError
+		if errors.As(err, &transitionErr) {
+			c.JSON(transitionErr.StatusCode(), transitionErr)
+			return
+		}
+
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Task deleted successfully"})
+	c.JSON(http.StatusOK, gin.H{"task": task})
 }
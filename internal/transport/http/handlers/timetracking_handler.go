@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/usecase/timetracking"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimeTrackingHandler struct {
+	timeTrackingUC *timetracking.TimeTrackingUseCase
+}
+
+func NewTimeTrackingHandler(timeTrackingUC *timetracking.TimeTrackingUseCase) *TimeTrackingHandler {
+	return &TimeTrackingHandler{
+		timeTrackingUC: timeTrackingUC,
+	}
+}
+
+func (h *TimeTrackingHandler) StartTimer(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	// the body is optional
+	var req entity.StartTimerRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	log, err := h.timeTrackingUC.StartTimer(userID.(int64), taskID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"timer": log})
+}
+
+func (h *TimeTrackingHandler) StopTimer(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	log, err := h.timeTrackingUC.StopTimer(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"work_log": log})
+}
+
+func (h *TimeTrackingHandler) GetRunningTimer(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	log, err := h.timeTrackingUC.GetRunningTimer(userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"timer": log})
+}
+
+func (h *TimeTrackingHandler) CreateWorkLog(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var req entity.CreateWorkLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	log, err := h.timeTrackingUC.CreateWorkLog(userID.(int64), taskID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"work_log": log})
+}
+
+func (h *TimeTrackingHandler) GetWorkLogs(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	logs, err := h.timeTrackingUC.GetWorkLogs(userID.(int64), taskID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"work_logs": logs})
+}
+
+func (h *TimeTrackingHandler) DeleteWorkLog(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	logID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work log ID"})
+		return
+	}
+
+	if err := h.timeTrackingUC.DeleteWorkLog(userID.(int64), logID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Work log deleted successfully"})
+}
+
+// GetTimesheet returns JSON, or CSV with one row per work log when format=csv.
+func (h *TimeTrackingHandler) GetTimesheet(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var query entity.TimesheetQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if query.Format != "" && query.Format != "json" && query.Format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or csv"})
+		return
+	}
+
+	sheet, err := h.timeTrackingUC.GetTimesheet(userID.(int64), query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if query.Format != "csv" {
+		c.JSON(http.StatusOK, gin.H{"timesheet": sheet})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="timesheet_%s_%s.csv"`, sheet.From, sheet.To))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{"date", "task_id", "task_title", "project_id", "started_at", "ended_at", "duration_seconds", "hours", "source", "note"})
+	for _, e := range sheet.Entries {
+		projectID := ""
+		if e.ProjectID != nil {
+			projectID = strconv.FormatInt(*e.ProjectID, 10)
+		}
+
+		endedAt := ""
+		if e.EndedAt != nil {
+			endedAt = e.EndedAt.Format(time.RFC3339)
+		}
+
+		w.Write([]string{
+			e.Date,
+			strconv.FormatInt(e.TaskID, 10),
+			e.TaskTitle,
+			projectID,
+			e.StartedAt.Format(time.RFC3339),
+			endedAt,
+			strconv.FormatInt(e.DurationSeconds, 10),
+			strconv.FormatFloat(float64(e.DurationSeconds)/3600, 'f', 2, 64),
+			string(e.Source),
+			e.Note,
+		})
+	}
+
+	w.Flush()
+}
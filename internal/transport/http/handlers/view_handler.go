@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/usecase/view"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ViewHandler struct {
+	viewUC *view.ViewUseCase
+}
+
+func NewViewHandler(viewUC *view.ViewUseCase) *ViewHandler {
+	return &ViewHandler{
+		viewUC: viewUC,
+	}
+}
+
+func (h *ViewHandler) GetViews(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var projectID *int64
+	if value := c.Query("project_id"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+			return
+		}
+
+		projectID = &id
+	}
+
+	views, err := h.viewUC.GetViews(userID.(int64), projectID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"views": views})
+}
+
+func (h *ViewHandler) CreateView(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req entity.CreateViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	view, err := h.viewUC.CreateView(userID.(int64), req)
+	if err != nil {
+		listError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"view": view})
+}
+
+func (h *ViewHandler) GetView(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	viewID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID"})
+		return
+	}
+
+	view, err := h.viewUC.GetView(userID.(int64), viewID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"view": view})
+}
+
+func (h *ViewHandler) UpdateView(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	viewID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID"})
+		return
+	}
+
+	var req entity.UpdateViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	view, err := h.viewUC.UpdateView(userID.(int64), viewID, req)
+	if err != nil {
+		listError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"view": view})
+}
+
+func (h *ViewHandler) DeleteView(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	viewID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID"})
+		return
+	}
+
+	if err := h.viewUC.DeleteView(userID.(int64), viewID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "View deleted successfully"})
+}
+
+// RunView returns the view's tasks, reduced to the view's fields when it has any.
+func (h *ViewHandler) RunView(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	viewID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID"})
+		return
+	}
+
+	result, err := h.viewUC.RunView(userID.(int64), viewID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := gin.H{"view": result.View}
+	if result.Groups == nil {
+		tasks, err := viewTasks(result.Tasks, result.View.Fields)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		response["tasks"] = tasks
+	} else {
+		groups := make([]gin.H, 0, len(result.Groups))
+		for _, group := range result.Groups {
+			tasks, err := viewTasks(group.Tasks, result.View.Fields)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+
+			groups = append(groups, gin.H{"key": group.Key, "tasks": tasks})
+		}
+
+		response["groups"] = groups
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func viewTasks(tasks []entity.Task, fields []string) (any, error) {
+	if len(fields) == 0 {
+		return tasks, nil
+	}
+
+	return entity.SelectTasksFields(tasks, fields)
+}
@@ -11,6 +11,14 @@ import (
 type RouterDeps struct {
 	Auth      *handlers.AuthHandler
 	Task      *handlers.TaskHandler
+	Reminder  *handlers.ReminderHandler
+	Project   *handlers.ProjectHandler
+	Time      *handlers.TimeTrackingHandler
+	Checklist *handlers.ChecklistHandler
+	Template  *handlers.TemplateHandler
+	Sprint    *handlers.SprintHandler
+	View      *handlers.ViewHandler
+	Search    *handlers.SearchHandler
 	JwtSecret string
 }
 
@@ -29,7 +37,147 @@ func RegisterRoutes(g *gin.Engine, deps RouterDeps) {
 	{
 		protected.GET("", deps.Task.GetTasks)
 		protected.POST("", deps.Task.CreateTask)
+		protected.GET("/:id", deps.Task.GetTask)
+		protected.GET("/:id/ancestors", deps.Task.GetAncestors)
 		protected.PUT("/:id", deps.Task.UpdateTask)
 		protected.DELETE("/:id", deps.Task.DeleteTask)
+		protected.GET("/:id/reminders", deps.Reminder.GetReminders)
+		protected.POST("/:id/reminders", deps.Reminder.CreateReminder)
+		protected.POST("/:id/dependencies", deps.Task.AddDependency)
+		protected.DELETE("/:id/dependencies/:blockerId", deps.Task.RemoveDependency)
+		protected.GET("/:id/history", deps.Task.GetStatusHistory)
+		protected.GET("/:id/versions", deps.Task.GetVersions)
+		protected.GET("/:id/versions/diff", deps.Task.DiffVersions)
+		protected.GET("/:id/versions/:version", deps.Task.GetVersion)
+		protected.POST("/:id/versions/:version/revert", deps.Task.RevertToVersion)
+		protected.POST("/:id/clone", deps.Task.CloneTask)
+		protected.POST("/:id/move", deps.Task.MoveTask)
+		protected.POST("/:id/restore", deps.Task.RestoreTask)
+		protected.POST("/:id/archive", deps.Task.ArchiveTask)
+		protected.POST("/:id/unarchive", deps.Task.UnarchiveTask)
+		protected.POST("/:id/timer", deps.Time.StartTimer)
+		protected.GET("/:id/worklogs", deps.Time.GetWorkLogs)
+		protected.POST("/:id/worklogs", deps.Time.CreateWorkLog)
+		protected.GET("/:id/checklist", deps.Checklist.GetItems)
+		protected.POST("/:id/checklist", deps.Checklist.CreateItem)
+		protected.PUT("/:id/checklist/:itemId", deps.Checklist.UpdateItem)
+		protected.DELETE("/:id/checklist/:itemId", deps.Checklist.DeleteItem)
+		protected.POST("/:id/checklist/:itemId/promote", deps.Checklist.PromoteItem)
+	}
+
+	projects := api.Group("/projects")
+	projects.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		projects.GET("", deps.Project.GetProjects)
+		projects.POST("", deps.Project.CreateProject)
+		projects.GET("/:id/workflow", deps.Project.GetWorkflow)
+		projects.PUT("/:id/workflow", deps.Project.UpdateWorkflow)
+		projects.GET("/:id/transitions", deps.Project.GetTransitions)
+		projects.PUT("/:id/transitions", deps.Project.UpdateTransitions)
+		projects.GET("/:id/fields", deps.Project.GetCustomFields)
+		projects.POST("/:id/fields", deps.Project.CreateCustomField)
+		projects.PUT("/:id/fields/:fieldId", deps.Project.UpdateCustomField)
+		projects.DELETE("/:id/fields/:fieldId", deps.Project.DeleteCustomField)
+		projects.GET("/:id/sprints", deps.Sprint.GetSprints)
+		projects.POST("/:id/sprints", deps.Sprint.CreateSprint)
+		projects.GET("/:id/velocity", deps.Sprint.GetVelocity)
+	}
+
+	sprints := api.Group("/sprints")
+	sprints.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		sprints.GET("/:id", deps.Sprint.GetSprint)
+		sprints.PUT("/:id", deps.Sprint.UpdateSprint)
+		sprints.DELETE("/:id", deps.Sprint.DeleteSprint)
+		sprints.POST("/:id/tasks", deps.Sprint.AddTasks)
+		sprints.DELETE("/:id/tasks/:taskId", deps.Sprint.RemoveTask)
+		sprints.POST("/:id/close", deps.Sprint.CloseSprint)
+		sprints.GET("/:id/burndown", deps.Sprint.GetBurndown)
+	}
+
+	board := api.Group("/board")
+	board.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		board.GET("", deps.Task.GetBoard)
+	}
+
+	trash := api.Group("/trash")
+	trash.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		trash.GET("", deps.Task.GetTrash)
+		trash.DELETE("/:id", deps.Task.PurgeTask)
+	}
+
+	views := api.Group("/views")
+	views.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		views.GET("", deps.View.GetViews)
+		views.POST("", deps.View.CreateView)
+		views.GET("/:id", deps.View.GetView)
+		views.PUT("/:id", deps.View.UpdateView)
+		views.DELETE("/:id", deps.View.DeleteView)
+		views.GET("/:id/tasks", deps.View.RunView)
+	}
+
+	search := api.Group("/search")
+	search.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		search.GET("", deps.Search.Search)
+	}
+
+	undo := api.Group("/undo")
+	undo.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		undo.POST("", deps.Task.Undo)
+	}
+
+	templates := api.Group("/templates")
+	templates.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		templates.GET("", deps.Template.GetTemplates)
+		templates.POST("", deps.Template.CreateTemplate)
+		templates.GET("/:id", deps.Template.GetTemplate)
+		templates.PUT("/:id", deps.Template.UpdateTemplate)
+		templates.DELETE("/:id", deps.Template.DeleteTemplate)
+		templates.POST("/:id/instantiate", deps.Template.Instantiate)
+	}
+
+	timer := api.Group("/timer")
+	timer.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		timer.GET("", deps.Time.GetRunningTimer)
+		timer.POST("/stop", deps.Time.StopTimer)
+	}
+
+	worklogs := api.Group("/worklogs")
+	worklogs.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		worklogs.DELETE("/:id", deps.Time.DeleteWorkLog)
+	}
+
+	timesheet := api.Group("/timesheet")
+	timesheet.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		timesheet.GET("", deps.Time.GetTimesheet)
+	}
+
+	recurrence := api.Group("/recurrence")
+	recurrence.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		recurrence.GET("/preview", deps.Task.PreviewRecurrence)
+	}
+
+	reminders := api.Group("/reminders")
+	reminders.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		reminders.DELETE("/:id", deps.Reminder.DeleteReminder)
+		reminders.POST("/:id/snooze", deps.Reminder.SnoozeReminder)
+		reminders.POST("/:id/dismiss", deps.Reminder.DismissReminder)
+	}
+
+	notifications := api.Group("/notifications")
+	notifications.Use(middleware.JWTMiddleware(deps.JwtSecret))
+	{
+		notifications.GET("", deps.Reminder.GetNotifications)
 	}
 }
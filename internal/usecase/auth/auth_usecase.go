@@ -21,12 +21,18 @@ func NewAuthUseCase(jwtSecret string, userRepo ports.UserRepository) *AuthUseCas
 	}
 }
 
-func (uc *AuthUseCase) Login(username, password string) (*entity.LoginResponse, error) {
+func (uc *AuthUseCase) Login(username, password, timezone string) (*entity.LoginResponse, error) {
 	cfg := config.GetConfig()
 	if username == "" {
 		return nil, fmt.Errorf("username is required")
 	}
 
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone: %s", timezone)
+		}
+	}
+
 	bcryptHasher := security.NewBcryptHasher()
 	jwtTokenService := security.NewJWTTokenService()
 
@@ -38,6 +44,7 @@ func (uc *AuthUseCase) Login(username, password string) (*entity.LoginResponse,
 	user := &entity.User{
 		Username: username,
 		Password: string(hashedPassword),
+		Timezone: timezone,
 	}
 
 	existingUser, err := uc.userRepo.Upsert(user)
@@ -45,6 +52,12 @@ func (uc *AuthUseCase) Login(username, password string) (*entity.LoginResponse,
 		return nil, fmt.Errorf("failed to upsert user: %w", err)
 	}
 
+	if timezone != "" && existingUser.Timezone != timezone {
+		if err := uc.userRepo.UpdateTimezone(existingUser.ID, timezone); err != nil {
+			return nil, err
+		}
+	}
+
 	token, err := jwtTokenService.Generate(uint(existingUser.ID), time.Duration(cfg.TokenDuration)*time.Hour)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
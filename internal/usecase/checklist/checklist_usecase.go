@@ -0,0 +1,142 @@
+package checklist
+
+import (
+	"fmt"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+)
+
+// TaskCreator creates tasks with the full validation of the task use case.
+type TaskCreator interface {
+	CreateTask(userID int64, req entity.CreateTaskRequest) (*entity.Task, error)
+}
+
+type ChecklistUseCase struct {
+	repo        ports.ChecklistRepository
+	taskRepo    ports.TaskRepository
+	taskCreator TaskCreator
+	cache       ports.TaskCache
+}
+
+func NewChecklistUseCase(repo ports.ChecklistRepository, taskRepo ports.TaskRepository, taskCreator TaskCreator, cache ports.TaskCache) *ChecklistUseCase {
+	return &ChecklistUseCase{
+		repo:        repo,
+		taskRepo:    taskRepo,
+		taskCreator: taskCreator,
+		cache:       cache,
+	}
+}
+
+func (uc *ChecklistUseCase) GetItems(userID, taskID int64) ([]entity.ChecklistItem, error) {
+	if _, err := uc.taskRepo.GetByID(taskID, userID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	return uc.repo.GetByTaskID(taskID)
+}
+
+func (uc *ChecklistUseCase) CreateItem(userID, taskID int64, req entity.CreateChecklistItemRequest) (*entity.ChecklistItem, error) {
+	if _, err := uc.taskRepo.GetByID(taskID, userID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, fmt.Errorf("checklist item title cannot be empty")
+	}
+
+	item := &entity.ChecklistItem{
+		TaskID: taskID,
+		Title:  title,
+	}
+
+	if err := uc.repo.Create(item, req.Position); err != nil {
+		return nil, err
+	}
+
+	uc.invalidate(userID)
+	return item, nil
+}
+
+func (uc *ChecklistUseCase) UpdateItem(userID, taskID, itemID int64, req entity.UpdateChecklistItemRequest) (*entity.ChecklistItem, error) {
+	if _, err := uc.taskRepo.GetByID(taskID, userID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	item, err := uc.repo.GetByID(itemID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, fmt.Errorf("checklist item title cannot be empty")
+		}
+
+		item.Title = title
+	}
+
+	if req.Checked != nil {
+		item.Checked = *req.Checked
+	}
+
+	if req.Position != nil {
+		item.Position = *req.Position
+	}
+
+	if err := uc.repo.Update(item); err != nil {
+		return nil, err
+	}
+
+	uc.invalidate(userID)
+	return item, nil
+}
+
+func (uc *ChecklistUseCase) DeleteItem(userID, taskID, itemID int64) error {
+	if _, err := uc.taskRepo.GetByID(taskID, userID); err != nil {
+		return fmt.Errorf("task not found: %w", err)
+	}
+
+	if err := uc.repo.Delete(itemID, taskID); err != nil {
+		return err
+	}
+
+	uc.invalidate(userID)
+	return nil
+}
+
+// PromoteItem turns a checklist item into a subtask of its task. The subtask is created
+// through the regular task creation path, so it gets the same defaults and validation.
+func (uc *ChecklistUseCase) PromoteItem(userID, taskID, itemID int64) (*entity.Task, error) {
+	if _, err := uc.taskRepo.GetByID(taskID, userID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	item, err := uc.repo.GetByID(itemID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	subTask, err := uc.taskCreator.CreateTask(userID, entity.CreateTaskRequest{
+		Title:    item.Title,
+		ParentID: &taskID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote checklist item: %w", err)
+	}
+
+	if err := uc.repo.Delete(item.ID, taskID); err != nil {
+		return nil, err
+	}
+
+	uc.invalidate(userID)
+	return subTask, nil
+}
+
+// invalidate drops the user's cached lists, which embed checklist summaries in the task
+// trees.
+func (uc *ChecklistUseCase) invalidate(userID int64) {
+	uc.cache.InvalidateUser(userID)
+}
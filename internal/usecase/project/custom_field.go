@@ -0,0 +1,177 @@
+package project
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+)
+
+var fieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+func (uc *ProjectUseCase) GetCustomFields(userID, projectID int64) ([]entity.CustomField, error) {
+	if _, err := uc.repo.GetByID(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.fieldRepo.GetByProjectID(projectID)
+}
+
+func (uc *ProjectUseCase) CreateCustomField(userID, projectID int64, req entity.CreateCustomFieldRequest) (*entity.CustomField, error) {
+	if _, err := uc.repo.GetByID(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	if !fieldKeyPattern.MatchString(req.Key) {
+		return nil, fmt.Errorf("invalid field key %q: use lowercase letters, digits and underscores, starting with a letter", req.Key)
+	}
+
+	fieldType := constant.CustomFieldType(req.Type)
+	if !fieldType.IsValid() {
+		return nil, fmt.Errorf("invalid field type: %s", req.Type)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("field name cannot be empty")
+	}
+
+	options, err := validateOptions(fieldType, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := uc.fieldRepo.GetByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fields {
+		if f.Key == req.Key {
+			return nil, fmt.Errorf("custom field %s already exists", req.Key)
+		}
+	}
+
+	field := &entity.CustomField{
+		ProjectID: projectID,
+		Key:       req.Key,
+		Name:      name,
+		Type:      fieldType,
+		Options:   options,
+		Required:  req.Required,
+	}
+
+	if err := uc.fieldRepo.Create(field); err != nil {
+		return nil, err
+	}
+
+	return field, nil
+}
+
+// UpdateCustomField renames a field, changes whether it is required or replaces its options.
+// Options still used by tasks cannot be removed.
+func (uc *ProjectUseCase) UpdateCustomField(userID, projectID, fieldID int64, req entity.UpdateCustomFieldRequest) (*entity.CustomField, error) {
+	if _, err := uc.repo.GetByID(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	field, err := uc.fieldRepo.GetByID(fieldID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("field name cannot be empty")
+		}
+
+		field.Name = name
+	}
+
+	if req.Required != nil {
+		field.Required = *req.Required
+	}
+
+	if req.Options != nil {
+		options, err := validateOptions(field.Type, req.Options)
+		if err != nil {
+			return nil, err
+		}
+
+		counts, err := uc.fieldRepo.CountOptionUsage(field.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		kept := entity.CustomField{Options: options}
+		var missing []string
+		for option := range counts {
+			if kept.OptionIndex(option) < 0 {
+				missing = append(missing, option)
+			}
+		}
+
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return nil, fmt.Errorf("options still in use by tasks cannot be removed: %s", strings.Join(missing, ", "))
+		}
+
+		field.Options = options
+	}
+
+	if err := uc.fieldRepo.Update(field); err != nil {
+		return nil, err
+	}
+
+	uc.cache.InvalidateUser(userID)
+	return field, nil
+}
+
+// DeleteCustomField removes the field and its values from every task.
+func (uc *ProjectUseCase) DeleteCustomField(userID, projectID, fieldID int64) error {
+	if _, err := uc.repo.GetByID(projectID, userID); err != nil {
+		return err
+	}
+
+	if err := uc.fieldRepo.Delete(fieldID, projectID); err != nil {
+		return err
+	}
+
+	uc.cache.InvalidateUser(userID)
+	return nil
+}
+
+func validateOptions(fieldType constant.CustomFieldType, options []string) ([]string, error) {
+	if !fieldType.IsSelect() {
+		if len(options) > 0 {
+			return nil, fmt.Errorf("only select fields have options")
+		}
+
+		return nil, nil
+	}
+
+	if len(options) == 0 {
+		return nil, fmt.Errorf("select fields need at least one option")
+	}
+
+	seen := make(map[string]bool)
+	cleaned := make([]string, 0, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return nil, fmt.Errorf("field options cannot be empty")
+		}
+
+		if seen[option] {
+			return nil, fmt.Errorf("duplicate field option: %s", option)
+		}
+
+		seen[option] = true
+		cleaned = append(cleaned, option)
+	}
+
+	return cleaned, nil
+}
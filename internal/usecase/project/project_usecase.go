@@ -0,0 +1,218 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+	"task-management-backend/internal/domain/statemachine"
+	"task-management-backend/pkg/constant"
+)
+
+type ProjectUseCase struct {
+	repo      ports.ProjectRepository
+	fieldRepo ports.CustomFieldRepository
+	cache     ports.TaskCache
+}
+
+func NewProjectUseCase(repo ports.ProjectRepository, fieldRepo ports.CustomFieldRepository, cache ports.TaskCache) *ProjectUseCase {
+	return &ProjectUseCase{
+		repo:      repo,
+		fieldRepo: fieldRepo,
+		cache:     cache,
+	}
+}
+
+func (uc *ProjectUseCase) CreateProject(userID int64, name string) (*entity.Project, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("project name cannot be empty")
+	}
+
+	project := &entity.Project{
+		OwnerID: userID,
+		Name:    name,
+	}
+
+	// new projects start with the default workflow
+	if err := uc.repo.Create(project, entity.DefaultWorkflow().Statuses); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (uc *ProjectUseCase) GetProjects(userID int64) ([]entity.Project, error) {
+	return uc.repo.GetByOwnerID(userID)
+}
+
+func (uc *ProjectUseCase) GetWorkflow(userID, projectID int64) (*entity.Workflow, error) {
+	if _, err := uc.repo.GetByID(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	statuses, err := uc.repo.GetWorkflowStatuses(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Workflow{ProjectID: &projectID, Statuses: statuses}, nil
+}
+
+// UpdateWorkflow replaces the ordered status list. Statuses still used by tasks in the
+// project cannot be removed.
+func (uc *ProjectUseCase) UpdateWorkflow(userID, projectID int64, req entity.UpdateWorkflowRequest) (*entity.Workflow, error) {
+	if _, err := uc.repo.GetByID(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	statuses, err := validateStatuses(req.Statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow := entity.Workflow{ProjectID: &projectID, Statuses: statuses}
+	if err := uc.repo.ReplaceWorkflowStatuses(projectID, workflow.Statuses); err != nil {
+		return nil, err
+	}
+
+	return &workflow, nil
+}
+
+func validateStatuses(inputs []entity.WorkflowStatusInput) ([]entity.WorkflowStatus, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("workflow must have at least one status")
+	}
+
+	seen := make(map[string]bool)
+	statuses := make([]entity.WorkflowStatus, 0, len(inputs))
+	hasDone := false
+	for i, input := range inputs {
+		name := strings.TrimSpace(input.Name)
+		if name == "" || name == string(constant.TaskStatusAll) || name == string(constant.AnyStatus) {
+			return nil, fmt.Errorf("invalid status name: %q", input.Name)
+		}
+
+		key := strings.ToLower(name)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate status: %s", name)
+		}
+
+		seen[key] = true
+
+		category := constant.StatusCategory(input.Category)
+		if !category.IsValid() {
+			return nil, fmt.Errorf("invalid category for status %s: %s", name, input.Category)
+		}
+
+		if i == 0 && category != constant.StatusCategoryTodo {
+			return nil, fmt.Errorf("the first status is the initial status and must be in the todo category")
+		}
+
+		if input.WIPLimit != nil && *input.WIPLimit < 1 {
+			return nil, fmt.Errorf("wip_limit for status %s must be at least 1", name)
+		}
+
+		if input.EnforceWIPLimit && input.WIPLimit == nil {
+			return nil, fmt.Errorf("enforce_wip_limit for status %s requires a wip_limit", name)
+		}
+
+		hasDone = hasDone || category == constant.StatusCategoryDone
+		statuses = append(statuses, entity.WorkflowStatus{
+			Name:            constant.TaskStatus(name),
+			Category:        category,
+			Position:        i,
+			WIPLimit:        input.WIPLimit,
+			EnforceWIPLimit: input.EnforceWIPLimit,
+		})
+	}
+
+	if !hasDone {
+		return nil, fmt.Errorf("workflow must have at least one status in the done category")
+	}
+
+	return statuses, nil
+}
+
+// GetTransitions returns the project's transition rules. Projects without explicit rules
+// report the default rules with Default set.
+func (uc *ProjectUseCase) GetTransitions(userID, projectID int64) ([]entity.WorkflowTransition, bool, error) {
+	workflow, err := uc.GetWorkflow(userID, projectID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	transitions, err := uc.repo.GetTransitions(projectID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return statemachine.New(*workflow, transitions).Transitions(), len(transitions) == 0, nil
+}
+
+// UpdateTransitions replaces the transition rules. An empty list restores the defaults.
+func (uc *ProjectUseCase) UpdateTransitions(userID, projectID int64, req entity.UpdateTransitionsRequest) ([]entity.WorkflowTransition, error) {
+	workflow, err := uc.GetWorkflow(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions, err := validateTransitions(*workflow, req.Transitions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.ReplaceTransitions(projectID, transitions); err != nil {
+		return nil, err
+	}
+
+	return statemachine.New(*workflow, transitions).Transitions(), nil
+}
+
+func validateTransitions(workflow entity.Workflow, inputs []entity.WorkflowTransition) ([]entity.WorkflowTransition, error) {
+	validStatus := func(status constant.TaskStatus) bool {
+		return status == constant.AnyStatus || workflow.Has(status)
+	}
+
+	transitions := make([]entity.WorkflowTransition, 0, len(inputs))
+	for _, t := range inputs {
+		if !validStatus(t.From) {
+			return nil, fmt.Errorf("invalid transition source status: %s", t.From)
+		}
+
+		if !validStatus(t.To) {
+			return nil, fmt.Errorf("invalid transition target status: %s", t.To)
+		}
+
+		for _, guard := range t.Guards {
+			if !guard.IsValid() {
+				return nil, fmt.Errorf("invalid guard for transition %s -> %s: %s", t.From, t.To, guard)
+			}
+		}
+
+		hasFieldsGuard := false
+		for _, guard := range t.Guards {
+			hasFieldsGuard = hasFieldsGuard || guard == constant.GuardRequiredFields
+		}
+
+		if hasFieldsGuard != (len(t.RequiredFields) > 0) {
+			return nil, fmt.Errorf("transition %s -> %s: the required_fields guard needs a non-empty required_fields list and vice versa", t.From, t.To)
+		}
+
+		for _, field := range t.RequiredFields {
+			if !statemachine.RequiredFieldNames[field] {
+				return nil, fmt.Errorf("invalid required field for transition %s -> %s: %s", t.From, t.To, field)
+			}
+		}
+
+		transitions = append(transitions, entity.WorkflowTransition{
+			From:           t.From,
+			To:             t.To,
+			RequiresReason: t.RequiresReason,
+			Guards:         t.Guards,
+			RequiredFields: t.RequiredFields,
+		})
+	}
+
+	return transitions, nil
+}
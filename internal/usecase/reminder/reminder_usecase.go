@@ -0,0 +1,129 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+	"task-management-backend/pkg/constant"
+	"task-management-backend/pkg/netguard"
+	"time"
+)
+
+// webhookLookupTimeout bounds the DNS lookup that checks a webhook URL.
+const webhookLookupTimeout = 5 * time.Second
+
+type ReminderUseCase struct {
+	repo             ports.ReminderRepository
+	taskRepo         ports.TaskRepository
+	notificationRepo ports.NotificationRepository
+}
+
+func NewReminderUseCase(repo ports.ReminderRepository, taskRepo ports.TaskRepository, notificationRepo ports.NotificationRepository) *ReminderUseCase {
+	return &ReminderUseCase{
+		repo:             repo,
+		taskRepo:         taskRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (uc *ReminderUseCase) CreateReminder(userID, taskID int64, req entity.CreateReminderRequest) (*entity.Reminder, error) {
+	task, err := uc.taskRepo.GetByID(taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	reminder := &entity.Reminder{
+		TaskID:     taskID,
+		UserID:     userID,
+		Channel:    constant.NotificationChannel(req.Channel),
+		WebhookURL: req.WebhookURL,
+	}
+
+	if reminder.Channel == "" {
+		reminder.Channel = constant.NotificationChannelInApp
+	}
+
+	switch reminder.Channel {
+	case constant.NotificationChannelInApp:
+		reminder.WebhookURL = ""
+	case constant.NotificationChannelWebhook:
+		u, err := url.Parse(req.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("webhook reminders require a valid http(s) webhook_url")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), webhookLookupTimeout)
+		defer cancel()
+
+		if err := netguard.CheckURL(ctx, req.WebhookURL); err != nil {
+			return nil, fmt.Errorf("webhook_url is not allowed: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid reminder channel: %s", req.Channel)
+	}
+
+	switch {
+	case req.BeforeMinutes != nil && req.At != nil:
+		return nil, fmt.Errorf("set either before_minutes or at, not both")
+	case req.BeforeMinutes != nil:
+		if *req.BeforeMinutes < 0 {
+			return nil, fmt.Errorf("before_minutes cannot be negative")
+		}
+
+		if task.DueAt == nil {
+			return nil, fmt.Errorf("task has no due date to remind before")
+		}
+
+		remindAt := task.DueAt.Add(-time.Duration(*req.BeforeMinutes) * time.Minute)
+		reminder.OffsetMinutes = req.BeforeMinutes
+		reminder.RemindAt = &remindAt
+	case req.At != nil:
+		reminder.RemindAt = req.At
+	default:
+		return nil, fmt.Errorf("before_minutes or at is required")
+	}
+
+	if err := uc.repo.Create(reminder); err != nil {
+		return nil, err
+	}
+
+	return reminder, nil
+}
+
+func (uc *ReminderUseCase) GetReminders(userID, taskID int64) ([]entity.Reminder, error) {
+	if _, err := uc.taskRepo.GetByID(taskID, userID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	return uc.repo.GetByTaskID(taskID, userID)
+}
+
+func (uc *ReminderUseCase) DeleteReminder(userID, reminderID int64) error {
+	return uc.repo.Delete(reminderID, userID)
+}
+
+func (uc *ReminderUseCase) SnoozeReminder(userID, reminderID int64, minutes int) (*entity.Reminder, error) {
+	if minutes <= 0 {
+		return nil, fmt.Errorf("minutes must be positive")
+	}
+
+	if err := uc.repo.Snooze(reminderID, userID, time.Now().Add(time.Duration(minutes)*time.Minute)); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetByID(reminderID, userID)
+}
+
+func (uc *ReminderUseCase) DismissReminder(userID, reminderID int64) (*entity.Reminder, error) {
+	if err := uc.repo.Dismiss(reminderID, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetByID(reminderID, userID)
+}
+
+func (uc *ReminderUseCase) GetNotifications(userID int64) ([]entity.Notification, error) {
+	return uc.notificationRepo.GetByUserID(userID)
+}
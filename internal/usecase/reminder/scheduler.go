@@ -0,0 +1,119 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+const (
+	batchSize   = 100
+	maxAttempts = 5
+	// claimLease is how long a reminder may stay in sending before it is considered abandoned.
+	claimLease = 5 * time.Minute
+)
+
+// Scheduler polls for due reminders and hands them to the notifier for their channel.
+// Reminders are claimed with a conditional update before delivery, so concurrent
+// schedulers never pick up the same reminder and a sent reminder is never queued again.
+// A reminder claimed by a process that crashed mid-delivery is released after claimLease
+// and retried; in-app notifications are unique per reminder and webhook receivers can
+// deduplicate on the X-Reminder-ID header.
+type Scheduler struct {
+	repo      ports.ReminderRepository
+	notifiers map[constant.NotificationChannel]ports.Notifier
+	interval  time.Duration
+}
+
+func NewScheduler(repo ports.ReminderRepository, interval time.Duration, notifiers ...ports.Notifier) *Scheduler {
+	byChannel := make(map[constant.NotificationChannel]ports.Notifier, len(notifiers))
+	for _, n := range notifiers {
+		byChannel[n.Channel()] = n
+	}
+
+	return &Scheduler{
+		repo:      repo,
+		notifiers: byChannel,
+		interval:  interval,
+	}
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	now := time.Now()
+	if err := s.repo.ReleaseStale(now.Add(-claimLease)); err != nil {
+		log.Printf("reminder scheduler: %v", err)
+	}
+
+	reminders, err := s.repo.ClaimDue(now, batchSize)
+	if err != nil {
+		log.Printf("reminder scheduler: %v", err)
+		return
+	}
+
+	for _, reminder := range reminders {
+		if err := s.deliver(ctx, reminder); err != nil {
+			log.Printf("reminder scheduler: reminder %d: %v", reminder.ID, err)
+			s.fail(reminder)
+			continue
+		}
+
+		if err := s.repo.MarkSent(reminder.ID, time.Now()); err != nil {
+			log.Printf("reminder scheduler: %v", err)
+		}
+	}
+}
+
+func (s *Scheduler) deliver(ctx context.Context, reminder entity.Reminder) error {
+	notifier, ok := s.notifiers[reminder.Channel]
+	if !ok {
+		return fmt.Errorf("no notifier for channel %s", reminder.Channel)
+	}
+
+	task, err := s.repo.GetTask(reminder.TaskID, reminder.UserID)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Reminder: %s", task.Title)
+	if task.DueAt != nil {
+		message = fmt.Sprintf("Reminder: %s is due at %s", task.Title, task.DueAt.Format(time.RFC3339))
+	}
+
+	return notifier.Notify(ctx, entity.ReminderEvent{
+		Reminder: reminder,
+		Task:     *task,
+		Message:  message,
+	})
+}
+
+// fail retries with exponential backoff until maxAttempts is reached.
+func (s *Scheduler) fail(reminder entity.Reminder) {
+	var retryAt *time.Time
+	if reminder.Attempts+1 < maxAttempts {
+		t := time.Now().Add(time.Duration(1<<reminder.Attempts) * time.Minute)
+		retryAt = &t
+	}
+
+	if err := s.repo.MarkFailed(reminder.ID, retryAt); err != nil {
+		log.Printf("reminder scheduler: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+	maxQueryLen  = 500
+)
+
+type SearchUseCase struct {
+	repo ports.SearchRepository
+}
+
+func NewSearchUseCase(repo ports.SearchRepository) *SearchUseCase {
+	return &SearchUseCase{
+		repo: repo,
+	}
+}
+
+// Search finds the user's tasks whose title or description match the query.
+func (uc *SearchUseCase) Search(userID int64, query entity.SearchQuery) (*entity.SearchResult, error) {
+	match, err := matchExpression(query.Q)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Limit == 0 {
+		query.Limit = defaultLimit
+	}
+
+	if query.Limit < 0 || query.Limit > maxLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxLimit)
+	}
+
+	if query.Offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative")
+	}
+
+	// one extra hit tells whether there is a next page
+	page := query
+	page.Limit++
+	hits, err := uc.repo.Search(userID, match, page)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.SearchResult{Results: hits}
+	if len(hits) > query.Limit {
+		result.Results = hits[:query.Limit]
+		next := query.Offset + query.Limit
+		result.NextOffset = &next
+	}
+
+	return result, nil
+}
+
+// matchExpression turns the user's query into an FTS5 match expression. Every word becomes
+// a quoted prefix term and double quoted text an exact phrase, so FTS5 operators and syntax
+// typed by the user are searched for as text. All terms must match.
+func matchExpression(q string) (string, error) {
+	if utf8.RuneCountInString(q) > maxQueryLen {
+		return "", fmt.Errorf("q cannot be longer than %d characters", maxQueryLen)
+	}
+
+	var terms []string
+	add := func(text string, prefix bool) {
+		if !strings.ContainsFunc(text, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) }) {
+			return
+		}
+
+		term := `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+		if prefix {
+			term += "*"
+		}
+
+		terms = append(terms, term)
+	}
+
+	rest := strings.TrimSpace(q)
+	for rest != "" {
+		if phrase, ok := strings.CutPrefix(rest, `"`); ok {
+			// an unterminated quote runs to the end of the query
+			text, after, _ := strings.Cut(phrase, `"`)
+			add(text, false)
+			rest = strings.TrimSpace(after)
+			continue
+		}
+
+		end := strings.IndexFunc(rest, func(r rune) bool { return unicode.IsSpace(r) || r == '"' })
+		if end < 0 {
+			end = len(rest)
+		}
+
+		add(rest[:end], true)
+		rest = strings.TrimSpace(rest[end:])
+	}
+
+	if len(terms) == 0 {
+		return "", fmt.Errorf("q must contain at least one word")
+	}
+
+	return strings.Join(terms, " "), nil
+}
@@ -0,0 +1,517 @@
+package sprint
+
+import (
+	"fmt"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+const (
+	// maxBurndownDays bounds the length of a burndown series.
+	maxBurndownDays = 366
+	// defaultVelocitySprints and maxVelocitySprints bound the velocity window.
+	defaultVelocitySprints = 5
+	maxVelocitySprints     = 20
+)
+
+type SprintUseCase struct {
+	repo        ports.SprintRepository
+	taskRepo    ports.TaskRepository
+	projectRepo ports.ProjectRepository
+	fieldRepo   ports.CustomFieldRepository
+	userRepo    ports.UserRepository
+}
+
+func NewSprintUseCase(repo ports.SprintRepository, taskRepo ports.TaskRepository, projectRepo ports.ProjectRepository, fieldRepo ports.CustomFieldRepository, userRepo ports.UserRepository) *SprintUseCase {
+	return &SprintUseCase{
+		repo:        repo,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		fieldRepo:   fieldRepo,
+		userRepo:    userRepo,
+	}
+}
+
+func (uc *SprintUseCase) CreateSprint(userID, projectID int64, req entity.CreateSprintRequest) (*entity.Sprint, error) {
+	if _, err := uc.projectRepo.GetByID(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	kind := constant.SprintKind(req.Kind)
+	if kind == "" {
+		kind = constant.SprintKindSprint
+	}
+
+	if !kind.IsValid() {
+		return nil, fmt.Errorf("invalid kind: %s", req.Kind)
+	}
+
+	sprint := &entity.Sprint{
+		ProjectID: projectID,
+		Kind:      kind,
+		Name:      strings.TrimSpace(req.Name),
+		Goal:      req.Goal,
+		StartAt:   req.StartAt,
+	}
+
+	if req.EndAt != nil {
+		sprint.EndAt = *req.EndAt
+	}
+
+	if err := validateSprint(sprint); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Create(sprint); err != nil {
+		return nil, err
+	}
+
+	return sprint, nil
+}
+
+func validateSprint(sprint *entity.Sprint) error {
+	if sprint.Name == "" {
+		return fmt.Errorf("sprint name cannot be empty")
+	}
+
+	if sprint.EndAt.IsZero() {
+		return fmt.Errorf("end_at is required")
+	}
+
+	if sprint.Kind == constant.SprintKindSprint && sprint.StartAt == nil {
+		return fmt.Errorf("sprints require a start_at")
+	}
+
+	if sprint.StartAt != nil && !sprint.StartAt.Before(sprint.EndAt) {
+		return fmt.Errorf("start_at must be before end_at")
+	}
+
+	return nil
+}
+
+func (uc *SprintUseCase) GetSprints(userID, projectID int64) ([]entity.Sprint, error) {
+	if _, err := uc.projectRepo.GetByID(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetByProjectID(projectID)
+}
+
+// GetSprint returns the sprint with the IDs of its current tasks.
+func (uc *SprintUseCase) GetSprint(userID, sprintID int64) (*entity.Sprint, error) {
+	sprint, err := uc.repo.GetByID(sprintID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := uc.repo.GetMembers(sprintID, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	sprint.TaskIDs = make([]int64, 0, len(members))
+	for _, m := range members {
+		sprint.TaskIDs = append(sprint.TaskIDs, m.TaskID)
+	}
+
+	return sprint, nil
+}
+
+func (uc *SprintUseCase) UpdateSprint(userID, sprintID int64, req entity.UpdateSprintRequest) (*entity.Sprint, error) {
+	sprint, err := uc.openSprint(userID, sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		sprint.Name = strings.TrimSpace(*req.Name)
+	}
+
+	if req.Goal != nil {
+		sprint.Goal = *req.Goal
+	}
+
+	if req.StartAt != nil {
+		sprint.StartAt = req.StartAt
+	}
+
+	if req.EndAt != nil {
+		sprint.EndAt = *req.EndAt
+	}
+
+	if err := validateSprint(sprint); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(sprint); err != nil {
+		return nil, err
+	}
+
+	return sprint, nil
+}
+
+func (uc *SprintUseCase) DeleteSprint(userID, sprintID int64) error {
+	if _, err := uc.repo.GetByID(sprintID, userID); err != nil {
+		return err
+	}
+
+	return uc.repo.Delete(sprintID)
+}
+
+func (uc *SprintUseCase) openSprint(userID, sprintID int64) (*entity.Sprint, error) {
+	sprint, err := uc.repo.GetByID(sprintID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sprint.ClosedAt != nil {
+		return nil, fmt.Errorf("sprint is closed")
+	}
+
+	return sprint, nil
+}
+
+// AddTasks adds top-level tasks of the sprint's project. A task can be in one open sprint
+// and one open milestone at a time; subtasks follow their parent.
+func (uc *SprintUseCase) AddTasks(userID, sprintID int64, req entity.SprintTasksRequest) (*entity.Sprint, error) {
+	sprint, err := uc.openSprint(userID, sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int64]bool)
+	var taskIDs []int64
+	for _, taskID := range req.TaskIDs {
+		if seen[taskID] {
+			continue
+		}
+
+		seen[taskID] = true
+		task, err := uc.taskRepo.GetByID(taskID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("task %d not found: %w", taskID, err)
+		}
+
+		if task.ProjectID == nil || *task.ProjectID != sprint.ProjectID {
+			return nil, fmt.Errorf("task %d does not belong to the sprint's project", taskID)
+		}
+
+		if task.ParentID != nil {
+			return nil, fmt.Errorf("task %d is a subtask; add its parent instead", taskID)
+		}
+
+		current, err := uc.repo.GetOpenSprintOf(taskID, sprint.Kind)
+		if err != nil {
+			return nil, err
+		}
+
+		if current == sprintID {
+			return nil, fmt.Errorf("task %d is already in the %s", taskID, sprint.Kind)
+		}
+
+		if current != 0 {
+			return nil, fmt.Errorf("task %d is already in open %s %d", taskID, sprint.Kind, current)
+		}
+
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	if err := uc.repo.AddTasks(sprintID, taskIDs); err != nil {
+		return nil, err
+	}
+
+	return uc.GetSprint(userID, sprintID)
+}
+
+func (uc *SprintUseCase) RemoveTask(userID, sprintID, taskID int64) error {
+	if _, err := uc.openSprint(userID, sprintID); err != nil {
+		return err
+	}
+
+	return uc.repo.RemoveTask(sprintID, taskID)
+}
+
+// CloseSprint records which tasks were done and moves the unfinished ones to the
+// carry-over sprint, or back to the backlog.
+func (uc *SprintUseCase) CloseSprint(userID, sprintID int64, req entity.CloseSprintRequest) (*entity.Sprint, error) {
+	sprint, err := uc.openSprint(userID, sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CarryOverTo != nil {
+		target, err := uc.openSprint(userID, *req.CarryOverTo)
+		if err != nil {
+			return nil, fmt.Errorf("carry-over sprint: %w", err)
+		}
+
+		if target.ID == sprint.ID || target.ProjectID != sprint.ProjectID || target.Kind != sprint.Kind {
+			return nil, fmt.Errorf("unfinished tasks can only be carried over to another open %s of the project", sprint.Kind)
+		}
+	}
+
+	workflow, err := uc.workflow(sprint.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := uc.repo.GetMembers(sprintID, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := make(map[int64]constant.SprintOutcome, len(members))
+	for _, m := range members {
+		switch {
+		case workflow.IsDone(m.Status):
+			outcomes[m.TaskID] = constant.SprintOutcomeDone
+		case req.CarryOverTo != nil:
+			outcomes[m.TaskID] = constant.SprintOutcomeCarriedOver
+		default:
+			outcomes[m.TaskID] = constant.SprintOutcomeBacklog
+		}
+	}
+
+	if err := uc.repo.Close(sprintID, outcomes, req.CarryOverTo); err != nil {
+		return nil, err
+	}
+
+	return uc.GetSprint(userID, sprintID)
+}
+
+// GetBurndown replays the status history of the sprint's tasks and reports scope and
+// completed work at the end of each day, up to the sprint's end, its close or now.
+func (uc *SprintUseCase) GetBurndown(userID, sprintID int64, query entity.SprintMetricQuery) (*entity.Burndown, error) {
+	sprint, err := uc.repo.GetByID(sprintID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldID, unit, err := uc.pointsField(sprint.ProjectID, query.Points)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := uc.workflow(sprint.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := uc.repo.GetMembers(sprintID, fieldID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := uc.repo.GetStatusHistory(sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[int64][]entity.StatusChange)
+	for _, change := range history {
+		changes[change.TaskID] = append(changes[change.TaskID], change)
+	}
+
+	loc := entity.UserLocation(uc.userRepo.GetByID(userID))
+	start := sprint.CreatedAt
+	if sprint.StartAt != nil {
+		start = *sprint.StartAt
+	}
+
+	cutoff := time.Now()
+	if sprint.ClosedAt != nil && sprint.ClosedAt.Before(cutoff) {
+		cutoff = *sprint.ClosedAt
+	}
+
+	firstDay := entity.StartOfDay(start.In(loc))
+	lastDay := entity.StartOfDay(sprint.EndAt.In(loc))
+	totalDays := daysBetween(firstDay, lastDay)
+	if totalDays > maxBurndownDays {
+		return nil, fmt.Errorf("burndown is limited to sprints of %d days", maxBurndownDays)
+	}
+
+	burndown := &entity.Burndown{SprintID: sprintID, Unit: unit, Timezone: loc.String(), Series: []entity.BurndownPoint{}}
+	for day := 0; day <= totalDays; day++ {
+		date := firstDay.AddDate(0, 0, day)
+		if date.After(cutoff) {
+			break
+		}
+
+		at := date.AddDate(0, 0, 1)
+		if at.After(cutoff) {
+			at = cutoff
+		}
+
+		point := entity.BurndownPoint{Date: date.Format(entity.DateLayout)}
+		for _, m := range members {
+			if m.AddedAt.After(at) || (m.RemovedAt != nil && !m.RemovedAt.After(at)) {
+				continue
+			}
+
+			weight := 1.0
+			if fieldID != 0 {
+				weight = m.Points
+			}
+
+			point.Scope += weight
+			if workflow.IsDone(statusAt(changes[m.TaskID], m.Status, at)) {
+				point.Completed += weight
+			}
+		}
+
+		point.Remaining = point.Scope - point.Completed
+		burndown.Series = append(burndown.Series, point)
+	}
+
+	// The ideal line starts from the first planned scope, so sprints planned after their
+	// start date still get a useful guideline.
+	var baseline float64
+	for _, point := range burndown.Series {
+		if point.Scope > 0 {
+			baseline = point.Scope
+			break
+		}
+	}
+
+	for day := range burndown.Series {
+		burndown.Series[day].Ideal = baseline
+		if totalDays > 0 {
+			burndown.Series[day].Ideal = baseline * float64(totalDays-day) / float64(totalDays)
+		}
+	}
+
+	return burndown, nil
+}
+
+// statusAt is the task's status at the given time according to its history. Tasks without
+// history from before the time fall back to the status they left first, or to current.
+func statusAt(changes []entity.StatusChange, current constant.TaskStatus, at time.Time) constant.TaskStatus {
+	status := current
+	if len(changes) > 0 {
+		status = changes[0].FromStatus
+	}
+
+	for _, change := range changes {
+		if change.ChangedAt.After(at) {
+			break
+		}
+
+		status = change.ToStatus
+	}
+
+	return status
+}
+
+// GetVelocity reports committed and completed work of the project's last closed sprints.
+// Work is committed when the task was in the sprint at its start.
+func (uc *SprintUseCase) GetVelocity(userID, projectID int64, query entity.SprintMetricQuery) (*entity.Velocity, error) {
+	if _, err := uc.projectRepo.GetByID(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	count := query.Count
+	if count == 0 {
+		count = defaultVelocitySprints
+	}
+
+	if count < 1 || count > maxVelocitySprints {
+		return nil, fmt.Errorf("count must be between 1 and %d", maxVelocitySprints)
+	}
+
+	fieldID, unit, err := uc.pointsField(projectID, query.Points)
+	if err != nil {
+		return nil, err
+	}
+
+	sprints, err := uc.repo.GetClosed(projectID, constant.SprintKindSprint, count)
+	if err != nil {
+		return nil, err
+	}
+
+	velocity := &entity.Velocity{ProjectID: projectID, Unit: unit, Sprints: []entity.SprintVelocity{}}
+	var total float64
+	for _, sprint := range sprints {
+		members, err := uc.repo.GetMembers(sprint.ID, fieldID, true)
+		if err != nil {
+			return nil, err
+		}
+
+		result := entity.SprintVelocity{SprintID: sprint.ID, Name: sprint.Name, ClosedAt: *sprint.ClosedAt}
+		for _, m := range members {
+			weight := 1.0
+			if fieldID != 0 {
+				weight = m.Points
+			}
+
+			if sprint.StartAt != nil && !m.AddedAt.After(*sprint.StartAt) && (m.RemovedAt == nil || m.RemovedAt.After(*sprint.StartAt)) {
+				result.Committed += weight
+			}
+
+			if m.Outcome == constant.SprintOutcomeDone {
+				result.Completed += weight
+			}
+		}
+
+		total += result.Completed
+		velocity.Sprints = append(velocity.Sprints, result)
+	}
+
+	if len(velocity.Sprints) > 0 {
+		velocity.Average = total / float64(len(velocity.Sprints))
+	}
+
+	return velocity, nil
+}
+
+// pointsField resolves the story points field; an empty key counts tasks instead.
+func (uc *SprintUseCase) pointsField(projectID int64, key string) (int64, string, error) {
+	if key == "" {
+		return 0, "tasks", nil
+	}
+
+	fields, err := uc.fieldRepo.GetByProjectID(projectID)
+	if err != nil {
+		return 0, "", err
+	}
+
+	for _, field := range fields {
+		if field.Key == key {
+			if field.Type != constant.CustomFieldNumber {
+				return 0, "", fmt.Errorf("points field %s must be a number field", key)
+			}
+
+			return field.ID, "points", nil
+		}
+	}
+
+	return 0, "", fmt.Errorf("unknown points field: %s", key)
+}
+
+func (uc *SprintUseCase) workflow(projectID int64) (entity.Workflow, error) {
+	statuses, err := uc.projectRepo.GetWorkflowStatuses(projectID)
+	if err != nil {
+		return entity.Workflow{}, err
+	}
+
+	if len(statuses) == 0 {
+		return entity.DefaultWorkflow(), nil
+	}
+
+	return entity.Workflow{ProjectID: &projectID, Statuses: statuses}, nil
+}
+
+// daysBetween counts calendar days, which stays correct across DST changes.
+func daysBetween(from, to time.Time) int {
+	days := 0
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		days++
+		if days > maxBurndownDays {
+			break
+		}
+	}
+
+	return days
+}
@@ -0,0 +1,73 @@
+package task
+
+import (
+	"context"
+	"log"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// ArchiveTask archives a task with its subtree. Archiving is independent of the status.
+func (uc *TaskUseCase) ArchiveTask(userID, taskID int64) (*entity.Task, error) {
+	if err := uc.repo.Archive(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	uc.invalidateAll(userID)
+	return uc.withUndo(constant.UndoActionArchive, taskID, userID)
+}
+
+func (uc *TaskUseCase) UnarchiveTask(userID, taskID int64) (*entity.Task, error) {
+	if err := uc.repo.Unarchive(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	uc.invalidateAll(userID)
+	return uc.withUndo(constant.UndoActionUnarchive, taskID, userID)
+}
+
+// Archiver archives top-level tasks that have been done for longer than a set period.
+type Archiver struct {
+	repo     ports.TaskRepository
+	cache    ports.TaskCache
+	after    time.Duration
+	interval time.Duration
+}
+
+func NewArchiver(repo ports.TaskRepository, cache ports.TaskCache, after, interval time.Duration) *Archiver {
+	return &Archiver{
+		repo:     repo,
+		cache:    cache,
+		after:    after,
+		interval: interval,
+	}
+}
+
+func (a *Archiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	a.archive()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.archive()
+		}
+	}
+}
+
+func (a *Archiver) archive() {
+	userIDs, err := a.repo.ArchiveDoneBefore(time.Now().Add(-a.after))
+	if err != nil {
+		log.Printf("archiver: %v", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		a.cache.InvalidateUser(userID)
+	}
+}
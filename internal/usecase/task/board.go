@@ -0,0 +1,216 @@
+package task
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+)
+
+const (
+	defaultBoardLimit = 20
+	maxBoardLimit     = 100
+)
+
+// GetBoard groups the user's top-level tasks into one column per workflow status, each
+// paged independently, and optionally splits the columns into swimlanes. WIP limits are
+// reported for project boards.
+func (uc *TaskUseCase) GetBoard(userID int64, query entity.BoardQuery) (*entity.Board, error) {
+	workflow, err := uc.listWorkflow(userID, query.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := workflow.Statuses
+	if query.Status != "" {
+		status, ok := workflow.Status(query.Status)
+		if !ok {
+			return nil, fmt.Errorf("invalid status filter: %s", query.Status)
+		}
+
+		statuses = []entity.WorkflowStatus{status}
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultBoardLimit
+	}
+
+	if limit < 0 || limit > maxBoardLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxBoardLimit)
+	}
+
+	if query.Offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative")
+	}
+
+	swimlane, err := uc.parseSwimlane(query.Swimlane, query.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := entity.BoardFilter{
+		UserID:    userID,
+		ProjectID: query.ProjectID,
+		Swimlane:  swimlane,
+		Offset:    query.Offset,
+		Limit:     limit,
+	}
+
+	for _, status := range statuses {
+		filter.Statuses = append(filter.Statuses, status.Name)
+	}
+
+	cells, err := uc.repo.GetBoard(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &entity.Board{ProjectID: query.ProjectID, Swimlane: query.Swimlane}
+	column := func(status entity.WorkflowStatus, cells []entity.BoardCell) entity.BoardColumn {
+		col := entity.BoardColumn{Status: status.Name, Category: status.Category}
+		for _, cell := range cells {
+			if cell.Status == status.Name {
+				col.Count += cell.Count
+				col.Tasks = append(col.Tasks, cell.Tasks...)
+			}
+		}
+
+		if query.Offset+len(col.Tasks) < col.Count {
+			next := query.Offset + len(col.Tasks)
+			col.NextOffset = &next
+		}
+
+		return col
+	}
+
+	for _, status := range statuses {
+		col := column(status, cells)
+		// limits belong to a project's workflow, so they only apply to project boards
+		if query.ProjectID != nil && status.WIPLimit != nil {
+			col.WIPLimit = status.WIPLimit
+			col.WIPExceeded = col.Count > *status.WIPLimit
+		}
+
+		if swimlane != nil {
+			col.Tasks, col.NextOffset = nil, nil
+		}
+
+		board.Columns = append(board.Columns, col)
+	}
+
+	if swimlane == nil {
+		return board, nil
+	}
+
+	// cells are grouped by the stored value; a nil value is the lane of tasks without one
+	type laneID struct {
+		set   bool
+		value string
+	}
+
+	lanes := make(map[laneID][]entity.BoardCell)
+	var ids []laneID
+	for _, cell := range cells {
+		var id laneID
+		if cell.Lane != nil {
+			id = laneID{set: true, value: *cell.Lane}
+		}
+
+		if _, ok := lanes[id]; !ok {
+			ids = append(ids, id)
+		}
+
+		lanes[id] = append(lanes[id], cell)
+	}
+
+	keys := make([]*string, len(ids))
+	for i, id := range ids {
+		keys[i] = laneKey(lanes[id][0].Lane, swimlane)
+	}
+
+	for i, id := range ids {
+		lane := entity.BoardSwimlane{Key: keys[i]}
+		for _, status := range statuses {
+			lane.Columns = append(lane.Columns, column(status, lanes[id]))
+		}
+
+		board.Swimlanes = append(board.Swimlanes, lane)
+	}
+
+	sort.SliceStable(board.Swimlanes, func(i, j int) bool {
+		return laneOrder(board.Swimlanes[i].Key, swimlane) < laneOrder(board.Swimlanes[j].Key, swimlane)
+	})
+
+	return board, nil
+}
+
+// parseSwimlane resolves "priority" or "cf.<key>"; custom field swimlanes need a project
+// and a select field. Tasks have no assignees or labels, so there are no swimlanes for them.
+func (uc *TaskUseCase) parseSwimlane(value string, projectID *int64) (*entity.Swimlane, error) {
+	switch value {
+	case "":
+		return nil, nil
+	case entity.SwimlanePriority:
+		return &entity.Swimlane{Kind: value}, nil
+	case "assignee", "label":
+		return nil, fmt.Errorf("invalid swimlane: %q (tasks have no %ss; use priority or a select custom field)", value, value)
+	}
+
+	key, ok := strings.CutPrefix(value, customFieldSortPrefix)
+	if !ok {
+		return nil, fmt.Errorf("invalid swimlane: %q (use priority or cf.<key>)", value)
+	}
+
+	fields, err := uc.customFieldsFor(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	field, found := findCustomField(fields, key)
+	if !found {
+		return nil, fmt.Errorf("invalid swimlane: %q (custom fields require project_id)", value)
+	}
+
+	if !field.Type.IsSelect() {
+		return nil, fmt.Errorf("swimlane field %s must be a single or multi select field", key)
+	}
+
+	return &entity.Swimlane{Kind: entity.SwimlaneCustomField, Field: &field}, nil
+}
+
+// laneKey turns the stored swimlane value into its display key; select fields store the
+// option position.
+func laneKey(value *string, swimlane *entity.Swimlane) *string {
+	if value == nil || swimlane.Kind != entity.SwimlaneCustomField {
+		return value
+	}
+
+	index, err := strconv.Atoi(*value)
+	if err != nil || index < 0 || index >= len(swimlane.Field.Options) {
+		return value
+	}
+
+	option := swimlane.Field.Options[index]
+	return &option
+}
+
+// laneOrder sorts the most urgent priorities and earlier options first, tasks without a
+// value last.
+func laneOrder(key *string, swimlane *entity.Swimlane) int {
+	if key == nil {
+		return math.MaxInt
+	}
+
+	switch swimlane.Kind {
+	case entity.SwimlanePriority:
+		return -constant.TaskPriority(*key).Rank()
+	case entity.SwimlaneCustomField:
+		return swimlane.Field.OptionIndex(*key)
+	}
+
+	return 0
+}
@@ -0,0 +1,89 @@
+package task
+
+import (
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+)
+
+// CloneTask copies a task, by default with its subtasks and checklists, into the requested
+// parent or project. Custom field values are only copied within the same project, and
+// clones moved to another project start in that project's initial status.
+func (uc *TaskUseCase) CloneTask(userID, taskID int64, req entity.CloneTaskRequest) (*entity.Task, error) {
+	source, err := uc.repo.GetByID(taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	if req.IncludeLabels != nil && *req.IncludeLabels {
+		return nil, fmt.Errorf("include_labels is not supported: tasks have no labels")
+	}
+
+	if req.IncludeAttachments != nil && *req.IncludeAttachments {
+		return nil, fmt.Errorf("include_attachments is not supported: tasks have no attachments")
+	}
+
+	opts := entity.CloneOptions{
+		ParentID:   source.ParentID,
+		ProjectID:  source.ProjectID,
+		SubTasks:   req.IncludeSubTasks == nil || *req.IncludeSubTasks,
+		Checklists: req.IncludeChecklists == nil || *req.IncludeChecklists,
+	}
+
+	if req.Title != nil {
+		if *req.Title == "" {
+			return nil, fmt.Errorf("task title cannot be empty")
+		}
+
+		opts.Title = *req.Title
+	}
+
+	switch {
+	case req.ParentID != nil && *req.ParentID != 0:
+		parent, err := uc.repo.GetByID(*req.ParentID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("parent task not found: %w", err)
+		}
+
+		if req.ProjectID != nil && !sameProject(req.ProjectID, parent.ProjectID) {
+			return nil, fmt.Errorf("subtasks must belong to the parent's project")
+		}
+
+		opts.ParentID = &parent.ID
+		opts.ProjectID = parent.ProjectID
+	case req.ProjectID != nil:
+		if _, err := uc.projectRepo.GetByID(*req.ProjectID, userID); err != nil {
+			return nil, err
+		}
+
+		opts.ProjectID = req.ProjectID
+		if req.ParentID != nil || !sameProject(req.ProjectID, source.ProjectID) {
+			opts.ParentID = nil
+		}
+	case req.ParentID != nil:
+		opts.ParentID = nil
+	}
+
+	opts.CustomFields = sameProject(opts.ProjectID, source.ProjectID)
+	if req.ResetStatuses || !opts.CustomFields {
+		workflow, err := uc.workflowFor(opts.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Status = workflow.Initial()
+	}
+
+	clone, err := uc.repo.CloneTree(taskID, userID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone task: %w", err)
+	}
+
+	if err := uc.recordVersion(clone, nil); err != nil {
+		return nil, err
+	}
+
+	uc.cache.InvalidateUser(userID)
+	uc.recordUndo(constant.UndoActionCreate, clone, nil)
+	return clone, nil
+}
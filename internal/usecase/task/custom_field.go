@@ -0,0 +1,270 @@
+package task
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// customFieldSortPrefix marks sort keys and filters that refer to a custom field.
+const customFieldSortPrefix = "cf."
+
+func (uc *TaskUseCase) customFieldsFor(projectID *int64) ([]entity.CustomField, error) {
+	if projectID == nil {
+		return nil, nil
+	}
+
+	return uc.customFieldRepo.GetByProjectID(*projectID)
+}
+
+func findCustomField(fields []entity.CustomField, key string) (entity.CustomField, bool) {
+	for _, field := range fields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+
+	return entity.CustomField{}, false
+}
+
+// resolveCustomFields validates input against the project's fields. It returns the values to
+// store keyed by field ID and the task's custom_fields after the change. current holds the
+// task's existing values and is nil for new tasks, which must set every required field.
+func (uc *TaskUseCase) resolveCustomFields(projectID *int64, input, current map[string]any) (map[int64][]entity.FieldValue, map[string]any, error) {
+	if projectID == nil {
+		if len(input) > 0 {
+			return nil, nil, fmt.Errorf("custom fields require the task to belong to a project")
+		}
+
+		return nil, nil, nil
+	}
+
+	fields, err := uc.customFieldsFor(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for key := range input {
+		if _, ok := findCustomField(fields, key); !ok {
+			return nil, nil, fmt.Errorf("unknown custom field: %s", key)
+		}
+	}
+
+	values := make(map[int64][]entity.FieldValue)
+	display := make(map[string]any, len(current))
+	for key, value := range current {
+		display[key] = value
+	}
+
+	for _, field := range fields {
+		raw, given := input[field.Key]
+		if !given {
+			if field.Required && current == nil {
+				return nil, nil, fmt.Errorf("custom field %s is required", field.Key)
+			}
+
+			continue
+		}
+
+		fieldValues, err := uc.normalizeFieldValue(field, raw)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(fieldValues) == 0 && field.Required {
+			return nil, nil, fmt.Errorf("custom field %s is required", field.Key)
+		}
+
+		values[field.ID] = fieldValues
+		if len(fieldValues) == 0 {
+			delete(display, field.Key)
+		} else {
+			display[field.Key] = field.Display(fieldValues)
+		}
+	}
+
+	if len(display) == 0 {
+		display = nil
+	}
+
+	return values, display, nil
+}
+
+// normalizeFieldValue converts a JSON value to stored values. null, an empty string and an
+// empty list clear the field.
+func (uc *TaskUseCase) normalizeFieldValue(field entity.CustomField, raw any) ([]entity.FieldValue, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	invalid := func(expected string) error {
+		return fmt.Errorf("custom field %s expects %s, got %v", field.Key, expected, raw)
+	}
+
+	switch field.Type {
+	case constant.CustomFieldText:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, invalid("a string")
+		}
+
+		if text == "" {
+			return nil, nil
+		}
+
+		return []entity.FieldValue{{FieldID: field.ID, Text: &text}}, nil
+	case constant.CustomFieldNumber:
+		number, ok := raw.(float64)
+		if !ok {
+			return nil, invalid("a number")
+		}
+
+		return []entity.FieldValue{{FieldID: field.ID, Number: &number}}, nil
+	case constant.CustomFieldDate:
+		date, ok := raw.(string)
+		if !ok {
+			return nil, invalid("a YYYY-MM-DD date")
+		}
+
+		if date == "" {
+			return nil, nil
+		}
+
+		if _, err := time.Parse(entity.DateLayout, date); err != nil {
+			return nil, invalid("a YYYY-MM-DD date")
+		}
+
+		return []entity.FieldValue{{FieldID: field.ID, Text: &date}}, nil
+	case constant.CustomFieldSingleSelect:
+		option, ok := raw.(string)
+		if !ok {
+			return nil, invalid("one of: " + strings.Join(field.Options, ", "))
+		}
+
+		if option == "" {
+			return nil, nil
+		}
+
+		value, err := selectValue(field, option)
+		if err != nil {
+			return nil, err
+		}
+
+		return []entity.FieldValue{value}, nil
+	case constant.CustomFieldMultiSelect:
+		list, ok := raw.([]any)
+		if !ok {
+			return nil, invalid("a list of options")
+		}
+
+		seen := make(map[string]bool)
+		values := make([]entity.FieldValue, 0, len(list))
+		for _, item := range list {
+			option, ok := item.(string)
+			if !ok {
+				return nil, invalid("a list of options")
+			}
+
+			if seen[option] {
+				continue
+			}
+
+			seen[option] = true
+			value, err := selectValue(field, option)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, value)
+		}
+
+		sort.Slice(values, func(i, j int) bool { return *values[i].Number < *values[j].Number })
+		return values, nil
+	case constant.CustomFieldUser:
+		number, ok := raw.(float64)
+		if !ok || number != math.Trunc(number) {
+			return nil, invalid("a user ID")
+		}
+
+		if _, err := uc.userRepo.GetByID(int64(number)); err != nil {
+			return nil, fmt.Errorf("custom field %s: user %d not found", field.Key, int64(number))
+		}
+
+		return []entity.FieldValue{{FieldID: field.ID, Number: &number}}, nil
+	default:
+		return nil, fmt.Errorf("custom field %s has unsupported type %s", field.Key, field.Type)
+	}
+}
+
+func selectValue(field entity.CustomField, option string) (entity.FieldValue, error) {
+	index := field.OptionIndex(option)
+	if index < 0 {
+		return entity.FieldValue{}, fmt.Errorf("invalid option %q for custom field %s, expected one of: %s", option, field.Key, strings.Join(field.Options, ", "))
+	}
+
+	position := float64(index)
+	return entity.FieldValue{FieldID: field.ID, Text: &option, Number: &position}, nil
+}
+
+// customFieldFilters parses "key" and "key.gte" / "key.lte" list parameters. Range filters
+// apply to number and date fields only.
+func customFieldFilters(fields []entity.CustomField, raw map[string]string) ([]entity.CustomFieldFilter, error) {
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	filters := make([]entity.CustomFieldFilter, 0, len(raw))
+	for _, param := range keys {
+		key, op := param, "eq"
+		if i := strings.LastIndex(param, "."); i >= 0 {
+			key, op = param[:i], param[i+1:]
+		}
+
+		field, ok := findCustomField(fields, key)
+		if !ok {
+			return nil, fmt.Errorf("unknown custom field filter: %s", param)
+		}
+
+		ranged := field.Type == constant.CustomFieldNumber || field.Type == constant.CustomFieldDate
+		if op != "eq" && !(ranged && (op == "gte" || op == "lte")) {
+			return nil, fmt.Errorf("invalid custom field filter: %s", param)
+		}
+
+		value, err := parseFieldFilterValue(field, raw[param])
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, entity.CustomFieldFilter{Field: field, Op: op, Value: value})
+	}
+
+	return filters, nil
+}
+
+func parseFieldFilterValue(field entity.CustomField, value string) (entity.FieldValue, error) {
+	switch field.Type {
+	case constant.CustomFieldNumber, constant.CustomFieldUser:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return entity.FieldValue{}, fmt.Errorf("invalid value for custom field filter %s: %q", field.Key, value)
+		}
+
+		return entity.FieldValue{FieldID: field.ID, Number: &number}, nil
+	case constant.CustomFieldDate:
+		if _, err := time.Parse(entity.DateLayout, value); err != nil {
+			return entity.FieldValue{}, fmt.Errorf("invalid date for custom field filter %s: %q", field.Key, value)
+		}
+	case constant.CustomFieldSingleSelect, constant.CustomFieldMultiSelect:
+		return selectValue(field, value)
+	}
+
+	return entity.FieldValue{FieldID: field.ID, Text: &value}, nil
+}
@@ -0,0 +1,53 @@
+package task
+
+import (
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+)
+
+// AddDependency records that taskID cannot start until blockerID is done.
+func (uc *TaskUseCase) AddDependency(userID, taskID, blockerID int64) (*entity.Task, error) {
+	if taskID == blockerID {
+		return nil, fmt.Errorf("a task cannot block itself")
+	}
+
+	if _, err := uc.repo.GetByID(taskID, userID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	if _, err := uc.repo.GetByID(blockerID, userID); err != nil {
+		return nil, fmt.Errorf("blocking task not found: %w", err)
+	}
+
+	// adding blocker -> task closes a cycle if blocker is already downstream of task
+	cycle, err := uc.dependencyRepo.Reaches(taskID, blockerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cycle {
+		return nil, fmt.Errorf("cannot add dependency: circular dependency detected")
+	}
+
+	if err := uc.dependencyRepo.Add(blockerID, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	uc.invalidateAll(userID)
+	return uc.repo.GetByID(taskID, userID)
+}
+
+func (uc *TaskUseCase) RemoveDependency(userID, taskID, blockerID int64) (*entity.Task, error) {
+	if err := uc.dependencyRepo.Remove(blockerID, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	uc.invalidateAll(userID)
+	return uc.repo.GetByID(taskID, userID)
+}
+
+// invalidateAll drops every cached list for the user, for changes that are visible on
+// tasks other than the one being modified.
+func (uc *TaskUseCase) invalidateAll(userID int64) {
+	uc.cache.InvalidateUser(userID)
+}
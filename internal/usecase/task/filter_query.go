@@ -0,0 +1,362 @@
+package task
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"task-management-backend/pkg/query"
+	"time"
+)
+
+// filterQueryFields lists the fields of the filter language besides cf.<key>, for errors.
+const filterQueryFields = "title, description, status, category, priority, project, due, start, created, updated or cf.<key>"
+
+// filterDateColumns maps the date fields of the filter language to task columns.
+var filterDateColumns = map[string]string{
+	"due":     "due_at",
+	"start":   "start_at",
+	"created": "created_at",
+	"updated": "updated_at",
+}
+
+// filterQuery resolves the filter expressions of a list query. Statuses are checked against
+// workflow and custom fields against the fields of the query's project.
+type filterQuery struct {
+	workflow entity.Workflow
+	fields   []entity.CustomField
+	project  bool
+	loc      *time.Location
+	now      time.Time
+}
+
+// parseFilterQuery parses and resolves the filter parameter. Errors carry the position of
+// the offending part of the filter.
+func (uc *TaskUseCase) parseFilterQuery(userID int64, q entity.TaskQuery, workflow entity.Workflow) (*entity.FilterExpr, error) {
+	node, err := query.Parse(q.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	fields, err := uc.customFieldsFor(q.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := entity.UserLocation(uc.userRepo.GetByID(userID))
+	fq := &filterQuery{
+		workflow: workflow,
+		fields:   fields,
+		project:  q.ProjectID != nil,
+		loc:      loc,
+		now:      time.Now().In(loc),
+	}
+
+	expr, err := fq.resolve(node)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	return &expr, nil
+}
+
+func (fq *filterQuery) resolve(node query.Node) (entity.FilterExpr, error) {
+	switch n := node.(type) {
+	case *query.And:
+		left, right, err := fq.resolvePair(n.Left, n.Right)
+		if err != nil {
+			return entity.FilterExpr{}, err
+		}
+
+		// chains of AND parse as nested pairs; keep them as one list
+		return entity.FilterExpr{And: append(flatten(left, left.And), flatten(right, right.And)...)}, nil
+	case *query.Or:
+		left, right, err := fq.resolvePair(n.Left, n.Right)
+		if err != nil {
+			return entity.FilterExpr{}, err
+		}
+
+		return entity.FilterExpr{Or: append(flatten(left, left.Or), flatten(right, right.Or)...)}, nil
+	case *query.Not:
+		expr, err := fq.resolve(n.Expr)
+		if err != nil {
+			return entity.FilterExpr{}, err
+		}
+
+		return entity.FilterExpr{Not: &expr}, nil
+	case *query.Comparison:
+		return fq.comparison(n)
+	}
+
+	return entity.FilterExpr{}, query.Errorf(node.Pos(), "unsupported expression")
+}
+
+func (fq *filterQuery) resolvePair(a, b query.Node) (entity.FilterExpr, entity.FilterExpr, error) {
+	left, err := fq.resolve(a)
+	if err != nil {
+		return left, left, err
+	}
+
+	right, err := fq.resolve(b)
+	return left, right, err
+}
+
+// flatten returns the operands of expr if it is a list of the same kind, or expr itself.
+func flatten(expr entity.FilterExpr, operands []entity.FilterExpr) []entity.FilterExpr {
+	if len(operands) > 0 {
+		return operands
+	}
+
+	return []entity.FilterExpr{expr}
+}
+
+func condition(field, op string, value any) entity.FilterExpr {
+	return entity.FilterExpr{Condition: &entity.FilterCondition{Field: field, Op: op, Value: value}}
+}
+
+func (fq *filterQuery) comparison(c *query.Comparison) (entity.FilterExpr, error) {
+	name := strings.ToLower(c.Field)
+	if strings.HasPrefix(name, customFieldSortPrefix) {
+		return fq.customField(c, c.Field[len(customFieldSortPrefix):])
+	}
+
+	if column, ok := filterDateColumns[name]; ok {
+		return fq.date(c, column)
+	}
+
+	switch name {
+	case "title", "description":
+		switch c.Op {
+		case query.OpMatch:
+			return condition(name, "contains", c.Value), nil
+		case query.OpEqual:
+			return condition(name, "eq", c.Value), nil
+		case query.OpNotEqual:
+			return condition(name, "ne", c.Value), nil
+		}
+	case "status":
+		status := constant.TaskStatus(c.Value)
+		if !fq.workflow.Has(status) {
+			return entity.FilterExpr{}, query.Errorf(c.ValuePos, "unknown status %q", c.Value)
+		}
+
+		if op, ok := equality(c.Op); ok {
+			return condition("status", op, string(status)), nil
+		}
+	case "category":
+		category := constant.StatusCategory(c.Value)
+		if !category.IsValid() {
+			return entity.FilterExpr{}, query.Errorf(c.ValuePos, "unknown status category %q", c.Value)
+		}
+
+		if op, ok := equality(c.Op); ok {
+			values := []any{}
+			for _, status := range fq.workflow.StatusesIn(category) {
+				values = append(values, string(status))
+			}
+
+			in := entity.FilterExpr{Condition: &entity.FilterCondition{Field: "status", Op: "in", Values: values}}
+			if op == "ne" {
+				return entity.FilterExpr{Not: &in}, nil
+			}
+
+			return in, nil
+		}
+	case "priority":
+		return fq.priority(c)
+	case "project":
+		if c.Value == "none" && !c.Quoted {
+			if op, ok := equality(c.Op); ok {
+				return nullCondition("project_id", op), nil
+			}
+
+			break
+		}
+
+		projectID, err := strconv.ParseInt(c.Value, 10, 64)
+		if err != nil {
+			return entity.FilterExpr{}, query.Errorf(c.ValuePos, "project expects a project ID or none, got %q", c.Value)
+		}
+
+		if op, ok := equality(c.Op); ok {
+			return condition("project_id", op, projectID), nil
+		}
+	case "label":
+		// the filter language was specified with labels, which tasks do not have yet
+		return entity.FilterExpr{}, query.Errorf(c.FieldPos, "label filters are not supported: tasks have no labels")
+	default:
+		return entity.FilterExpr{}, query.Errorf(c.FieldPos, "unknown field %q, expected %s", c.Field, filterQueryFields)
+	}
+
+	return entity.FilterExpr{}, query.Errorf(c.OpPos, "operator %q is not supported for %s", c.Op, name)
+}
+
+// equality maps ":", "=" and "!=" to eq and ne.
+func equality(op query.Operator) (string, bool) {
+	switch op {
+	case query.OpMatch, query.OpEqual:
+		return "eq", true
+	case query.OpNotEqual:
+		return "ne", true
+	}
+
+	return "", false
+}
+
+func nullCondition(column, op string) entity.FilterExpr {
+	if op == "ne" {
+		return condition(column, "not_null", nil)
+	}
+
+	return condition(column, "null", nil)
+}
+
+// priority resolves comparisons to the list of matching priorities, so priority>=high
+// matches high and urgent.
+func (fq *filterQuery) priority(c *query.Comparison) (entity.FilterExpr, error) {
+	rank := constant.TaskPriority(c.Value).Rank()
+	if rank < 0 {
+		return entity.FilterExpr{}, query.Errorf(c.ValuePos, "unknown priority %q, expected one of: none, low, medium, high, urgent", c.Value)
+	}
+
+	var match func(int) bool
+	switch c.Op {
+	case query.OpMatch, query.OpEqual:
+		match = func(r int) bool { return r == rank }
+	case query.OpNotEqual:
+		match = func(r int) bool { return r != rank }
+	case query.OpLess:
+		match = func(r int) bool { return r < rank }
+	case query.OpLessEqual:
+		match = func(r int) bool { return r <= rank }
+	case query.OpGreater:
+		match = func(r int) bool { return r > rank }
+	case query.OpGreaterEqual:
+		match = func(r int) bool { return r >= rank }
+	}
+
+	values := []any{}
+	for r, priority := range constant.TaskPriorities {
+		if match(r) {
+			values = append(values, string(priority))
+		}
+	}
+
+	return entity.FilterExpr{Condition: &entity.FilterCondition{Field: "priority", Op: "in", Values: values}}, nil
+}
+
+// date resolves a date comparison. A YYYY-MM-DD date, today, yesterday or tomorrow stands
+// for the whole day in the user's timezone, so due:2026-11-01 matches any time that day and
+// due>2026-11-01 starts the next midnight. none matches tasks without the date.
+func (fq *filterQuery) date(c *query.Comparison, column string) (entity.FilterExpr, error) {
+	if c.Value == "none" && !c.Quoted {
+		if op, ok := equality(c.Op); ok {
+			return nullCondition(column, op), nil
+		}
+
+		return entity.FilterExpr{}, query.Errorf(c.OpPos, "operator %q is not supported with none", c.Op)
+	}
+
+	day, dateOnly, err := fq.parseDate(c.Value)
+	if err != nil {
+		return entity.FilterExpr{}, query.Errorf(c.ValuePos, "%s expects YYYY-MM-DD, an RFC 3339 timestamp, today, yesterday, tomorrow or none, got %q", strings.ToLower(c.Field), c.Value)
+	}
+
+	if !dateOnly {
+		switch c.Op {
+		case query.OpMatch, query.OpEqual:
+			return condition(column, "eq", day), nil
+		case query.OpNotEqual:
+			return condition(column, "ne", day), nil
+		case query.OpLess:
+			return condition(column, "lt", day), nil
+		case query.OpLessEqual:
+			return condition(column, "lte", day), nil
+		case query.OpGreater:
+			return condition(column, "gt", day), nil
+		}
+
+		return condition(column, "gte", day), nil
+	}
+
+	next := day.AddDate(0, 0, 1)
+	switch c.Op {
+	case query.OpLess:
+		return condition(column, "lt", day), nil
+	case query.OpLessEqual:
+		return condition(column, "lt", next), nil
+	case query.OpGreater:
+		return condition(column, "gte", next), nil
+	case query.OpGreaterEqual:
+		return condition(column, "gte", day), nil
+	}
+
+	within := entity.FilterExpr{And: []entity.FilterExpr{condition(column, "gte", day), condition(column, "lt", next)}}
+	if c.Op == query.OpNotEqual {
+		// like ne, != also matches tasks without the date
+		return entity.FilterExpr{Or: []entity.FilterExpr{{Not: &within}, condition(column, "null", nil)}}, nil
+	}
+
+	return within, nil
+}
+
+func (fq *filterQuery) parseDate(value string) (time.Time, bool, error) {
+	today := entity.StartOfDay(fq.now)
+	switch strings.ToLower(value) {
+	case "today":
+		return today, true, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true, nil
+	}
+
+	return parseDateParam(value, fq.loc)
+}
+
+// customField resolves a comparison of a custom field of the query's project. Ranges apply
+// to number and date fields; != also matches tasks without a value.
+func (fq *filterQuery) customField(c *query.Comparison, key string) (entity.FilterExpr, error) {
+	if !fq.project {
+		return entity.FilterExpr{}, query.Errorf(c.FieldPos, "custom field filters require project_id")
+	}
+
+	field, ok := findCustomField(fq.fields, key)
+	if !ok {
+		return entity.FilterExpr{}, query.Errorf(c.FieldPos, "unknown custom field %q", key)
+	}
+
+	value, err := parseFieldFilterValue(field, c.Value)
+	if err != nil {
+		return entity.FilterExpr{}, query.Errorf(c.ValuePos, "%s", err)
+	}
+
+	ranged := field.Type == constant.CustomFieldNumber || field.Type == constant.CustomFieldDate
+	op := ""
+	switch c.Op {
+	case query.OpMatch, query.OpEqual, query.OpNotEqual:
+		op = "eq"
+	case query.OpLess:
+		op = "lt"
+	case query.OpLessEqual:
+		op = "lte"
+	case query.OpGreater:
+		op = "gt"
+	case query.OpGreaterEqual:
+		op = "gte"
+	}
+
+	if op != "eq" && !ranged {
+		return entity.FilterExpr{}, query.Errorf(c.OpPos, "operator %q is not supported for %s custom field %s", c.Op, field.Type, field.Key)
+	}
+
+	expr := entity.FilterExpr{Condition: &entity.FilterCondition{
+		CustomField: &entity.CustomFieldFilter{Field: field, Op: op, Value: value},
+	}}
+	if c.Op == query.OpNotEqual {
+		return entity.FilterExpr{Not: &expr}, nil
+	}
+
+	return expr, nil
+}
@@ -0,0 +1,231 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"task-management-backend/pkg/query"
+	"testing"
+	"time"
+)
+
+// describe renders a resolved filter in a compact form for comparison.
+func describe(expr entity.FilterExpr) string {
+	list := func(exprs []entity.FilterExpr, separator string) string {
+		parts := make([]string, len(exprs))
+		for i, e := range exprs {
+			parts[i] = describe(e)
+		}
+
+		return "(" + strings.Join(parts, separator) + ")"
+	}
+
+	switch {
+	case len(expr.And) > 0:
+		return list(expr.And, " AND ")
+	case len(expr.Or) > 0:
+		return list(expr.Or, " OR ")
+	case expr.Not != nil:
+		return "NOT " + describe(*expr.Not)
+	case expr.Condition == nil:
+		return "<empty>"
+	}
+
+	c := expr.Condition
+	if cf := c.CustomField; cf != nil {
+		value := "?"
+		if cf.Value.Text != nil {
+			value = *cf.Value.Text
+		} else if cf.Value.Number != nil {
+			value = fmt.Sprint(*cf.Value.Number)
+		}
+
+		return fmt.Sprintf("cf.%s %s %s", cf.Field.Key, cf.Op, value)
+	}
+
+	switch c.Op {
+	case "null", "not_null":
+		return c.Field + " " + c.Op
+	case "in":
+		return fmt.Sprintf("%s in %v", c.Field, c.Values)
+	}
+
+	value := c.Value
+	if t, ok := value.(time.Time); ok {
+		value = t.Format(time.RFC3339)
+	}
+
+	return fmt.Sprintf("%s %s %v", c.Field, c.Op, value)
+}
+
+func testFilterQuery(project bool) *filterQuery {
+	loc := time.FixedZone("WIB", 7*60*60)
+	return &filterQuery{
+		workflow: entity.DefaultWorkflow(),
+		fields: []entity.CustomField{
+			{ID: 1, Key: "team", Type: constant.CustomFieldText},
+			{ID: 2, Key: "points", Type: constant.CustomFieldNumber},
+		},
+		project: project,
+		loc:     loc,
+		now:     time.Date(2026, time.October, 19, 15, 0, 0, 0, loc),
+	}
+}
+
+func resolveFilter(fq *filterQuery, input string) (entity.FilterExpr, error) {
+	node, err := query.Parse(input)
+	if err != nil {
+		return entity.FilterExpr{}, err
+	}
+
+	return fq.resolve(node)
+}
+
+func TestFilterQueryResolve(t *testing.T) {
+	const (
+		nov1 = "2026-11-01T00:00:00+07:00"
+		nov2 = "2026-11-02T00:00:00+07:00"
+		ts   = "2026-11-01T09:30:00Z"
+	)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		// text
+		{"title:Report", "title contains Report"},
+		{"title=Report", "title eq Report"},
+		{"title!=Report", "title ne Report"},
+		{"description:bug", "description contains bug"},
+		{"description=bug", "description eq bug"},
+		{"description!=bug", "description ne bug"},
+		{"TITLE:x", "title contains x"},
+
+		// status and category
+		{`status:"in progress"`, "status eq in progress"},
+		{"status=done", "status eq done"},
+		{"status!=done", "status ne done"},
+		{"category:active", "status in [in progress]"},
+		{"category=done", "status in [done]"},
+		{"category!=todo", "NOT status in [to do]"},
+
+		// priority
+		{"priority:high", "priority in [high]"},
+		{"priority=none", "priority in [none]"},
+		{"priority!=low", "priority in [none medium high urgent]"},
+		{"priority<medium", "priority in [none low]"},
+		{"priority<=medium", "priority in [none low medium]"},
+		{"priority>medium", "priority in [high urgent]"},
+		{"priority>=high", "priority in [high urgent]"},
+		{"priority>urgent", "priority in []"},
+
+		// project
+		{"project:3", "project_id eq 3"},
+		{"project=3", "project_id eq 3"},
+		{"project!=3", "project_id ne 3"},
+		{"project:none", "project_id null"},
+		{"project!=none", "project_id not_null"},
+
+		// dates stand for the whole day in the user's timezone
+		{"due:2026-11-01", "(due_at gte " + nov1 + " AND due_at lt " + nov2 + ")"},
+		{"due=2026-11-01", "(due_at gte " + nov1 + " AND due_at lt " + nov2 + ")"},
+		{"due!=2026-11-01", "(NOT (due_at gte " + nov1 + " AND due_at lt " + nov2 + ") OR due_at null)"},
+		{"due<2026-11-01", "due_at lt " + nov1},
+		{"due<=2026-11-01", "due_at lt " + nov2},
+		{"due>2026-11-01", "due_at gte " + nov2},
+		{"due>=2026-11-01", "due_at gte " + nov1},
+		{"start:2026-11-01", "(start_at gte " + nov1 + " AND start_at lt " + nov2 + ")"},
+		{"created<2026-11-01", "created_at lt " + nov1},
+		{"updated>=2026-11-01", "updated_at gte " + nov1},
+		{"due:today", "(due_at gte 2026-10-19T00:00:00+07:00 AND due_at lt 2026-10-20T00:00:00+07:00)"},
+		{"due<yesterday", "due_at lt 2026-10-18T00:00:00+07:00"},
+		{"due>=tomorrow", "due_at gte 2026-10-20T00:00:00+07:00"},
+		{"due:none", "due_at null"},
+		{"due!=none", "due_at not_null"},
+
+		// timestamps compare exactly
+		{"due:" + ts, "due_at eq " + ts},
+		{"due=" + ts, "due_at eq " + ts},
+		{"due!=" + ts, "due_at ne " + ts},
+		{"due<" + ts, "due_at lt " + ts},
+		{"due<=" + ts, "due_at lte " + ts},
+		{"due>" + ts, "due_at gt " + ts},
+		{"due>=" + ts, "due_at gte " + ts},
+
+		// custom fields
+		{"cf.team:backend", "cf.team eq backend"},
+		{"cf.team=backend", "cf.team eq backend"},
+		{"cf.team!=backend", "NOT cf.team eq backend"},
+		{"cf.points:3", "cf.points eq 3"},
+		{"cf.points!=3", "NOT cf.points eq 3"},
+		{"cf.points<3", "cf.points lt 3"},
+		{"cf.points<=3", "cf.points lte 3"},
+		{"cf.points>3", "cf.points gt 3"},
+		{"cf.points>=3", "cf.points gte 3"},
+
+		// combinations
+		{"status:done priority>=high OR due:none", "((status eq done AND priority in [high urgent]) OR due_at null)"},
+		{"title:a title:b title:c", "(title contains a AND title contains b AND title contains c)"},
+		{"title:a OR title:b OR title:c", "(title contains a OR title contains b OR title contains c)"},
+		{"NOT (title:a OR title:b)", "NOT (title contains a OR title contains b)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := resolveFilter(testFilterQuery(true), tt.input)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			if got := describe(expr); got != tt.want {
+				t.Errorf("resolved = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterQueryRejects(t *testing.T) {
+	tests := []struct {
+		input   string
+		project bool
+		pos     int
+		message string
+	}{
+		{"owner:me", true, 1, `unknown field "owner", expected ` + filterQueryFields},
+		{"status:done AND (label:bug OR priority>=high)", true, 18, "label filters are not supported: tasks have no labels"},
+		{"status:done AND nope:1", true, 17, `unknown field "nope", expected ` + filterQueryFields},
+		{"title<a", true, 6, `operator "<" is not supported for title`},
+		{"description>=a", true, 12, `operator ">=" is not supported for description`},
+		{"status:blocked", true, 8, `unknown status "blocked"`},
+		{"status<done", true, 7, `operator "<" is not supported for status`},
+		{"category:waiting", true, 10, `unknown status category "waiting"`},
+		{"category>todo", true, 9, `operator ">" is not supported for category`},
+		{"priority:critical", true, 10, `unknown priority "critical", expected one of: none, low, medium, high, urgent`},
+		{"project:abc", true, 9, `project expects a project ID or none, got "abc"`},
+		{`project:"none"`, true, 9, `project expects a project ID or none, got "none"`},
+		{"project<3", true, 8, `operator "<" is not supported for project`},
+		{"project<none", true, 8, `operator "<" is not supported for project`},
+		{"due<none", true, 4, `operator "<" is not supported with none`},
+		{"due:soon", true, 5, `due expects YYYY-MM-DD, an RFC 3339 timestamp, today, yesterday, tomorrow or none, got "soon"`},
+		{"cf.team:x", false, 1, "custom field filters require project_id"},
+		{"cf.owner:x", true, 1, `unknown custom field "owner"`},
+		{"cf.points:many", true, 11, `invalid value for custom field filter points: "many"`},
+		{"cf.team<x", true, 8, `operator "<" is not supported for text custom field team`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := resolveFilter(testFilterQuery(tt.project), tt.input)
+			var qerr *query.Error
+			if !errors.As(err, &qerr) {
+				t.Fatalf("error = %v, want *query.Error", err)
+			}
+
+			if qerr.Pos != tt.pos || qerr.Message != tt.message {
+				t.Errorf("error = %q at %d, want %q at %d", qerr.Message, qerr.Pos, tt.message, tt.pos)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package task
+
+import (
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+)
+
+// MoveTask reorders a task among its siblings and optionally moves it under another
+// parent, which must not be one of its own subtasks.
+func (uc *TaskUseCase) MoveTask(userID, taskID int64, req entity.MoveTaskRequest) (*entity.Task, error) {
+	task, err := uc.repo.GetByID(taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	before := entity.NewTaskSnapshot(task)
+	for _, id := range []*int64{req.Before, req.After} {
+		if id != nil && *id == taskID {
+			return nil, fmt.Errorf("a task cannot be moved next to itself")
+		}
+	}
+
+	parentID := task.ParentID
+	if req.ParentID != nil {
+		parentID = nil
+		if *req.ParentID != 0 {
+			if err := uc.validateNoCircularRelationship(taskID, *req.ParentID, userID); err != nil {
+				return nil, err
+			}
+
+			parentID = req.ParentID
+		}
+	}
+
+	// a subtask moved to the top level enters its status column
+	var warning string
+	if task.ParentID != nil && parentID == nil {
+		workflow, err := uc.workflowFor(task.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+
+		moving := *task
+		moving.ParentID = nil
+		if warning, err = uc.checkWIPLimit(&moving, workflow, task.Status, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.repo.Move(taskID, userID, parentID, req.After, req.Before); err != nil {
+		return nil, err
+	}
+
+	uc.cache.InvalidateUser(userID)
+	moved, err := uc.repo.GetByID(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.recordVersion(moved, before); err != nil {
+		return nil, err
+	}
+
+	if warning != "" {
+		moved.Warnings = append(moved.Warnings, warning)
+	}
+
+	uc.recordUndo(constant.UndoActionMove, moved, before)
+	return moved, nil
+}
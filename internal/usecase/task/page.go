@@ -0,0 +1,80 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"task-management-backend/internal/domain/entity"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// GetTaskPage lists one page of root tasks. A cached list of the query is paged in memory,
+// but pages never fill the cache, since that would load every task for the first page.
+func (uc *TaskUseCase) GetTaskPage(userID int64, query entity.TaskQuery, page entity.PageRequest) (*entity.TaskPage, error) {
+	if page.Limit == 0 {
+		page.Limit = defaultPageSize
+	}
+
+	if page.Limit < 1 || page.Limit > maxPageSize {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxPageSize)
+	}
+
+	filter, err := uc.resolveQuery(userID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.IsCacheable() {
+		if cachedTasks, ok := uc.cache.Get(userID, query.Status); ok {
+			result, err := uc.pageFromCache(filter, cachedTasks, page)
+			if err != nil || result != nil {
+				return result, err
+			}
+		}
+	}
+
+	return uc.repo.GetPage(filter, page)
+}
+
+// pageFromCache cuts the page out of a cached list, which is in the same order as the
+// database query. It returns nil when the cursor's task is no longer in the list, and the
+// page is then read from the database.
+func (uc *TaskUseCase) pageFromCache(filter entity.TaskFilter, tasks []entity.Task, page entity.PageRequest) (*entity.TaskPage, error) {
+	start := 0
+	if page.Cursor != "" {
+		cursor, err := entity.DecodeTaskCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		if cursor.Sort != entity.SortSpec(filter.Sort) {
+			return nil, fmt.Errorf("cursor does not match the sort")
+		}
+
+		i := slices.IndexFunc(tasks, func(task entity.Task) bool { return task.ID == cursor.ID })
+		if i < 0 {
+			return nil, nil
+		}
+
+		start = i + 1
+	}
+
+	end := min(start+page.Limit, len(tasks))
+	result := &entity.TaskPage{Tasks: append([]entity.Task{}, tasks[start:end]...)}
+	if end < len(tasks) {
+		var err error
+		if result.NextCursor, err = uc.repo.PageCursor(filter.Sort, tasks[end-1].ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if page.WithTotal {
+		total := len(tasks)
+		result.Total = &total
+	}
+
+	return result, nil
+}
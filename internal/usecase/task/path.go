@@ -0,0 +1,63 @@
+package task
+
+import (
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+)
+
+// GetAncestors returns the chain from the task's parent up to its root, root first.
+func (uc *TaskUseCase) GetAncestors(userID, taskID int64) ([]entity.TaskPathItem, error) {
+	ancestors, err := uc.repo.GetAncestors(taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	return ancestors, nil
+}
+
+// WithPaths returns copies of the task trees with Path set on every task. Only listed tasks
+// that are not roots need a query; subtasks extend the path of their parent. The given trees
+// are left unchanged, since they may be cached.
+func (uc *TaskUseCase) WithPaths(tasks []entity.Task) ([]entity.Task, error) {
+	var ids []int64
+	for _, task := range tasks {
+		if task.ParentID != nil {
+			ids = append(ids, task.ID)
+		}
+	}
+
+	paths := map[int64][]entity.TaskPathItem{}
+	if len(ids) > 0 {
+		var err error
+		if paths, err = uc.repo.GetPaths(ids); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]entity.Task, len(tasks))
+	for i, task := range tasks {
+		result[i] = withPath(task, paths[task.ID])
+	}
+
+	return result, nil
+}
+
+func withPath(task entity.Task, path []entity.TaskPathItem) entity.Task {
+	task.Path = path
+	if len(task.SubTasks) == 0 {
+		return task
+	}
+
+	// a new slice for the children, so siblings do not share one backing array
+	childPath := make([]entity.TaskPathItem, len(path), len(path)+1)
+	copy(childPath, path)
+	childPath = append(childPath, entity.TaskPathItem{ID: task.ID, Title: task.Title})
+
+	subTasks := make([]entity.Task, len(task.SubTasks))
+	for i, subTask := range task.SubTasks {
+		subTasks[i] = withPath(subTask, childPath)
+	}
+
+	task.SubTasks = subTasks
+	return task
+}
@@ -0,0 +1,189 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"time"
+)
+
+func validateSchedule(startAt, dueAt *time.Time) error {
+	if startAt != nil && dueAt != nil && startAt.After(*dueAt) {
+		return fmt.Errorf("start_at cannot be after due_at")
+	}
+
+	return nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Equal(*b)
+}
+
+func (uc *TaskUseCase) buildFilter(userID int64, query entity.TaskQuery) (entity.TaskFilter, error) {
+	fields, err := uc.customFieldsFor(query.ProjectID)
+	if err != nil {
+		return entity.TaskFilter{}, err
+	}
+
+	sort, err := parseSort(query.Sort, fields)
+	if err != nil {
+		return entity.TaskFilter{}, err
+	}
+
+	if len(query.CustomFields) > 0 && query.ProjectID == nil {
+		return entity.TaskFilter{}, fmt.Errorf("custom field filters require project_id")
+	}
+
+	customFields, err := customFieldFilters(fields, query.CustomFields)
+	if err != nil {
+		return entity.TaskFilter{}, err
+	}
+
+	filter := entity.TaskFilter{
+		UserID:          userID,
+		ProjectID:       query.ProjectID,
+		SprintID:        query.SprintID,
+		IncludeArchived: query.IncludeArchived,
+		Status:          query.Status,
+		Sort:            sort,
+		CustomFields:    customFields,
+	}
+
+	if !query.Overdue && !query.DueToday && query.DueBefore == "" && query.DueAfter == "" {
+		return filter, nil
+	}
+
+	loc := entity.UserLocation(uc.userRepo.GetByID(userID))
+	now := time.Now().In(loc)
+
+	if query.Overdue {
+		filter.OverdueAt = &now
+	}
+
+	if query.DueToday {
+		start := entity.StartOfDay(now)
+		end := start.AddDate(0, 0, 1)
+		narrowDueRange(&filter, &start, &end)
+	}
+
+	if query.DueBefore != "" {
+		before, _, err := parseDateParam(query.DueBefore, loc)
+		if err != nil {
+			return filter, fmt.Errorf("invalid due_before: %w", err)
+		}
+
+		narrowDueRange(&filter, nil, &before)
+	}
+
+	if query.DueAfter != "" {
+		after, dateOnly, err := parseDateParam(query.DueAfter, loc)
+		if err != nil {
+			return filter, fmt.Errorf("invalid due_after: %w", err)
+		}
+
+		// a bare date means "after that day", so the range starts the next midnight
+		if dateOnly {
+			after = after.AddDate(0, 0, 1)
+		} else {
+			after = after.Add(time.Nanosecond)
+		}
+
+		narrowDueRange(&filter, &after, nil)
+	}
+
+	return filter, nil
+}
+
+// narrowDueRange intersects the filter's due range with [from, until).
+func narrowDueRange(filter *entity.TaskFilter, from, until *time.Time) {
+	if from != nil && (filter.DueFrom == nil || from.After(*filter.DueFrom)) {
+		filter.DueFrom = from
+	}
+
+	if until != nil && (filter.DueUntil == nil || until.Before(*filter.DueUntil)) {
+		filter.DueUntil = until
+	}
+}
+
+// parseDateParam accepts either an RFC 3339 timestamp or a YYYY-MM-DD date, which is
+// interpreted as midnight in loc. The boolean reports whether a bare date was given.
+func parseDateParam(value string, loc *time.Location) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation(entity.DateLayout, value, loc); err == nil {
+		return t, true, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("expected YYYY-MM-DD or RFC 3339 timestamp, got %q", value)
+	}
+
+	return t, false, nil
+}
+
+// parseSort turns "priority,-due_at,created_at" into validated sort fields. Keys of the
+// form "cf.<key>" sort by one of the given custom fields.
+func parseSort(value string, customFields []entity.CustomField) ([]entity.SortField, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var fields []entity.SortField
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(strings.TrimPrefix(part, "-"), "+")
+		var customField *entity.CustomField
+		if key, ok := strings.CutPrefix(name, customFieldSortPrefix); ok {
+			field, found := findCustomField(customFields, key)
+			if !found {
+				return nil, fmt.Errorf("invalid sort field: %q (custom fields require project_id)", part)
+			}
+
+			customField = &field
+		} else if !entity.SortableTaskFields[name] {
+			return nil, fmt.Errorf("invalid sort field: %q", part)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate sort field: %s", name)
+		}
+
+		seen[name] = true
+		fields = append(fields, entity.SortField{Field: name, Desc: desc, CustomField: customField})
+	}
+
+	return fields, nil
+}
+
+// setEstimates applies estimates given in seconds; 0 clears an estimate.
+func setEstimates(task *entity.Task, original, remaining *int64) error {
+	set := func(target **int64, value *int64, name string) error {
+		if value == nil {
+			return nil
+		}
+
+		if *value < 0 {
+			return fmt.Errorf("%s cannot be negative", name)
+		}
+
+		if *value == 0 {
+			*target = nil
+			return nil
+		}
+
+		v := *value
+		*target = &v
+		return nil
+	}
+
+	if err := set(&task.OriginalEstimate, original, "original_estimate_seconds"); err != nil {
+		return err
+	}
+
+	return set(&task.RemainingEstimate, remaining, "remaining_estimate_seconds")
+}
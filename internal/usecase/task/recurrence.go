@@ -0,0 +1,297 @@
+package task
+
+import (
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/rrule"
+	"time"
+)
+
+const (
+	defaultPreviewCount = 5
+	maxPreviewCount     = 50
+)
+
+// recurrenceAnchor is the date a recurrence is computed from: the due date, or the start
+// date for tasks without one.
+func recurrenceAnchor(task *entity.Task) *time.Time {
+	if task.DueAt != nil {
+		return task.DueAt
+	}
+
+	return task.StartAt
+}
+
+// setRecurrence validates and applies a recurrence rule. An empty rule removes it.
+func (uc *TaskUseCase) setRecurrence(task *entity.Task, rule, timezone string) error {
+	if rule == "" {
+		task.RRule = ""
+		task.Timezone = ""
+		task.RecurrenceStart = nil
+		return nil
+	}
+
+	if _, err := rrule.Parse(rule); err != nil {
+		return fmt.Errorf("invalid rrule: %w", err)
+	}
+
+	anchor := recurrenceAnchor(task)
+	if anchor == nil {
+		return fmt.Errorf("recurring tasks require a due_at or start_at")
+	}
+
+	if timezone == "" {
+		timezone = task.Timezone
+	}
+
+	if timezone == "" {
+		timezone = entity.UserLocation(uc.userRepo.GetByID(task.UserID)).String()
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("invalid timezone: %s", timezone)
+	}
+
+	start := *anchor
+	task.RRule = rule
+	task.Timezone = timezone
+	task.RecurrenceStart = &start
+	return nil
+}
+
+func (uc *TaskUseCase) setTimezone(task *entity.Task, timezone string) error {
+	if task.RRule == "" {
+		return fmt.Errorf("timezone can only be set on recurring tasks")
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("invalid timezone: %s", timezone)
+	}
+
+	task.Timezone = timezone
+	return nil
+}
+
+// spawnNextOccurrence creates the next occurrence of a completed recurring task once. A task
+// that is reopened and completed again, or whose completion is redone after an undo that
+// kept the occurrence, is linked to an occurrence already and spawns nothing.
+func (uc *TaskUseCase) spawnNextOccurrence(task *entity.Task, workflow entity.Workflow) (*entity.Task, error) {
+	existing, err := uc.repo.GetNextOccurrenceID(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, nil
+	}
+
+	next, err := uc.createNextOccurrence(task, workflow)
+	if err != nil || next == nil {
+		return next, err
+	}
+
+	if err := uc.repo.SetNextOccurrenceID(task.ID, &next.ID); err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
+
+// revertNextOccurrence undoes the occurrence spawned by the completion an undo reverts. An
+// occurrence that has not been edited since goes to the trash and the link is cleared, so
+// completing the task again spawns a fresh one. An edited occurrence is kept along with
+// the link.
+func (uc *TaskUseCase) revertNextOccurrence(task *entity.Task) error {
+	nextID, err := uc.repo.GetNextOccurrenceID(task.ID)
+	if err != nil || nextID == nil {
+		return err
+	}
+
+	next, err := uc.repo.GetByID(*nextID, task.UserID)
+	if err != nil {
+		// already in the trash
+		return nil
+	}
+
+	if !next.UpdatedAt.Equal(next.CreatedAt) {
+		return nil
+	}
+
+	if err := uc.repo.Delete(next.ID, task.UserID); err != nil {
+		return fmt.Errorf("failed to delete next occurrence: %w", err)
+	}
+
+	return uc.repo.SetNextOccurrenceID(task.ID, nil)
+}
+
+// createNextOccurrence creates the occurrence that follows a completed recurring task.
+// It returns nil when the rule has no further occurrences.
+func (uc *TaskUseCase) createNextOccurrence(task *entity.Task, workflow entity.Workflow) (*entity.Task, error) {
+	rule, err := rrule.Parse(task.RRule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rrule: %w", err)
+	}
+
+	loc, err := time.LoadLocation(task.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	anchor := recurrenceAnchor(task)
+	if anchor == nil {
+		return nil, nil
+	}
+
+	current := anchor.In(loc)
+	dtstart := current
+	if task.RecurrenceStart != nil {
+		dtstart = task.RecurrenceStart.In(loc)
+	}
+
+	next, ok := rule.Next(dtstart, current)
+	if !ok {
+		return nil, nil
+	}
+
+	shift := next.Sub(current)
+	occurrence := &entity.Task{
+		UserID:          task.UserID,
+		ParentID:        task.ParentID,
+		ProjectID:       task.ProjectID,
+		Title:           task.Title,
+		Description:     task.Description,
+		Status:          workflow.Initial(),
+		Priority:        task.Priority,
+		StartAt:         shiftTime(task.StartAt, shift),
+		DueAt:           shiftTime(task.DueAt, shift),
+		RRule:           task.RRule,
+		Timezone:        task.Timezone,
+		RecurrenceStart: task.RecurrenceStart,
+		CopySubTasks:    task.CopySubTasks,
+		// each occurrence starts with the full estimate
+		OriginalEstimate:  task.OriginalEstimate,
+		RemainingEstimate: task.OriginalEstimate,
+		SubTasks:          make([]entity.Task, 0),
+	}
+
+	// keep the anchor on the rule's wall-clock time even when the shift crosses a DST change
+	if occurrence.DueAt != nil {
+		occurrence.DueAt = &next
+	} else {
+		occurrence.StartAt = &next
+	}
+
+	if err := uc.repo.Create(occurrence); err != nil {
+		return nil, fmt.Errorf("failed to create next occurrence: %w", err)
+	}
+
+	if err := uc.recordStatusChange(occurrence, "", ""); err != nil {
+		return nil, err
+	}
+
+	if err := uc.customFieldRepo.CopyTaskValues(task.ID, occurrence.ID); err != nil {
+		return nil, err
+	}
+
+	occurrence.CustomFields = task.CustomFields
+
+	if task.CopySubTasks {
+		subTasks, err := uc.copySubTasks(task.SubTasks, occurrence, shift)
+		if err != nil {
+			return nil, err
+		}
+
+		occurrence.SubTasks = subTasks
+	}
+
+	return occurrence, nil
+}
+
+// copySubTasks recreates a subtask tree under parent with the parent's initial status and
+// dates shifted.
+func (uc *TaskUseCase) copySubTasks(subTasks []entity.Task, parent *entity.Task, shift time.Duration) ([]entity.Task, error) {
+	copies := make([]entity.Task, 0, len(subTasks))
+	for _, sub := range subTasks {
+		pid := parent.ID
+		copied := entity.Task{
+			UserID:            sub.UserID,
+			ParentID:          &pid,
+			ProjectID:         parent.ProjectID,
+			Title:             sub.Title,
+			Description:       sub.Description,
+			Status:            parent.Status,
+			Priority:          sub.Priority,
+			StartAt:           shiftTime(sub.StartAt, shift),
+			DueAt:             shiftTime(sub.DueAt, shift),
+			OriginalEstimate:  sub.OriginalEstimate,
+			RemainingEstimate: sub.OriginalEstimate,
+			Rank:              sub.Rank,
+		}
+
+		if err := uc.repo.Create(&copied); err != nil {
+			return nil, fmt.Errorf("failed to copy subtask: %w", err)
+		}
+
+		if err := uc.recordStatusChange(&copied, "", ""); err != nil {
+			return nil, err
+		}
+
+		if err := uc.customFieldRepo.CopyTaskValues(sub.ID, copied.ID); err != nil {
+			return nil, err
+		}
+
+		copied.CustomFields = sub.CustomFields
+
+		children, err := uc.copySubTasks(sub.SubTasks, &copied, shift)
+		if err != nil {
+			return nil, err
+		}
+
+		copied.SubTasks = children
+		copies = append(copies, copied)
+	}
+
+	return copies, nil
+}
+
+func shiftTime(t *time.Time, d time.Duration) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	shifted := t.Add(d)
+	return &shifted
+}
+
+// PreviewRecurrence lists upcoming occurrences of a rule without creating any tasks.
+func (uc *TaskUseCase) PreviewRecurrence(userID int64, query entity.RecurrencePreviewQuery) ([]time.Time, error) {
+	rule, err := rrule.Parse(query.RRule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rrule: %w", err)
+	}
+
+	loc := entity.UserLocation(uc.userRepo.GetByID(userID))
+	if query.Timezone != "" {
+		loc, err = time.LoadLocation(query.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone: %s", query.Timezone)
+		}
+	}
+
+	start, _, err := parseDateParam(query.Start, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start: %w", err)
+	}
+
+	count := query.Count
+	if count <= 0 {
+		count = defaultPreviewCount
+	}
+
+	if count > maxPreviewCount {
+		count = maxPreviewCount
+	}
+
+	start = start.In(loc)
+	return rule.Occurrences(start, start.Add(-time.Nanosecond), count), nil
+}
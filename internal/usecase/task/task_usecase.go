@@ -5,38 +5,63 @@ import (
 	"task-management-backend/internal/domain/entity"
 	"task-management-backend/internal/domain/ports"
 	"task-management-backend/pkg/constant"
+	"time"
 )
 
+// Options toggles optional task rules.
+type Options struct {
+	// EnforceBlockers rejects moving a task to in progress or done while it has open blockers.
+	EnforceBlockers bool
+	// UndoWindow is how long mutations can be undone; 0 disables undo.
+	UndoWindow time.Duration
+}
+
 type TaskUseCase struct {
-	repo  ports.TaskRepository
-	cache ports.TaskCache
+	repo            ports.TaskRepository
+	userRepo        ports.UserRepository
+	reminderRepo    ports.ReminderRepository
+	dependencyRepo  ports.DependencyRepository
+	projectRepo     ports.ProjectRepository
+	customFieldRepo ports.CustomFieldRepository
+	undoRepo        ports.UndoRepository
+	versionRepo     ports.TaskVersionRepository
+	cache           ports.TaskCache
+	opts            Options
 }
 
-func NewTaskUseCase(repo ports.TaskRepository, cache ports.TaskCache) *TaskUseCase {
+func NewTaskUseCase(repo ports.TaskRepository, userRepo ports.UserRepository, reminderRepo ports.ReminderRepository, dependencyRepo ports.DependencyRepository, projectRepo ports.ProjectRepository, customFieldRepo ports.CustomFieldRepository, undoRepo ports.UndoRepository, versionRepo ports.TaskVersionRepository, cache ports.TaskCache, opts Options) *TaskUseCase {
 	return &TaskUseCase{
-		repo:  repo,
-		cache: cache,
+		repo:            repo,
+		userRepo:        userRepo,
+		reminderRepo:    reminderRepo,
+		dependencyRepo:  dependencyRepo,
+		projectRepo:     projectRepo,
+		customFieldRepo: customFieldRepo,
+		undoRepo:        undoRepo,
+		versionRepo:     versionRepo,
+		cache:           cache,
+		opts:            opts,
 	}
 }
 
-func (uc *TaskUseCase) GetTasks(userID int64, status constant.TaskStatus) ([]entity.Task, error) {
-	// check to cache first before query to database
-	if cachedTasks, ok := uc.cache.Get(userID, status); ok {
-		return cachedTasks, nil
+func (uc *TaskUseCase) GetTasks(userID int64, query entity.TaskQuery) ([]entity.Task, error) {
+	status := query.Status
+	filter, err := uc.resolveQuery(userID, query)
+	if err != nil {
+		return nil, err
 	}
 
-	var tasks []entity.Task
-	var err error
+	// date filters depend on the current time, so only plain status lists are cached
+	if !filter.IsCacheable() {
+		return uc.repo.GetByFilter(filter)
+	}
 
-	switch status {
-	case constant.TaskStatusDefault, constant.TaskStatusAll:
-		tasks, err = uc.repo.GetAllByUserID(userID)
-	case constant.TaskStatusTodo, constant.TaskStatusInProgress, constant.TaskStatusDone:
-		tasks, err = uc.repo.GetByUserIDAndStatus(userID, status)
-	default:
-		return nil, fmt.Errorf("invalid status filter: %s", status)
+	// check to cache first before query to database
+	if cachedTasks, ok := uc.cache.Get(userID, status); ok {
+		return cachedTasks, nil
 	}
 
+	tasks, err := uc.repo.GetByFilter(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -45,39 +70,149 @@ func (uc *TaskUseCase) GetTasks(userID int64, status constant.TaskStatus) ([]ent
 	return tasks, nil
 }
 
-func (uc *TaskUseCase) CreateTask(userID int64, title, description string, parentID *int64) (*entity.Task, error) {
-	if title == "" {
+// ValidateQuery checks a list query without running it.
+func (uc *TaskUseCase) ValidateQuery(userID int64, query entity.TaskQuery) error {
+	_, err := uc.resolveQuery(userID, query)
+	return err
+}
+
+func (uc *TaskUseCase) resolveQuery(userID int64, query entity.TaskQuery) (entity.TaskFilter, error) {
+	workflow, err := uc.listWorkflow(userID, query.ProjectID)
+	if err != nil {
+		return entity.TaskFilter{}, err
+	}
+
+	filter, err := uc.buildFilter(userID, query)
+	if err != nil {
+		return entity.TaskFilter{}, err
+	}
+
+	if err := applyWorkflowFilter(&filter, query, workflow); err != nil {
+		return entity.TaskFilter{}, err
+	}
+
+	if query.Filter != "" {
+		if filter.Expr, err = uc.parseFilterQuery(userID, query, workflow); err != nil {
+			return entity.TaskFilter{}, err
+		}
+	}
+
+	return filter, nil
+}
+
+func (uc *TaskUseCase) CreateTask(userID int64, req entity.CreateTaskRequest) (*entity.Task, error) {
+	if req.Title == "" {
 		return nil, fmt.Errorf("task title cannot be empty")
 	}
 
+	if err := validateSchedule(req.StartAt, req.DueAt); err != nil {
+		return nil, err
+	}
+
+	projectID := req.ProjectID
+	if req.ParentID != nil {
+		parent, err := uc.repo.GetByID(*req.ParentID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("parent task not found: %w", err)
+		}
+
+		if projectID != nil && !sameProject(projectID, parent.ProjectID) {
+			return nil, fmt.Errorf("subtasks must belong to the parent's project")
+		}
+
+		projectID = parent.ProjectID
+	} else if projectID != nil {
+		if _, err := uc.projectRepo.GetByID(*projectID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	workflow, err := uc.workflowFor(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	priority := constant.TaskPriority(req.Priority)
+	if priority == "" {
+		priority = constant.TaskPriorityNone
+	}
+
+	if !priority.IsValid() {
+		return nil, fmt.Errorf("invalid priority: %s", req.Priority)
+	}
+
 	task := &entity.Task{
 		UserID:      userID,
-		ParentID:    parentID,
-		Title:       title,
-		Description: description,
-		Status:      constant.TaskStatusTodo,
+		ParentID:    req.ParentID,
+		ProjectID:   projectID,
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      workflow.Initial(),
+		Priority:    priority,
+		StartAt:     req.StartAt,
+		DueAt:       req.DueAt,
 		SubTasks:    make([]entity.Task, 0),
 	}
 
+	if req.RRule != "" {
+		if err := uc.setRecurrence(task, req.RRule, req.Timezone); err != nil {
+			return nil, err
+		}
+
+		task.CopySubTasks = req.CopySubTasks
+	}
+
+	if err := setEstimates(task, req.OriginalEstimate, req.RemainingEstimate); err != nil {
+		return nil, err
+	}
+
+	if task.RemainingEstimate == nil {
+		task.RemainingEstimate = task.OriginalEstimate
+	}
+
+	fieldValues, customFields, err := uc.resolveCustomFields(projectID, req.CustomFields, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := uc.repo.Create(task); err != nil {
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 
+	if err := uc.customFieldRepo.SetTaskValues(task.ID, fieldValues); err != nil {
+		return nil, err
+	}
+
+	task.CustomFields = customFields
+
+	if err := uc.recordStatusChange(task, "", ""); err != nil {
+		return nil, err
+	}
+
+	if err := uc.recordVersion(task, nil); err != nil {
+		return nil, err
+	}
+
 	uc.cache.Invalidate(userID, []constant.TaskStatus{
-		constant.TaskStatusTodo,
+		task.Status,
 		constant.TaskStatusAll,
 		constant.TaskStatusDefault,
 	})
+	uc.recordUndo(constant.UndoActionCreate, task, nil)
 	return task, nil
 }
 
-func (uc *TaskUseCase) UpdateTask(userID, taskID int64, title, description *string, status *constant.TaskStatus, parentID *int64) (*entity.Task, error) {
+func (uc *TaskUseCase) UpdateTask(userID, taskID int64, req entity.UpdateTaskRequest) (*entity.Task, error) {
 	task, err := uc.repo.GetByID(taskID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("task not found: %w", err)
 	}
 
+	before := entity.NewTaskSnapshot(task)
+
 	oldStatus := task.Status
+	oldDueAt := task.DueAt
+	title, description, parentID := req.Title, req.Description, req.ParentID
 
 	if title != nil {
 		if *title == "" {
@@ -91,43 +226,180 @@ func (uc *TaskUseCase) UpdateTask(userID, taskID int64, title, description *stri
 		task.Description = *description
 	}
 
-	if status != nil {
-		task.Status = *status
+	workflow, err := uc.workflowFor(task.ProjectID)
+	if err != nil {
+		return nil, err
 	}
 
+	if req.Priority != nil {
+		priority := constant.TaskPriority(*req.Priority)
+		if !priority.IsValid() {
+			return nil, fmt.Errorf("invalid priority: %s", *req.Priority)
+		}
+
+		task.Priority = priority
+	}
+
+	if req.ClearStartAt {
+		task.StartAt = nil
+	} else if req.StartAt != nil {
+		task.StartAt = req.StartAt
+	}
+
+	if req.ClearDueAt {
+		task.DueAt = nil
+	} else if req.DueAt != nil {
+		task.DueAt = req.DueAt
+	}
+
+	if err := validateSchedule(task.StartAt, task.DueAt); err != nil {
+		return nil, err
+	}
+
+	if err := setEstimates(task, req.OriginalEstimate, req.RemainingEstimate); err != nil {
+		return nil, err
+	}
+
+	// re-sending the current rule must not restart the series and its COUNT
+	if req.RRule != nil && *req.RRule != task.RRule {
+		timezone := ""
+		if req.Timezone != nil {
+			timezone = *req.Timezone
+		}
+
+		if err := uc.setRecurrence(task, *req.RRule, timezone); err != nil {
+			return nil, err
+		}
+	} else if req.Timezone != nil && *req.Timezone != task.Timezone {
+		if err := uc.setTimezone(task, *req.Timezone); err != nil {
+			return nil, err
+		}
+	}
+
+	if task.RRule != "" && recurrenceAnchor(task) == nil {
+		return nil, fmt.Errorf("recurring tasks require a due_at or start_at")
+	}
+
+	if req.CopySubTasks != nil {
+		task.CopySubTasks = *req.CopySubTasks
+	}
+
+	var fieldValues map[int64][]entity.FieldValue
+	if len(req.CustomFields) > 0 {
+		current := task.CustomFields
+		if current == nil {
+			current = map[string]any{}
+		}
+
+		fieldValues, task.CustomFields, err = uc.resolveCustomFields(task.ProjectID, req.CustomFields, current)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// guards such as required fields see the task with the other changes applied
+	reason := ""
+	if req.StatusReason != nil {
+		reason = *req.StatusReason
+	}
+
+	wasTopLevel := task.ParentID == nil
 	if parentID != nil {
-		// validate that task is not creating a circular relationship
+		// 0 makes the task top-level
+		task.ParentID = nil
 		if *parentID != 0 {
+			// validate that task is not creating a circular relationship
 			if err := uc.validateNoCircularRelationship(taskID, *parentID, userID); err != nil {
 				return nil, err
 			}
+
+			task.ParentID = parentID
+		}
+	}
+
+	if req.Status != nil {
+		status := constant.TaskStatus(*req.Status)
+		if err := uc.checkTransition(task, workflow, status, reason); err != nil {
+			return nil, err
 		}
 
-		task.ParentID = parentID
+		task.Status = status
+	}
+
+	// WIP limits count top-level tasks, so they apply to the final parent and status
+	warning, err := uc.checkWIPLimit(task, workflow, oldStatus, wasTopLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	if warning != "" {
+		task.Warnings = append(task.Warnings, warning)
 	}
 
 	if err := uc.repo.Update(task); err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
+	if err := uc.customFieldRepo.SetTaskValues(task.ID, fieldValues); err != nil {
+		return nil, err
+	}
+
+	if task.Status != oldStatus {
+		if err := uc.recordStatusChange(task, oldStatus, reason); err != nil {
+			return nil, err
+		}
+	}
+
+	if !sameTime(oldDueAt, task.DueAt) {
+		if err := uc.reminderRepo.RescheduleForTask(task.ID, task.DueAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.recordVersion(task, before); err != nil {
+		return nil, err
+	}
+
 	statusesToInvalidate := []constant.TaskStatus{
 		oldStatus,
 		task.Status,
 		constant.TaskStatusAll,
 		constant.TaskStatusDefault,
 	}
+
+	if !workflow.IsDone(oldStatus) && workflow.IsDone(task.Status) && task.RRule != "" {
+		next, err := uc.spawnNextOccurrence(task, workflow)
+		if err != nil {
+			return nil, err
+		}
+
+		task.NextOccurrence = next
+		statusesToInvalidate = append(statusesToInvalidate, workflow.Initial())
+	}
+
 	uc.cache.Invalidate(userID, statusesToInvalidate)
+	uc.recordUndo(constant.UndoActionUpdate, task, before)
 	return task, nil
 }
 
-func (uc *TaskUseCase) DeleteTask(userID, taskID int64) error {
+// DeleteTask moves the task to the trash and returns it with its undo token.
+func (uc *TaskUseCase) DeleteTask(userID, taskID int64) (*entity.Task, error) {
 	task, err := uc.repo.GetByID(taskID, userID)
 	if err != nil {
-		return fmt.Errorf("task not found: %w", err)
+		return nil, fmt.Errorf("task not found: %w", err)
 	}
 
 	if err := uc.repo.Delete(taskID, userID); err != nil {
-		return fmt.Errorf("failed to delete task: %w", err)
+		return nil, fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	uc.recordUndo(constant.UndoActionDelete, task, nil)
+
+	// a subtask is part of its root's tree, and other tasks may list the deleted ones in
+	// blocked_by or blocking
+	if task.ParentID != nil || treeHasDependencies(*task) {
+		uc.invalidateAll(userID)
+		return task, nil
 	}
 
 	uc.cache.Invalidate(userID, []constant.TaskStatus{
@@ -135,7 +407,21 @@ func (uc *TaskUseCase) DeleteTask(userID, taskID int64) error {
 		constant.TaskStatusAll,
 		constant.TaskStatusDefault,
 	})
-	return nil
+	return task, nil
+}
+
+func treeHasDependencies(task entity.Task) bool {
+	if len(task.BlockedBy) > 0 || len(task.Blocking) > 0 {
+		return true
+	}
+
+	for _, sub := range task.SubTasks {
+		if treeHasDependencies(sub) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (uc *TaskUseCase) GetTaskByID(userID, taskID int64) (*entity.Task, error) {
@@ -147,83 +433,58 @@ func (uc *TaskUseCase) GetTaskByID(userID, taskID int64) (*entity.Task, error) {
 	return task, nil
 }
 
+// GetTask returns the task with its subtasks down to depth levels, or the whole tree for a
+// negative depth.
+func (uc *TaskUseCase) GetTask(userID, taskID int64, depth int) (*entity.Task, error) {
+	if depth > entity.MaxTreeDepth {
+		return nil, fmt.Errorf("depth must be at most %d", entity.MaxTreeDepth)
+	}
+
+	task, err := uc.repo.GetTree(taskID, userID, depth)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	return task, nil
+}
+
 func (uc *TaskUseCase) validateNoCircularRelationship(taskID, newParentID, userID int64) error {
 	// check if newParentID is the same as taskID
 	if newParentID == taskID {
 		return fmt.Errorf("a task cannot be its own parent")
 	}
 
-	allTasks, err := uc.repo.GetAllByUserID(userID)
+	task, err := uc.repo.GetByID(taskID, userID)
 	if err != nil {
-		return fmt.Errorf("failed to validate relationship: %w", err)
+		return fmt.Errorf("task not found: %w", err)
 	}
 
-	taskMap := make(map[int64]*entity.Task)
-	for i := range allTasks {
-		taskMap[allTasks[i].ID] = &allTasks[i]
+	parent, err := uc.repo.GetByID(newParentID, userID)
+	if err != nil {
+		return fmt.Errorf("parent task not found: %w", err)
 	}
 
-	// check if newParentID is a descendant of taskID
-	if uc.isDescendant(taskID, newParentID, taskMap) {
-		return fmt.Errorf("cannot set parent: circular relationship detected")
+	if !sameProject(task.ProjectID, parent.ProjectID) {
+		return fmt.Errorf("cannot set parent: parent belongs to a different project")
 	}
 
-	return nil
-}
-
-func (uc *TaskUseCase) isDescendant(ancestorID, potentialDescendantID int64, taskMap map[int64]*entity.Task) bool {
-	current := taskMap[potentialDescendantID]
-	visited := make(map[int64]bool)
-
-	for current != nil {
-		if visited[current.ID] {
-			return false
-		}
-
-		visited[current.ID] = true
-		if current.ParentID != nil && *current.ParentID == ancestorID {
-			return true
-		}
-
-		if current.ParentID != nil {
-			current = taskMap[*current.ParentID]
-		} else {
-			current = nil
-		}
+	// check if newParentID is a descendant of taskID
+	descendant, err := uc.repo.IsAncestor(taskID, newParentID)
+	if err != nil {
+		return fmt.Errorf("failed to validate relationship: %w", err)
 	}
 
-	for _, task := range taskMap {
-		if task.ParentID != nil && *task.ParentID == ancestorID {
-			if task.ID == potentialDescendantID {
-				return true
-			}
-
-			if uc.isDescendantRecursive(task.ID, potentialDescendantID, taskMap, visited) {
-				return true
-			}
-		}
+	if descendant {
+		return fmt.Errorf("cannot set parent: circular relationship detected")
 	}
 
-	return false
+	return nil
 }
 
-func (uc *TaskUseCase) isDescendantRecursive(parentID, targetID int64, taskMap map[int64]*entity.Task, visited map[int64]bool) bool {
-	if visited[parentID] {
-		return false
+func sameProject(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
 	}
 
-	visited[parentID] = true
-	for _, task := range taskMap {
-		if task.ParentID != nil && *task.ParentID == parentID {
-			if task.ID == targetID {
-				return true
-			}
-
-			if uc.isDescendantRecursive(task.ID, targetID, taskMap, visited) {
-				return true
-			}
-		}
-	}
-
-	return false
+	return *a == *b
 }
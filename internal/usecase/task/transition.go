@@ -0,0 +1,104 @@
+package task
+
+import (
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/statemachine"
+	"task-management-backend/pkg/constant"
+)
+
+// checkTransition validates a status change against the project's transition rules.
+func (uc *TaskUseCase) checkTransition(task *entity.Task, workflow entity.Workflow, to constant.TaskStatus, reason string) error {
+	machine, err := uc.stateMachine(task, workflow)
+	if err != nil {
+		return err
+	}
+
+	// the optional blocker rule applies to every move out of the todo category
+	var extra []constant.TransitionGuard
+	if category, _ := workflow.Category(to); uc.opts.EnforceBlockers && category != constant.StatusCategoryTodo {
+		extra = append(extra, constant.GuardNoOpenBlockers)
+	}
+
+	return machine.Check(task, to, reason, uc.dependencyRepo, extra...)
+}
+
+func (uc *TaskUseCase) stateMachine(task *entity.Task, workflow entity.Workflow) (*statemachine.Machine, error) {
+	var transitions []entity.WorkflowTransition
+	if task.ProjectID != nil {
+		var err error
+		transitions, err = uc.projectRepo.GetTransitions(*task.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return statemachine.New(workflow, transitions), nil
+}
+
+// checkWIPLimit checks the WIP limit of the column a top-level task enters, by changing
+// status or by becoming top-level. The task must carry its final parent and status; from
+// and wasTopLevel describe it before the change. It returns a warning when the move fills
+// the status past a limit that is not enforced.
+func (uc *TaskUseCase) checkWIPLimit(task *entity.Task, workflow entity.Workflow, from constant.TaskStatus, wasTopLevel bool) (string, error) {
+	if task.ProjectID == nil || task.ParentID != nil || (task.Status == from && wasTopLevel) {
+		return "", nil
+	}
+
+	to := task.Status
+	status, _ := workflow.Status(to)
+	if status.WIPLimit == nil {
+		return "", nil
+	}
+
+	count, err := uc.repo.CountTopLevelInStatus(*task.ProjectID, to, task.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if count < *status.WIPLimit {
+		return "", nil
+	}
+
+	message := fmt.Sprintf("status %q is at its WIP limit of %d", to, *status.WIPLimit)
+	if !status.EnforceWIPLimit {
+		return message, nil
+	}
+
+	machine, err := uc.stateMachine(task, workflow)
+	if err != nil {
+		return "", err
+	}
+
+	blocked := fmt.Sprintf("transition from %q to %q is blocked", from, to)
+	if from == to {
+		blocked = fmt.Sprintf("moving the task into %q is blocked", to)
+	}
+
+	return "", &entity.TransitionError{
+		From:         from,
+		To:           to,
+		Message:      blocked,
+		Allowed:      machine.Allowed(from),
+		FailedGuards: []string{message},
+	}
+}
+
+func (uc *TaskUseCase) recordStatusChange(task *entity.Task, from constant.TaskStatus, reason string) error {
+	return uc.repo.AddStatusChange(&entity.StatusChange{
+		TaskID:     task.ID,
+		UserID:     task.UserID,
+		ProjectID:  task.ProjectID,
+		FromStatus: from,
+		ToStatus:   task.Status,
+		Reason:     reason,
+	})
+}
+
+func (uc *TaskUseCase) GetStatusHistory(userID, taskID int64) ([]entity.StatusChange, error) {
+	if _, err := uc.repo.GetByID(taskID, userID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	return uc.repo.GetStatusHistory(taskID)
+}
@@ -0,0 +1,75 @@
+package task
+
+import (
+	"context"
+	"log"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// GetTrash lists the user's deleted tasks. Subtasks deleted together with their parent are
+// not listed separately; they come back when the parent is restored.
+func (uc *TaskUseCase) GetTrash(userID int64) ([]entity.Task, error) {
+	return uc.repo.GetTrash(userID)
+}
+
+// RestoreTask brings a deleted task back with the subtree that was deleted with it.
+func (uc *TaskUseCase) RestoreTask(userID, taskID int64) (*entity.Task, error) {
+	if err := uc.repo.Restore(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	// the restored tree may show up under a parent and in other tasks' dependencies
+	uc.invalidateAll(userID)
+	return uc.withUndo(constant.UndoActionRestore, taskID, userID)
+}
+
+// PurgeTask permanently deletes a task from the trash.
+func (uc *TaskUseCase) PurgeTask(userID, taskID int64) error {
+	return uc.repo.Purge(taskID, userID)
+}
+
+// TrashPurger permanently deletes tasks that have been in the trash longer than the
+// retention period.
+type TrashPurger struct {
+	repo      ports.TaskRepository
+	retention time.Duration
+	interval  time.Duration
+}
+
+func NewTrashPurger(repo ports.TaskRepository, retention, interval time.Duration) *TrashPurger {
+	return &TrashPurger{
+		repo:      repo,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.purge()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.purge()
+		}
+	}
+}
+
+func (p *TrashPurger) purge() {
+	count, err := p.repo.PurgeDeletedBefore(time.Now().Add(-p.retention))
+	if err != nil {
+		log.Printf("trash purger: %v", err)
+		return
+	}
+
+	if count > 0 {
+		log.Printf("trash purger: purged %d tasks", count)
+	}
+}
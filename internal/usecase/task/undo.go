@@ -0,0 +1,258 @@
+package task
+
+import (
+	"fmt"
+	"log"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// maxUndoCount bounds how many operations one request can undo.
+const maxUndoCount = 20
+
+// recordUndo stores how to revert a mutation of task and sets its undo token. The mutation
+// has already succeeded, so a failure here only means it cannot be undone.
+func (uc *TaskUseCase) recordUndo(action constant.UndoAction, task *entity.Task, before *entity.TaskSnapshot) {
+	if uc.opts.UndoWindow <= 0 {
+		return
+	}
+
+	entry := &entity.UndoEntry{
+		UserID:   task.UserID,
+		TaskID:   task.ID,
+		Action:   action,
+		Before:   before,
+		Version:  task.UpdatedAt,
+		Previous: task.UpdatedAt,
+	}
+
+	if before != nil {
+		entry.Previous = before.UpdatedAt
+	}
+
+	if err := uc.undoRepo.Create(entry, time.Now().Add(-uc.opts.UndoWindow)); err != nil {
+		log.Printf("undo: task %d: %v", task.ID, err)
+		return
+	}
+
+	task.UndoToken = &entry.ID
+}
+
+// withUndo loads the task after action and records it for undo.
+func (uc *TaskUseCase) withUndo(action constant.UndoAction, taskID, userID int64) (*entity.Task, error) {
+	task, err := uc.repo.GetByID(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.recordUndo(action, task, nil)
+	return task, nil
+}
+
+// Undo reverts the operation of req.Token, or the user's last req.Count operations newest
+// first. It stops at the first operation whose task has changed since, returning the
+// operations undone before it together with an UndoConflictError.
+func (uc *TaskUseCase) Undo(userID int64, req entity.UndoRequest) (*entity.UndoResult, error) {
+	if uc.opts.UndoWindow <= 0 {
+		return nil, fmt.Errorf("undo is disabled")
+	}
+
+	since := time.Now().Add(-uc.opts.UndoWindow)
+	var entries []entity.UndoEntry
+	if req.Token != nil {
+		entry, err := uc.undoRepo.GetByID(*req.Token, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if entry.UndoneAt != nil {
+			return nil, fmt.Errorf("operation was already undone")
+		}
+
+		if entry.CreatedAt.Before(since) {
+			return nil, fmt.Errorf("operation can no longer be undone")
+		}
+
+		entries = append(entries, *entry)
+	} else {
+		count := req.Count
+		if count == 0 {
+			count = 1
+		}
+
+		if count < 1 || count > maxUndoCount {
+			return nil, fmt.Errorf("count must be between 1 and %d", maxUndoCount)
+		}
+
+		var err error
+		if entries, err = uc.undoRepo.GetRecent(userID, since, count); err != nil {
+			return nil, err
+		}
+
+		if len(entries) == 0 {
+			return nil, fmt.Errorf("nothing to undo")
+		}
+	}
+
+	result := &entity.UndoResult{Undone: []entity.UndoEntry{}}
+	defer func() {
+		if len(result.Undone) > 0 {
+			uc.invalidateAll(userID)
+		}
+	}()
+
+	for _, entry := range entries {
+		// undoing a newer entry of the same task rebases this one
+		current, err := uc.undoRepo.GetByID(entry.ID, userID)
+		if err != nil {
+			return result, err
+		}
+
+		entry = *current
+		if err := uc.undo(userID, entry); err != nil {
+			return result, err
+		}
+
+		if err := uc.undoRepo.MarkUndone(entry.ID); err != nil {
+			return result, err
+		}
+
+		if err := uc.rebaseUndo(userID, entry); err != nil {
+			return result, err
+		}
+
+		result.Undone = append(result.Undone, entry)
+	}
+
+	return result, nil
+}
+
+// rebaseUndo lets the task's older entries be undone after entry, which gave the task back
+// its previous state but a new updated_at.
+func (uc *TaskUseCase) rebaseUndo(userID int64, entry entity.UndoEntry) error {
+	if entry.Action == constant.UndoActionCreate || entry.Action == constant.UndoActionRestore {
+		return nil
+	}
+
+	task, err := uc.repo.GetByID(entry.TaskID, userID)
+	if err != nil {
+		return err
+	}
+
+	return uc.undoRepo.Rebase(task.ID, entry.Previous, task.UpdatedAt)
+}
+
+func (uc *TaskUseCase) undo(userID int64, entry entity.UndoEntry) error {
+	conflict := func(format string, args ...any) error {
+		return &entity.UndoConflictError{Token: entry.ID, TaskID: entry.TaskID, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if entry.Action == constant.UndoActionDelete {
+		if err := uc.repo.Restore(entry.TaskID, userID); err != nil {
+			return conflict("task %d cannot be restored: %v", entry.TaskID, err)
+		}
+
+		return nil
+	}
+
+	task, err := uc.repo.GetByID(entry.TaskID, userID)
+	if err != nil {
+		return conflict("task %d no longer exists", entry.TaskID)
+	}
+
+	if !task.UpdatedAt.Equal(entry.Version) {
+		return conflict("task %d has changed since the %s", entry.TaskID, entry.Action)
+	}
+
+	switch entry.Action {
+	case constant.UndoActionCreate, constant.UndoActionRestore:
+		return uc.repo.Delete(task.ID, userID)
+	case constant.UndoActionArchive:
+		if task.ArchivedAt == nil {
+			return conflict("task %d is no longer archived", entry.TaskID)
+		}
+
+		return uc.repo.Unarchive(task.ID, userID)
+	case constant.UndoActionUnarchive:
+		if task.ArchivedAt != nil {
+			return conflict("task %d is already archived", entry.TaskID)
+		}
+
+		return uc.repo.Archive(task.ID, userID)
+	case constant.UndoActionMove:
+		if err := uc.repo.SetPosition(task.ID, userID, entry.Before.ParentID, entry.Before.Rank); err != nil {
+			return err
+		}
+
+		task.ParentID = entry.Before.ParentID
+		return uc.recordVersion(task, nil)
+	case constant.UndoActionUpdate:
+		return uc.revertUpdate(task, entry.Before)
+	}
+
+	return fmt.Errorf("unknown undo action: %s", entry.Action)
+}
+
+// revertUpdate writes the snapshot's values back. Undo restores the previous state as it
+// was, so workflow transitions are not checked again.
+func (uc *TaskUseCase) revertUpdate(task *entity.Task, before *entity.TaskSnapshot) error {
+	workflow, err := uc.workflowFor(task.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	// undoing a completion takes back the occurrence it spawned
+	undoesCompletion := task.RRule != "" && workflow.IsDone(task.Status) && !workflow.IsDone(before.Status)
+
+	oldStatus, oldDueAt, current := task.Status, task.DueAt, task.CustomFields
+	before.Apply(task)
+
+	// fields set by the update are cleared again
+	input := make(map[string]any, len(current))
+	for key := range current {
+		if _, ok := before.CustomFields[key]; !ok {
+			input[key] = nil
+		}
+	}
+
+	for key, value := range before.CustomFields {
+		input[key] = value
+	}
+
+	var fieldValues map[int64][]entity.FieldValue
+	if len(input) > 0 {
+		var err error
+		if fieldValues, task.CustomFields, err = uc.resolveCustomFields(task.ProjectID, input, current); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.repo.Update(task); err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	if undoesCompletion {
+		if err := uc.revertNextOccurrence(task); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.customFieldRepo.SetTaskValues(task.ID, fieldValues); err != nil {
+		return err
+	}
+
+	if task.Status != oldStatus {
+		if err := uc.recordStatusChange(task, oldStatus, "undo"); err != nil {
+			return err
+		}
+	}
+
+	if !sameTime(oldDueAt, task.DueAt) {
+		if err := uc.reminderRepo.RescheduleForTask(task.ID, task.DueAt); err != nil {
+			return err
+		}
+	}
+
+	return uc.recordVersion(task, nil)
+}
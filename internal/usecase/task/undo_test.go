@@ -0,0 +1,169 @@
+package task
+
+import (
+	"errors"
+	"path/filepath"
+	"task-management-backend/config"
+	"task-management-backend/internal/cache"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/repository"
+	"testing"
+	"time"
+)
+
+// newTestUseCase returns a use case with undo enabled over a migrated temporary database,
+// and the ID of a user to act as.
+func newTestUseCase(t *testing.T) (*TaskUseCase, int64) {
+	t.Helper()
+	db, err := config.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	userRepo := repository.NewUserRepository(db)
+	user := &entity.User{Username: "alice", Password: "secret"}
+	if err := userRepo.Create(user); err != nil {
+		t.Fatal(err)
+	}
+
+	uc := NewTaskUseCase(
+		repository.NewTaskRepository(db),
+		userRepo,
+		repository.NewReminderRepository(db),
+		repository.NewDependencyRepository(db),
+		repository.NewProjectRepository(db),
+		repository.NewCustomFieldRepository(db),
+		repository.NewUndoRepository(db),
+		repository.NewTaskVersionRepository(db),
+		cache.NewTaskCache(time.Hour),
+		Options{UndoWindow: time.Hour},
+	)
+	return uc, user.ID
+}
+
+func setTitle(t *testing.T, uc *TaskUseCase, userID, taskID int64, title string) *entity.Task {
+	t.Helper()
+	task, err := uc.UpdateTask(userID, taskID, entity.UpdateTaskRequest{Title: &title})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if task.UndoToken == nil {
+		t.Fatal("update returned no undo token")
+	}
+
+	return task
+}
+
+func TestUndoDelete(t *testing.T) {
+	uc, userID := newTestUseCase(t)
+	task, err := uc.CreateTask(userID, entity.CreateTaskRequest{Title: "write report"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := uc.DeleteTask(userID, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := uc.GetTask(userID, task.ID, 0); err == nil {
+		t.Fatal("deleted task is still visible")
+	}
+
+	result, err := uc.Undo(userID, entity.UndoRequest{Token: deleted.UndoToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Undone) != 1 || result.Undone[0].ID != *deleted.UndoToken {
+		t.Fatalf("undone = %+v, want the delete", result.Undone)
+	}
+
+	restored, err := uc.GetTask(userID, task.ID, 0)
+	if err != nil {
+		t.Fatalf("task was not restored: %v", err)
+	}
+
+	if restored.Title != "write report" {
+		t.Errorf("title = %q, want %q", restored.Title, "write report")
+	}
+
+	if _, err := uc.Undo(userID, entity.UndoRequest{Token: deleted.UndoToken}); err == nil {
+		t.Error("undoing the delete twice succeeded")
+	}
+}
+
+func TestUndoConflict(t *testing.T) {
+	uc, userID := newTestUseCase(t)
+	task, err := uc.CreateTask(userID, entity.CreateTaskRequest{Title: "one"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := setTitle(t, uc, userID, task.ID, "two")
+	setTitle(t, uc, userID, task.ID, "three")
+
+	result, err := uc.Undo(userID, entity.UndoRequest{Token: first.UndoToken})
+	var conflict *entity.UndoConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("err = %v, want an UndoConflictError", err)
+	}
+
+	if conflict.Token != *first.UndoToken || conflict.TaskID != task.ID {
+		t.Errorf("conflict = %+v, want token %d of task %d", conflict, *first.UndoToken, task.ID)
+	}
+
+	if len(result.Undone) != 0 {
+		t.Errorf("undone = %+v, want none", result.Undone)
+	}
+
+	current, err := uc.GetTask(userID, task.ID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if current.Title != "three" {
+		t.Errorf("title = %q, want the newer update %q kept", current.Title, "three")
+	}
+}
+
+func TestUndoCountRebases(t *testing.T) {
+	uc, userID := newTestUseCase(t)
+	task, err := uc.CreateTask(userID, entity.CreateTaskRequest{Title: "one"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := setTitle(t, uc, userID, task.ID, "two")
+	second := setTitle(t, uc, userID, task.ID, "three")
+
+	result, err := uc.Undo(userID, entity.UndoRequest{Count: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Undone) != 2 || result.Undone[0].ID != *second.UndoToken || result.Undone[1].ID != *first.UndoToken {
+		t.Fatalf("undone = %+v, want the second update and then the first", result.Undone)
+	}
+
+	current, err := uc.GetTask(userID, task.ID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if current.Title != "one" {
+		t.Errorf("title = %q, want %q", current.Title, "one")
+	}
+
+	// the create is next, and was rebased onto the state the undos left behind
+	if _, err := uc.Undo(userID, entity.UndoRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := uc.GetTask(userID, task.ID, 0); err == nil {
+		t.Error("undoing the create left the task in place")
+	}
+}
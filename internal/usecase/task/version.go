@@ -0,0 +1,274 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/textdiff"
+	"time"
+)
+
+// recordVersion stores the task's current state as its next version unless nothing changed
+// since the latest one. Tasks created before versions were kept get before as their first
+// version, so the change is not lost.
+func (uc *TaskUseCase) recordVersion(task *entity.Task, before *entity.TaskSnapshot) error {
+	latest, err := uc.versionRepo.GetLatest(task.ID)
+	if err != nil {
+		return err
+	}
+
+	snapshot := entity.NewTaskSnapshot(task)
+	if latest != nil && len(diffSnapshots(latest.Snapshot, snapshot)) == 0 {
+		return nil
+	}
+
+	if latest == nil && before != nil && len(diffSnapshots(before, snapshot)) > 0 {
+		if err := uc.versionRepo.Create(&entity.TaskVersion{TaskID: task.ID, UserID: task.UserID, Snapshot: before}); err != nil {
+			return err
+		}
+	}
+
+	return uc.versionRepo.Create(&entity.TaskVersion{TaskID: task.ID, UserID: task.UserID, Snapshot: snapshot})
+}
+
+// GetVersions lists the task's versions, newest first. A task without any gets its
+// current state recorded as version 1.
+func (uc *TaskUseCase) GetVersions(userID, taskID int64) ([]entity.TaskVersion, error) {
+	task, err := uc.repo.GetByID(taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	versions, err := uc.versionRepo.GetByTaskID(task.ID)
+	if err != nil || len(versions) > 0 {
+		return versions, err
+	}
+
+	if err := uc.recordVersion(task, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.versionRepo.GetByTaskID(task.ID)
+}
+
+func (uc *TaskUseCase) GetVersion(userID, taskID int64, number int) (*entity.TaskVersion, error) {
+	if _, err := uc.repo.GetByID(taskID, userID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	return uc.versionRepo.GetVersion(taskID, number)
+}
+
+// DiffVersions compares two versions of the task.
+func (uc *TaskUseCase) DiffVersions(userID, taskID int64, query entity.VersionDiffQuery) (*entity.VersionDiff, error) {
+	if _, err := uc.repo.GetByID(taskID, userID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	var to *entity.TaskVersion
+	var err error
+	if query.To == 0 {
+		if to, err = uc.versionRepo.GetLatest(taskID); err == nil && to == nil {
+			err = fmt.Errorf("task has no versions")
+		}
+	} else {
+		to, err = uc.versionRepo.GetVersion(taskID, query.To)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if query.From == 0 {
+		query.From = to.Version - 1
+		if query.From == 0 {
+			return nil, fmt.Errorf("version %d has no previous version", to.Version)
+		}
+	}
+
+	from, err := uc.versionRepo.GetVersion(taskID, query.From)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.VersionDiff{
+		TaskID:  taskID,
+		From:    from.Version,
+		To:      to.Version,
+		Changes: diffSnapshots(from.Snapshot, to.Snapshot),
+	}, nil
+}
+
+// RevertToVersion updates the task back to the version's fields through UpdateTask, so the
+// revert is validated like any other update and recorded as a new version. Only fields that
+// differ are sent, which keeps unchanged statuses out of the transition checks.
+func (uc *TaskUseCase) RevertToVersion(userID, taskID int64, number int) (*entity.Task, error) {
+	task, err := uc.repo.GetByID(taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	version, err := uc.versionRepo.GetVersion(taskID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	target, current := version.Snapshot, entity.NewTaskSnapshot(task)
+	if !sameProject(target.ProjectID, current.ProjectID) {
+		return nil, fmt.Errorf("version %d belongs to another project", number)
+	}
+
+	var req entity.UpdateTaskRequest
+	if target.Title != current.Title {
+		req.Title = &target.Title
+	}
+
+	if target.Description != current.Description {
+		req.Description = &target.Description
+	}
+
+	if target.Status != current.Status {
+		status := string(target.Status)
+		reason := fmt.Sprintf("revert to version %d", number)
+		req.Status, req.StatusReason = &status, &reason
+	}
+
+	if target.Priority != current.Priority {
+		priority := string(target.Priority)
+		req.Priority = &priority
+	}
+
+	if !sameProject(target.ParentID, current.ParentID) {
+		parentID := int64(0)
+		if target.ParentID != nil {
+			parentID = *target.ParentID
+		}
+
+		req.ParentID = &parentID
+	}
+
+	if !sameTime(target.StartAt, current.StartAt) {
+		req.StartAt, req.ClearStartAt = target.StartAt, target.StartAt == nil
+	}
+
+	if !sameTime(target.DueAt, current.DueAt) {
+		req.DueAt, req.ClearDueAt = target.DueAt, target.DueAt == nil
+	}
+
+	if target.RRule != current.RRule {
+		req.RRule = &target.RRule
+	}
+
+	if target.Timezone != current.Timezone {
+		req.Timezone = &target.Timezone
+	}
+
+	if target.CopySubTasks != current.CopySubTasks {
+		req.CopySubTasks = &target.CopySubTasks
+	}
+
+	req.OriginalEstimate = revertEstimate(target.OriginalEstimate, current.OriginalEstimate)
+	req.RemainingEstimate = revertEstimate(target.RemainingEstimate, current.RemainingEstimate)
+
+	for key := range current.CustomFields {
+		if _, ok := target.CustomFields[key]; !ok {
+			if req.CustomFields == nil {
+				req.CustomFields = map[string]any{}
+			}
+
+			req.CustomFields[key] = nil
+		}
+	}
+
+	for key, value := range target.CustomFields {
+		if !jsonEqual(value, current.CustomFields[key]) {
+			if req.CustomFields == nil {
+				req.CustomFields = map[string]any{}
+			}
+
+			req.CustomFields[key] = value
+		}
+	}
+
+	return uc.UpdateTask(userID, taskID, req)
+}
+
+// revertEstimate returns the estimate to send to get from current back to target; 0 clears it.
+func revertEstimate(target, current *int64) *int64 {
+	if jsonEqual(target, current) {
+		return nil
+	}
+
+	if target == nil {
+		return new(int64)
+	}
+
+	return target
+}
+
+// diffSnapshots lists the fields that differ between two snapshots. The rank and the
+// update time are not compared.
+func diffSnapshots(a, b *entity.TaskSnapshot) []entity.VersionChange {
+	changes := []entity.VersionChange{}
+	add := func(field string, from, to any) {
+		if !jsonEqual(from, to) {
+			changes = append(changes, entity.VersionChange{Field: field, From: from, To: to})
+		}
+	}
+
+	addTime := func(field string, from, to *time.Time) {
+		if !sameTime(from, to) {
+			changes = append(changes, entity.VersionChange{Field: field, From: from, To: to})
+		}
+	}
+
+	add("title", a.Title, b.Title)
+	if a.Description != b.Description {
+		changes = append(changes, entity.VersionChange{
+			Field: "description",
+			From:  a.Description,
+			To:    b.Description,
+			Lines: textdiff.Lines(a.Description, b.Description),
+		})
+	}
+
+	add("status", a.Status, b.Status)
+	add("priority", a.Priority, b.Priority)
+	add("parent_id", a.ParentID, b.ParentID)
+	add("project_id", a.ProjectID, b.ProjectID)
+	addTime("start_at", a.StartAt, b.StartAt)
+	addTime("due_at", a.DueAt, b.DueAt)
+	add("rrule", a.RRule, b.RRule)
+	add("timezone", a.Timezone, b.Timezone)
+	addTime("recurrence_start", a.RecurrenceStart, b.RecurrenceStart)
+	add("copy_sub_tasks", a.CopySubTasks, b.CopySubTasks)
+	add("original_estimate_seconds", a.OriginalEstimate, b.OriginalEstimate)
+	add("remaining_estimate_seconds", a.RemainingEstimate, b.RemainingEstimate)
+
+	var keys []string
+	for key := range a.CustomFields {
+		keys = append(keys, key)
+	}
+
+	for key := range b.CustomFields {
+		if _, ok := a.CustomFields[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+	for _, key := range keys {
+		add("custom_fields."+key, a.CustomFields[key], b.CustomFields[key])
+	}
+
+	return changes
+}
+
+// jsonEqual compares values by their JSON form, since snapshots read back from the database
+// hold decoded JSON rather than the original types.
+func jsonEqual(a, b any) bool {
+	x, errX := json.Marshal(a)
+	y, errY := json.Marshal(b)
+	return errX == nil && errY == nil && string(x) == string(y)
+}
@@ -0,0 +1,75 @@
+package task
+
+import (
+	"fmt"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/pkg/constant"
+)
+
+// workflowFor returns the workflow of the project, or the default workflow for tasks
+// outside a project.
+func (uc *TaskUseCase) workflowFor(projectID *int64) (entity.Workflow, error) {
+	if projectID == nil {
+		return entity.DefaultWorkflow(), nil
+	}
+
+	statuses, err := uc.projectRepo.GetWorkflowStatuses(*projectID)
+	if err != nil {
+		return entity.Workflow{}, err
+	}
+
+	if len(statuses) == 0 {
+		return entity.DefaultWorkflow(), nil
+	}
+
+	return entity.Workflow{ProjectID: projectID, Statuses: statuses}, nil
+}
+
+// listWorkflow is the workflow used to validate list filters: the project's own workflow,
+// or the default workflow merged with every project workflow of the user.
+func (uc *TaskUseCase) listWorkflow(userID int64, projectID *int64) (entity.Workflow, error) {
+	if projectID != nil {
+		if _, err := uc.projectRepo.GetByID(*projectID, userID); err != nil {
+			return entity.Workflow{}, err
+		}
+
+		return uc.workflowFor(projectID)
+	}
+
+	statuses, err := uc.projectRepo.GetWorkflowStatusesByOwner(userID)
+	if err != nil {
+		return entity.Workflow{}, err
+	}
+
+	workflow := entity.DefaultWorkflow()
+	for _, s := range statuses {
+		if !workflow.Has(s.Name) {
+			workflow.Statuses = append(workflow.Statuses, s)
+		}
+	}
+
+	return workflow, nil
+}
+
+// applyWorkflowFilter validates the status filters and resolves categories to statuses.
+func applyWorkflowFilter(filter *entity.TaskFilter, query entity.TaskQuery, workflow entity.Workflow) error {
+	if filter.Status != constant.TaskStatusDefault && filter.Status != constant.TaskStatusAll && !workflow.Has(filter.Status) {
+		return fmt.Errorf("invalid status filter: %s", filter.Status)
+	}
+
+	if query.StatusCategory != "" {
+		category := constant.StatusCategory(query.StatusCategory)
+		if !category.IsValid() {
+			return fmt.Errorf("invalid status_category filter: %s", query.StatusCategory)
+		}
+
+		filter.Statuses = workflow.StatusesIn(category)
+		if len(filter.Statuses) == 0 {
+			// no status in the category: match nothing rather than everything
+			filter.Statuses = []constant.TaskStatus{""}
+		}
+	}
+
+	filter.DoneStatuses = workflow.StatusesIn(constant.StatusCategoryDone)
+	return nil
+}
@@ -0,0 +1,401 @@
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// maxTemplateTasks bounds the size of a template tree.
+const maxTemplateTasks = 500
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+type TemplateUseCase struct {
+	repo        ports.TemplateRepository
+	taskRepo    ports.TaskRepository
+	projectRepo ports.ProjectRepository
+	cache       ports.TaskCache
+}
+
+func NewTemplateUseCase(repo ports.TemplateRepository, taskRepo ports.TaskRepository, projectRepo ports.ProjectRepository, cache ports.TaskCache) *TemplateUseCase {
+	return &TemplateUseCase{
+		repo:        repo,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		cache:       cache,
+	}
+}
+
+func (uc *TemplateUseCase) CreateTemplate(userID int64, req entity.CreateTemplateRequest) (*entity.TaskTemplate, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("template name cannot be empty")
+	}
+
+	if (req.TaskID == nil) == (req.Task == nil) {
+		return nil, fmt.Errorf("set either task_id or task")
+	}
+
+	if req.ProjectID != nil {
+		if _, err := uc.projectRepo.GetByID(*req.ProjectID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	var root entity.TemplateNode
+	if req.TaskID != nil {
+		task, err := uc.taskRepo.GetByID(*req.TaskID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("task not found: %w", err)
+		}
+
+		root = nodeFromTask(*task, earliestDate(*task))
+	} else {
+		root = *req.Task
+	}
+
+	if err := validateTree(root); err != nil {
+		return nil, err
+	}
+
+	template := &entity.TaskTemplate{
+		OwnerID:     userID,
+		ProjectID:   req.ProjectID,
+		Name:        name,
+		Description: req.Description,
+		Task:        root,
+	}
+
+	if err := uc.repo.Create(template); err != nil {
+		return nil, err
+	}
+
+	template.Placeholders = placeholders(template.Task)
+	return template, nil
+}
+
+// GetTemplates lists the templates the user can use, optionally only those shared with a
+// project.
+func (uc *TemplateUseCase) GetTemplates(userID int64, projectID *int64) ([]entity.TaskTemplate, error) {
+	templates, err := uc.repo.GetVisible(userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range templates {
+		templates[i].Placeholders = placeholders(templates[i].Task)
+	}
+
+	return templates, nil
+}
+
+func (uc *TemplateUseCase) GetTemplate(userID, templateID int64) (*entity.TaskTemplate, error) {
+	template, err := uc.repo.GetByID(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	template.Placeholders = placeholders(template.Task)
+	return template, nil
+}
+
+func (uc *TemplateUseCase) UpdateTemplate(userID, templateID int64, req entity.UpdateTemplateRequest) (*entity.TaskTemplate, error) {
+	template, err := uc.repo.GetByID(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("template name cannot be empty")
+		}
+
+		template.Name = name
+	}
+
+	if req.Description != nil {
+		template.Description = *req.Description
+	}
+
+	if req.Task != nil {
+		if err := validateTree(*req.Task); err != nil {
+			return nil, err
+		}
+
+		template.Task = *req.Task
+	}
+
+	switch {
+	case req.Unshare && req.ProjectID != nil:
+		return nil, fmt.Errorf("set either project_id or unshare, not both")
+	case req.Unshare:
+		template.ProjectID = nil
+	case req.ProjectID != nil:
+		if _, err := uc.projectRepo.GetByID(*req.ProjectID, userID); err != nil {
+			return nil, err
+		}
+
+		template.ProjectID = req.ProjectID
+	}
+
+	if err := uc.repo.Update(template); err != nil {
+		return nil, err
+	}
+
+	template.Placeholders = placeholders(template.Task)
+	return template, nil
+}
+
+func (uc *TemplateUseCase) DeleteTemplate(userID, templateID int64) error {
+	if _, err := uc.repo.GetByID(templateID, userID); err != nil {
+		return err
+	}
+
+	return uc.repo.Delete(templateID)
+}
+
+// Instantiate creates the template's task tree in a single transaction. Placeholders are
+// replaced with the given variables and date offsets are applied to req.StartAt.
+func (uc *TemplateUseCase) Instantiate(userID, templateID int64, req entity.InstantiateTemplateRequest) (*entity.Task, error) {
+	template, err := uc.repo.GetByID(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, name := range placeholders(template.Task) {
+		if _, ok := req.Variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing template variables: %s", strings.Join(missing, ", "))
+	}
+
+	projectID := req.ProjectID
+	if req.ParentID != nil {
+		parent, err := uc.taskRepo.GetByID(*req.ParentID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("parent task not found: %w", err)
+		}
+
+		if projectID != nil && (parent.ProjectID == nil || *parent.ProjectID != *projectID) {
+			return nil, fmt.Errorf("subtasks must belong to the parent's project")
+		}
+
+		projectID = parent.ProjectID
+	} else {
+		if projectID == nil {
+			projectID = template.ProjectID
+		}
+
+		if projectID != nil {
+			if _, err := uc.projectRepo.GetByID(*projectID, userID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	status, err := uc.initialStatus(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	base := time.Now().UTC()
+	if req.StartAt != nil {
+		base = req.StartAt.UTC()
+	}
+
+	root, err := buildTask(template.Task, userID, projectID, status, base, req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	root.ParentID = req.ParentID
+	if err := uc.taskRepo.CreateTree(root); err != nil {
+		return nil, err
+	}
+
+	uc.cache.InvalidateUser(userID)
+	return root, nil
+}
+
+// initialStatus is the first status of the project's workflow.
+func (uc *TemplateUseCase) initialStatus(projectID *int64) (constant.TaskStatus, error) {
+	if projectID == nil {
+		return entity.DefaultWorkflow().Initial(), nil
+	}
+
+	statuses, err := uc.projectRepo.GetWorkflowStatuses(*projectID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(statuses) == 0 {
+		return entity.DefaultWorkflow().Initial(), nil
+	}
+
+	return entity.Workflow{Statuses: statuses}.Initial(), nil
+}
+
+func buildTask(node entity.TemplateNode, userID int64, projectID *int64, status constant.TaskStatus, base time.Time, variables map[string]string) (*entity.Task, error) {
+	title := strings.TrimSpace(render(node.Title, variables))
+	if title == "" {
+		return nil, fmt.Errorf("template task title %q is empty after filling in variables", node.Title)
+	}
+
+	priority := constant.TaskPriority(node.Priority)
+	if priority == "" {
+		priority = constant.TaskPriorityNone
+	}
+
+	task := &entity.Task{
+		UserID:      userID,
+		ProjectID:   projectID,
+		Title:       title,
+		Description: render(node.Description, variables),
+		Status:      status,
+		Priority:    priority,
+		StartAt:     offsetTime(base, node.StartOffsetMinutes),
+		DueAt:       offsetTime(base, node.DueOffsetMinutes),
+		SubTasks:    make([]entity.Task, 0, len(node.SubTasks)),
+	}
+
+	for _, child := range node.SubTasks {
+		sub, err := buildTask(child, userID, projectID, status, base, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		task.SubTasks = append(task.SubTasks, *sub)
+	}
+
+	return task, nil
+}
+
+func render(text string, variables map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		return variables[name]
+	})
+}
+
+// placeholders returns the sorted variable names used in the tree.
+func placeholders(root entity.TemplateNode) []string {
+	seen := make(map[string]bool)
+	var walk func(node entity.TemplateNode)
+	walk = func(node entity.TemplateNode) {
+		for _, text := range []string{node.Title, node.Description} {
+			for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+				seen[match[1]] = true
+			}
+		}
+
+		for _, child := range node.SubTasks {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func validateTree(root entity.TemplateNode) error {
+	count := 0
+	var walk func(node entity.TemplateNode) error
+	walk = func(node entity.TemplateNode) error {
+		count++
+		if count > maxTemplateTasks {
+			return fmt.Errorf("templates cannot have more than %d tasks", maxTemplateTasks)
+		}
+
+		if strings.TrimSpace(node.Title) == "" {
+			return fmt.Errorf("template task title cannot be empty")
+		}
+
+		if node.Priority != "" && !constant.TaskPriority(node.Priority).IsValid() {
+			return fmt.Errorf("invalid priority in template task %q: %s", node.Title, node.Priority)
+		}
+
+		if node.StartOffsetMinutes != nil && node.DueOffsetMinutes != nil && *node.StartOffsetMinutes > *node.DueOffsetMinutes {
+			return fmt.Errorf("template task %q starts after it is due", node.Title)
+		}
+
+		for _, child := range node.SubTasks {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return walk(root)
+}
+
+// nodeFromTask converts a task tree to template nodes with dates stored as offsets from base.
+func nodeFromTask(task entity.Task, base *time.Time) entity.TemplateNode {
+	node := entity.TemplateNode{
+		Title:              task.Title,
+		Description:        task.Description,
+		Priority:           string(task.Priority),
+		StartOffsetMinutes: offsetMinutes(base, task.StartAt),
+		DueOffsetMinutes:   offsetMinutes(base, task.DueAt),
+	}
+
+	for _, sub := range task.SubTasks {
+		node.SubTasks = append(node.SubTasks, nodeFromTask(sub, base))
+	}
+
+	return node
+}
+
+// earliestDate is the first start or due date in the tree, used as the offset base.
+func earliestDate(task entity.Task) *time.Time {
+	var earliest *time.Time
+	for _, t := range []*time.Time{task.StartAt, task.DueAt} {
+		if t != nil && (earliest == nil || t.Before(*earliest)) {
+			earliest = t
+		}
+	}
+
+	for _, sub := range task.SubTasks {
+		if t := earliestDate(sub); t != nil && (earliest == nil || t.Before(*earliest)) {
+			earliest = t
+		}
+	}
+
+	return earliest
+}
+
+func offsetMinutes(base, t *time.Time) *int64 {
+	if base == nil || t == nil {
+		return nil
+	}
+
+	minutes := int64(t.Sub(*base) / time.Minute)
+	return &minutes
+}
+
+func offsetTime(base time.Time, minutes *int64) *time.Time {
+	if minutes == nil {
+		return nil
+	}
+
+	t := base.Add(time.Duration(*minutes) * time.Minute)
+	return &t
+}
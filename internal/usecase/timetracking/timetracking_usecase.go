@@ -0,0 +1,232 @@
+package timetracking
+
+import (
+	"fmt"
+	"sort"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+	"task-management-backend/pkg/constant"
+	"time"
+)
+
+// maxTimesheetDays bounds the range of a single timesheet report.
+const maxTimesheetDays = 366
+
+type TimeTrackingUseCase struct {
+	repo     ports.WorkLogRepository
+	taskRepo ports.TaskRepository
+	userRepo ports.UserRepository
+	cache    ports.TaskCache
+}
+
+func NewTimeTrackingUseCase(repo ports.WorkLogRepository, taskRepo ports.TaskRepository, userRepo ports.UserRepository, cache ports.TaskCache) *TimeTrackingUseCase {
+	return &TimeTrackingUseCase{
+		repo:     repo,
+		taskRepo: taskRepo,
+		userRepo: userRepo,
+		cache:    cache,
+	}
+}
+
+// StartTimer starts timing work on a task. Each user can run one timer at a time.
+func (uc *TimeTrackingUseCase) StartTimer(userID, taskID int64, req entity.StartTimerRequest) (*entity.WorkLog, error) {
+	if _, err := uc.taskRepo.GetByID(taskID, userID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	running, err := uc.repo.GetRunning(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if running != nil {
+		return nil, fmt.Errorf("a timer is already running on task %d, stop it first", running.TaskID)
+	}
+
+	log := &entity.WorkLog{
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: time.Now().UTC(),
+		Note:      req.Note,
+		Source:    constant.WorkLogSourceTimer,
+	}
+
+	if err := uc.repo.Create(log); err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
+
+// StopTimer stops the user's running timer and logs the elapsed time.
+func (uc *TimeTrackingUseCase) StopTimer(userID int64) (*entity.WorkLog, error) {
+	log, err := uc.repo.GetRunning(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if log == nil {
+		return nil, fmt.Errorf("no timer is running")
+	}
+
+	now := time.Now().UTC()
+	log.EndedAt = &now
+	log.DurationSeconds = int64(now.Sub(log.StartedAt).Seconds())
+	if err := uc.repo.Stop(log); err != nil {
+		return nil, err
+	}
+
+	if err := uc.logged(userID, log); err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
+
+// GetRunningTimer returns the user's running timer, or nil.
+func (uc *TimeTrackingUseCase) GetRunningTimer(userID int64) (*entity.WorkLog, error) {
+	return uc.repo.GetRunning(userID)
+}
+
+func (uc *TimeTrackingUseCase) CreateWorkLog(userID, taskID int64, req entity.CreateWorkLogRequest) (*entity.WorkLog, error) {
+	if _, err := uc.taskRepo.GetByID(taskID, userID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	if req.DurationSeconds <= 0 {
+		return nil, fmt.Errorf("duration_seconds must be positive")
+	}
+
+	duration := time.Duration(req.DurationSeconds) * time.Second
+	startedAt := time.Now().UTC().Add(-duration)
+	if req.StartedAt != nil {
+		startedAt = req.StartedAt.UTC()
+	}
+
+	endedAt := startedAt.Add(duration)
+	if endedAt.After(time.Now()) {
+		return nil, fmt.Errorf("work logs cannot end in the future")
+	}
+
+	log := &entity.WorkLog{
+		TaskID:          taskID,
+		UserID:          userID,
+		StartedAt:       startedAt,
+		EndedAt:         &endedAt,
+		DurationSeconds: req.DurationSeconds,
+		Note:            req.Note,
+		Source:          constant.WorkLogSourceManual,
+	}
+
+	if err := uc.repo.Create(log); err != nil {
+		return nil, err
+	}
+
+	if err := uc.logged(userID, log); err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
+
+func (uc *TimeTrackingUseCase) GetWorkLogs(userID, taskID int64) ([]entity.WorkLog, error) {
+	if _, err := uc.taskRepo.GetByID(taskID, userID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	return uc.repo.GetByTaskID(taskID)
+}
+
+func (uc *TimeTrackingUseCase) DeleteWorkLog(userID, logID int64) error {
+	if err := uc.repo.Delete(logID, userID); err != nil {
+		return err
+	}
+
+	uc.cache.InvalidateUser(userID)
+	return nil
+}
+
+// logged burns the remaining estimate down by the logged time. Logged time rolls up into
+// parent tasks, so every cached list of the user is dropped.
+func (uc *TimeTrackingUseCase) logged(userID int64, log *entity.WorkLog) error {
+	task, err := uc.taskRepo.GetByID(log.TaskID, userID)
+	if err != nil {
+		// the task went to the trash while its timer was running; the log is kept
+		// for when it is restored
+		return nil
+	}
+
+	if task.RemainingEstimate != nil {
+		remaining := max(*task.RemainingEstimate-log.DurationSeconds, 0)
+		task.RemainingEstimate = &remaining
+		if err := uc.taskRepo.Update(task); err != nil {
+			return err
+		}
+	}
+
+	uc.cache.InvalidateUser(userID)
+	return nil
+}
+
+// GetTimesheet reports the user's finished work logs that started between the from and to
+// dates, inclusive, in the user's timezone.
+func (uc *TimeTrackingUseCase) GetTimesheet(userID int64, query entity.TimesheetQuery) (*entity.Timesheet, error) {
+	loc := entity.UserLocation(uc.userRepo.GetByID(userID))
+	from, err := time.ParseInLocation(entity.DateLayout, query.From, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date, expected YYYY-MM-DD: %q", query.From)
+	}
+
+	to, err := time.ParseInLocation(entity.DateLayout, query.To, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to date, expected YYYY-MM-DD: %q", query.To)
+	}
+
+	if to.Before(from) {
+		return nil, fmt.Errorf("to cannot be before from")
+	}
+
+	if to.Sub(from) > maxTimesheetDays*24*time.Hour {
+		return nil, fmt.Errorf("timesheet range cannot exceed %d days", maxTimesheetDays)
+	}
+
+	entries, err := uc.repo.GetTimesheet(userID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &entity.Timesheet{
+		UserID:   userID,
+		From:     query.From,
+		To:       query.To,
+		Timezone: loc.String(),
+		Days:     []entity.TimesheetDay{},
+		Tasks:    []entity.TimesheetTask{},
+		Entries:  entries,
+	}
+
+	days := make(map[string]int)
+	tasks := make(map[int64]int)
+	for i := range entries {
+		e := &entries[i]
+		e.Date = e.StartedAt.In(loc).Format(entity.DateLayout)
+		sheet.TotalSeconds += e.DurationSeconds
+
+		if _, ok := days[e.Date]; !ok {
+			days[e.Date] = len(sheet.Days)
+			sheet.Days = append(sheet.Days, entity.TimesheetDay{Date: e.Date})
+		}
+
+		sheet.Days[days[e.Date]].TotalSeconds += e.DurationSeconds
+
+		if _, ok := tasks[e.TaskID]; !ok {
+			tasks[e.TaskID] = len(sheet.Tasks)
+			sheet.Tasks = append(sheet.Tasks, entity.TimesheetTask{TaskID: e.TaskID, TaskTitle: e.TaskTitle})
+		}
+
+		sheet.Tasks[tasks[e.TaskID]].TotalSeconds += e.DurationSeconds
+	}
+
+	sort.Slice(sheet.Tasks, func(i, j int) bool { return sheet.Tasks[i].TaskID < sheet.Tasks[j].TaskID })
+	return sheet, nil
+}
@@ -0,0 +1,234 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"task-management-backend/internal/domain/entity"
+	"task-management-backend/internal/domain/ports"
+)
+
+// TaskLister runs task list queries through the task use case, and with it the task cache.
+type TaskLister interface {
+	GetTasks(userID int64, query entity.TaskQuery) ([]entity.Task, error)
+	ValidateQuery(userID int64, query entity.TaskQuery) error
+}
+
+type ViewUseCase struct {
+	repo        ports.ViewRepository
+	projectRepo ports.ProjectRepository
+	fieldRepo   ports.CustomFieldRepository
+	tasks       TaskLister
+}
+
+func NewViewUseCase(repo ports.ViewRepository, projectRepo ports.ProjectRepository, fieldRepo ports.CustomFieldRepository, tasks TaskLister) *ViewUseCase {
+	return &ViewUseCase{
+		repo:        repo,
+		projectRepo: projectRepo,
+		fieldRepo:   fieldRepo,
+		tasks:       tasks,
+	}
+}
+
+func (uc *ViewUseCase) CreateView(userID int64, req entity.CreateViewRequest) (*entity.SavedView, error) {
+	view := &entity.SavedView{
+		OwnerID:   userID,
+		ProjectID: req.ProjectID,
+		Name:      strings.TrimSpace(req.Name),
+		Shared:    req.Shared,
+		Filter:    req.Filter,
+		Sort:      req.Sort,
+		GroupBy:   req.GroupBy,
+		Fields:    req.Fields,
+	}
+
+	if err := uc.validateView(userID, view); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Create(view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// GetViews lists the user's own views and the views shared with the user's projects,
+// optionally only those of a project.
+func (uc *ViewUseCase) GetViews(userID int64, projectID *int64) ([]entity.SavedView, error) {
+	return uc.repo.GetVisible(userID, projectID)
+}
+
+func (uc *ViewUseCase) GetView(userID, viewID int64) (*entity.SavedView, error) {
+	return uc.repo.GetByID(viewID, userID)
+}
+
+// UpdateView changes a view the user owns. Views shared with the user can be run but not
+// changed.
+func (uc *ViewUseCase) UpdateView(userID, viewID int64, req entity.UpdateViewRequest) (*entity.SavedView, error) {
+	view, err := uc.repo.GetOwned(viewID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		view.Name = strings.TrimSpace(*req.Name)
+	}
+
+	if req.ProjectID != nil {
+		view.ProjectID = req.ProjectID
+		if *req.ProjectID == 0 {
+			view.ProjectID = nil
+		}
+	}
+
+	if req.Shared != nil {
+		view.Shared = *req.Shared
+	}
+
+	if req.Filter != nil {
+		view.Filter = *req.Filter
+	}
+
+	if req.Sort != nil {
+		view.Sort = *req.Sort
+	}
+
+	if req.GroupBy != nil {
+		view.GroupBy = *req.GroupBy
+	}
+
+	if req.Fields != nil {
+		view.Fields = *req.Fields
+	}
+
+	if err := uc.validateView(userID, view); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// DeleteView removes a view the user owns.
+func (uc *ViewUseCase) DeleteView(userID, viewID int64) error {
+	return uc.repo.Delete(viewID, userID)
+}
+
+// RunView lists the caller's tasks matching the view. The query goes through the task use
+// case unchanged, so a view that is a plain status list is served from the same cache entry
+// as GET /api/tasks; grouping and fields are applied to the result and never become part
+// of a cache key.
+func (uc *ViewUseCase) RunView(userID, viewID int64) (*entity.ViewResult, error) {
+	view, err := uc.repo.GetByID(viewID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := uc.tasks.GetTasks(userID, view.TaskQuery())
+	if err != nil {
+		return nil, err
+	}
+
+	if view.GroupBy == "" {
+		return &entity.ViewResult{View: view, Tasks: tasks}, nil
+	}
+
+	return &entity.ViewResult{View: view, Groups: groupTasks(tasks, view.GroupBy)}, nil
+}
+
+func (uc *ViewUseCase) validateView(userID int64, view *entity.SavedView) error {
+	if view.Name == "" {
+		return fmt.Errorf("view name cannot be empty")
+	}
+
+	if view.Shared && view.ProjectID == nil {
+		return fmt.Errorf("shared views require a project_id")
+	}
+
+	if err := entity.ValidateTaskFields(view.Fields); err != nil {
+		return err
+	}
+
+	if err := uc.validateGroupBy(view); err != nil {
+		return err
+	}
+
+	// also checks access to the project
+	return uc.tasks.ValidateQuery(userID, view.TaskQuery())
+}
+
+func (uc *ViewUseCase) validateGroupBy(view *entity.SavedView) error {
+	switch view.GroupBy {
+	case "", "status", "priority", "project_id":
+		return nil
+	}
+
+	key, ok := strings.CutPrefix(view.GroupBy, "cf.")
+	if !ok {
+		return fmt.Errorf("invalid group_by: %s", view.GroupBy)
+	}
+
+	if view.ProjectID == nil {
+		return fmt.Errorf("grouping by a custom field requires a project_id")
+	}
+
+	fields, err := uc.fieldRepo.GetByProjectID(*view.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if field.Key == key {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown custom field: %s", key)
+}
+
+// groupTasks groups root tasks by the field, in the order each group first appears in the
+// sorted list. Subtasks stay with their root.
+func groupTasks(tasks []entity.Task, groupBy string) []entity.TaskGroup {
+	groups := []entity.TaskGroup{}
+	index := map[string]int{}
+	for _, task := range tasks {
+		key := groupKey(task, groupBy)
+		id, err := json.Marshal(key)
+		if err != nil {
+			id = []byte(fmt.Sprint(key))
+		}
+
+		i, ok := index[string(id)]
+		if !ok {
+			i = len(groups)
+			index[string(id)] = i
+			groups = append(groups, entity.TaskGroup{Key: key})
+		}
+
+		groups[i].Tasks = append(groups[i].Tasks, task)
+	}
+
+	return groups
+}
+
+func groupKey(task entity.Task, groupBy string) any {
+	switch groupBy {
+	case "status":
+		return task.Status
+	case "priority":
+		return task.Priority
+	case "project_id":
+		if task.ProjectID == nil {
+			return nil
+		}
+
+		return *task.ProjectID
+	}
+
+	key := strings.TrimPrefix(groupBy, "cf.")
+	return task.CustomFields[key]
+}
@@ -9,3 +9,157 @@ const (
 	TaskStatusAll        TaskStatus = "all"
 	TaskStatusDefault    TaskStatus = ""
 )
+
+// StatusCategory groups workflow statuses by how far along the work is.
+type StatusCategory string
+
+const (
+	StatusCategoryTodo   StatusCategory = "todo"
+	StatusCategoryActive StatusCategory = "active"
+	StatusCategoryDone   StatusCategory = "done"
+)
+
+func (c StatusCategory) IsValid() bool {
+	return c == StatusCategoryTodo || c == StatusCategoryActive || c == StatusCategoryDone
+}
+
+// TransitionGuard is a precondition checked before a status transition is applied.
+type TransitionGuard string
+
+const (
+	// GuardRequiredFields needs every field in the transition's required_fields to be set.
+	GuardRequiredFields TransitionGuard = "required_fields"
+	// GuardSubTasksDone needs every subtask, at any depth, to be in a done status.
+	GuardSubTasksDone TransitionGuard = "subtasks_done"
+	// GuardNoOpenBlockers needs every blocking task to be in a done status.
+	GuardNoOpenBlockers TransitionGuard = "no_open_blockers"
+)
+
+func (g TransitionGuard) IsValid() bool {
+	return g == GuardRequiredFields || g == GuardSubTasksDone || g == GuardNoOpenBlockers
+}
+
+// AnyStatus matches every status on either side of a workflow transition.
+const AnyStatus TaskStatus = "*"
+
+type TaskPriority string
+
+const (
+	TaskPriorityNone   TaskPriority = "none"
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityMedium TaskPriority = "medium"
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityUrgent TaskPriority = "urgent"
+)
+
+// TaskPriorities lists priorities from least to most urgent.
+var TaskPriorities = []TaskPriority{
+	TaskPriorityNone,
+	TaskPriorityLow,
+	TaskPriorityMedium,
+	TaskPriorityHigh,
+	TaskPriorityUrgent,
+}
+
+// Rank returns the position of p in TaskPriorities, or -1 if p is unknown.
+func (p TaskPriority) Rank() int {
+	for i, priority := range TaskPriorities {
+		if priority == p {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (p TaskPriority) IsValid() bool {
+	return p.Rank() >= 0
+}
+
+type ReminderStatus string
+
+const (
+	ReminderStatusPending   ReminderStatus = "pending"
+	ReminderStatusSending   ReminderStatus = "sending"
+	ReminderStatusSent      ReminderStatus = "sent"
+	ReminderStatusFailed    ReminderStatus = "failed"
+	ReminderStatusDismissed ReminderStatus = "dismissed"
+)
+
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp   NotificationChannel = "in_app"
+	NotificationChannelWebhook NotificationChannel = "webhook"
+)
+
+type CustomFieldType string
+
+const (
+	CustomFieldText         CustomFieldType = "text"
+	CustomFieldNumber       CustomFieldType = "number"
+	CustomFieldDate         CustomFieldType = "date"
+	CustomFieldSingleSelect CustomFieldType = "single_select"
+	CustomFieldMultiSelect  CustomFieldType = "multi_select"
+	CustomFieldUser         CustomFieldType = "user"
+)
+
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case CustomFieldText, CustomFieldNumber, CustomFieldDate, CustomFieldSingleSelect, CustomFieldMultiSelect, CustomFieldUser:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSelect reports whether values must be one of the field's options.
+func (t CustomFieldType) IsSelect() bool {
+	return t == CustomFieldSingleSelect || t == CustomFieldMultiSelect
+}
+
+// IsNumeric reports whether values are stored as numbers.
+func (t CustomFieldType) IsNumeric() bool {
+	return t == CustomFieldNumber || t == CustomFieldUser
+}
+
+type WorkLogSource string
+
+const (
+	WorkLogSourceTimer  WorkLogSource = "timer"
+	WorkLogSourceManual WorkLogSource = "manual"
+)
+
+// SprintKind distinguishes time-boxed sprints from milestones, which only have a target date.
+type SprintKind string
+
+const (
+	SprintKindSprint    SprintKind = "sprint"
+	SprintKindMilestone SprintKind = "milestone"
+)
+
+func (k SprintKind) IsValid() bool {
+	return k == SprintKindSprint || k == SprintKindMilestone
+}
+
+// SprintOutcome records what happened to a task when its sprint closed.
+type SprintOutcome string
+
+const (
+	SprintOutcomeDone        SprintOutcome = "done"
+	SprintOutcomeCarriedOver SprintOutcome = "carried_over"
+	SprintOutcomeBacklog     SprintOutcome = "backlog"
+)
+
+// UndoAction is the kind of task mutation an undo entry reverts.
+type UndoAction string
+
+const (
+	UndoActionCreate    UndoAction = "create"
+	UndoActionUpdate    UndoAction = "update"
+	UndoActionMove      UndoAction = "move"
+	UndoActionDelete    UndoAction = "delete"
+	UndoActionRestore   UndoAction = "restore"
+	UndoActionArchive   UndoAction = "archive"
+	UndoActionUnarchive UndoAction = "unarchive"
+)
@@ -0,0 +1,86 @@
+// Package netguard keeps outgoing requests to user-supplied URLs, such as reminder webhooks,
+// away from the server's own network. Loopback, private, link-local, multicast and
+// unspecified addresses are refused.
+//
+// CheckURL gives an early error when a URL is saved. The URL's host can resolve differently
+// by the time it is called, so Control must also be set on the dialer that sends the
+// request; it checks the address actually being connected to.
+package netguard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range, which is not public either.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Allowed reports whether ip is a public unicast address.
+func Allowed(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// CheckURL checks that rawURL is an absolute http(s) URL whose host resolves only to
+// allowed addresses.
+func CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("%q is not a valid http(s) URL", rawURL)
+	}
+
+	host := u.Hostname()
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if !Allowed(ip) {
+			return fmt.Errorf("%s is not a public address", host)
+		}
+
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("cannot resolve %s: %w", host, err)
+	}
+
+	for _, ip := range addrs {
+		if !Allowed(ip) {
+			return fmt.Errorf("%s resolves to %s, which is not a public address", host, ip.Unmap())
+		}
+	}
+
+	return nil
+}
+
+// Control is a net.Dialer Control function that refuses connections to addresses that are
+// not allowed. It runs after name resolution, for every address tried.
+func Control(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	if !Allowed(addrPort.Addr()) {
+		return fmt.Errorf("connection to %s refused: not a public address", addrPort.Addr().Unmap())
+	}
+
+	return nil
+}
+
+// Dialer returns a dialer that only connects to allowed addresses.
+func Dialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{Timeout: timeout, Control: Control}
+}
@@ -0,0 +1,77 @@
+package netguard
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+)
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"127.8.9.10", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"fd00::1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"224.0.0.1", false},
+		{"100.64.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := Allowed(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("Allowed(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url string
+		ok  bool
+	}{
+		{"https://93.184.216.34/hook", true},
+		{"http://127.0.0.1:8080/hook", false},
+		{"http://[::1]/hook", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://localhost/hook", false},
+		{"ftp://93.184.216.34/hook", false},
+		{"/hook", false},
+		{"http:///hook", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := CheckURL(context.Background(), tt.url)
+			if (err == nil) != tt.ok {
+				t.Errorf("CheckURL(%q) error = %v, want ok %v", tt.url, err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestControl(t *testing.T) {
+	if err := Control("tcp4", "93.184.216.34:443", nil); err != nil {
+		t.Errorf("public address refused: %v", err)
+	}
+
+	for _, address := range []string{"127.0.0.1:80", "[::1]:80", "10.0.0.5:443", "[fe80::1]:443"} {
+		if err := Control("tcp", address, nil); err == nil {
+			t.Errorf("Control(%s) allowed a non-public address", address)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+// Package query parses the task filter language, for example
+//
+//	status:"in progress" AND (priority>=high OR cf.team:backend) AND due<2026-11-01
+//
+// A filter is a list of comparisons of the form field operator value, combined with AND,
+// OR, NOT and parentheses. Comparisons next to each other without an operator are joined
+// with AND. NOT binds tighter than AND, and AND tighter than OR. Values are bare words or
+// double quoted strings, in which \" and \\ are escapes. The keywords are case-insensitive;
+// quote a value to use a keyword as a value.
+//
+// The package only knows the syntax. Field names and values are checked by the caller,
+// which reports its own errors with the positions kept in the tree.
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxLength and MaxDepth bound the size of a filter.
+const (
+	MaxLength = 2000
+	MaxDepth  = 32
+)
+
+type Operator string
+
+const (
+	// OpMatch is the ":" operator. Its meaning depends on the field, e.g. "contains" for text.
+	OpMatch        Operator = ":"
+	OpEqual        Operator = "="
+	OpNotEqual     Operator = "!="
+	OpLess         Operator = "<"
+	OpLessEqual    Operator = "<="
+	OpGreater      Operator = ">"
+	OpGreaterEqual Operator = ">="
+)
+
+// Node is an expression of the filter. Pos is the 1-based character position where it
+// starts.
+type Node interface {
+	Pos() int
+}
+
+type And struct {
+	Left, Right Node
+}
+
+type Or struct {
+	Left, Right Node
+}
+
+type Not struct {
+	Expr Node
+	At   int
+}
+
+type Comparison struct {
+	Field    string
+	Op       Operator
+	Value    string
+	FieldPos int
+	OpPos    int
+	ValuePos int
+	// Quoted is set when the value was a quoted string.
+	Quoted bool
+}
+
+func (n *And) Pos() int        { return n.Left.Pos() }
+func (n *Or) Pos() int         { return n.Left.Pos() }
+func (n *Not) Pos() int        { return n.At }
+func (n *Comparison) Pos() int { return n.FieldPos }
+
+// Error is a syntax or validation error at a 1-based character position.
+type Error struct {
+	Pos     int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Pos)
+}
+
+func Errorf(pos int, format string, args ...any) *Error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) describe() string {
+	if t.kind == tokenEOF {
+		return "end of filter"
+	}
+
+	return fmt.Sprintf("%q", t.text)
+}
+
+// Parse parses a filter into its expression tree.
+func Parse(input string) (Node, error) {
+	if utf8.RuneCountInString(input) > MaxLength {
+		return nil, Errorf(MaxLength+1, "filter is longer than %d characters", MaxLength)
+	}
+
+	p := &parser{input: input}
+	if err := p.advance(false); err != nil {
+		return nil, err
+	}
+
+	if p.token.kind == tokenEOF {
+		return nil, Errorf(1, "filter is empty")
+	}
+
+	node, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.token.kind == tokenRightParen {
+		return nil, Errorf(p.token.pos, "unexpected \")\" without matching \"(\"")
+	}
+
+	if p.token.kind != tokenEOF {
+		return nil, Errorf(p.token.pos, "unexpected %s", p.token.describe())
+	}
+
+	return node, nil
+}
+
+type parser struct {
+	input string
+	// offset is the byte offset of the next character to scan.
+	offset int
+	token  token
+}
+
+func (p *parser) parseOr(depth int) (Node, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.token.kind == tokenOr {
+		if err := p.advance(false); err != nil {
+			return nil, err
+		}
+
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+
+		left = &Or{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd(depth int) (Node, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch p.token.kind {
+		case tokenAnd:
+			if err := p.advance(false); err != nil {
+				return nil, err
+			}
+		case tokenWord, tokenString, tokenLeftParen, tokenNot:
+			// implicit AND
+		default:
+			return left, nil
+		}
+
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+
+		left = &And{Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseUnary(depth int) (Node, error) {
+	if depth >= MaxDepth {
+		return nil, Errorf(p.token.pos, "filter is nested more than %d levels deep", MaxDepth)
+	}
+
+	switch p.token.kind {
+	case tokenNot:
+		at := p.token.pos
+		if err := p.advance(false); err != nil {
+			return nil, err
+		}
+
+		expr, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Not{Expr: expr, At: at}, nil
+	case tokenLeftParen:
+		open := p.token.pos
+		if err := p.advance(false); err != nil {
+			return nil, err
+		}
+
+		if p.token.kind == tokenRightParen {
+			return nil, Errorf(p.token.pos, "empty parentheses")
+		}
+
+		expr, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.token.kind != tokenRightParen {
+			return nil, Errorf(open, "missing \")\" for this \"(\"")
+		}
+
+		if err := p.advance(false); err != nil {
+			return nil, err
+		}
+
+		return expr, nil
+	case tokenWord:
+		return p.parseComparison()
+	case tokenEOF:
+		return nil, Errorf(p.token.pos, "expected a comparison, found end of filter")
+	}
+
+	return nil, Errorf(p.token.pos, "expected a comparison, found %s", p.token.describe())
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	field := p.token
+	if err := p.advance(false); err != nil {
+		return nil, err
+	}
+
+	if p.token.kind != tokenOperator {
+		if p.token.kind == tokenEOF {
+			return nil, Errorf(p.token.pos, "expected an operator after %q", field.text)
+		}
+
+		return nil, Errorf(p.token.pos, "expected an operator after %q, found %s", field.text, p.token.describe())
+	}
+
+	op, opPos := Operator(p.token.text), p.token.pos
+	if err := p.advance(true); err != nil {
+		return nil, err
+	}
+
+	value := p.token
+	if value.kind != tokenWord && value.kind != tokenString {
+		if value.kind == tokenEOF {
+			return nil, Errorf(value.pos, "expected a value after %q", field.text+string(op))
+		}
+
+		return nil, Errorf(value.pos, "expected a value after %q, found %s", field.text+string(op), value.describe())
+	}
+
+	if err := p.advance(false); err != nil {
+		return nil, err
+	}
+
+	return &Comparison{
+		Field:    field.text,
+		Op:       op,
+		Value:    value.text,
+		FieldPos: field.pos,
+		OpPos:    opPos,
+		ValuePos: value.pos,
+		Quoted:   value.kind == tokenString,
+	}, nil
+}
+
+// advance scans the next token. In a value, keywords are plain words and ":" does not
+// end a word, so timestamps such as 2026-11-01T09:00:00Z need no quotes.
+func (p *parser) advance(value bool) error {
+	for p.offset < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.offset:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+
+		p.offset += size
+	}
+
+	pos := p.position(p.offset)
+	if p.offset >= len(p.input) {
+		p.token = token{kind: tokenEOF, pos: pos}
+		return nil
+	}
+
+	rest := p.input[p.offset:]
+	switch {
+	case rest[0] == '(':
+		p.offset++
+		p.token = token{kind: tokenLeftParen, text: "(", pos: pos}
+		return nil
+	case rest[0] == ')':
+		p.offset++
+		p.token = token{kind: tokenRightParen, text: ")", pos: pos}
+		return nil
+	case rest[0] == '"':
+		return p.scanString(pos)
+	}
+
+	for _, op := range []Operator{OpNotEqual, OpLessEqual, OpGreaterEqual, OpMatch, OpEqual, OpLess, OpGreater} {
+		if !value && strings.HasPrefix(rest, string(op)) {
+			p.offset += len(op)
+			p.token = token{kind: tokenOperator, text: string(op), pos: pos}
+			return nil
+		}
+	}
+
+	if rest[0] == '!' {
+		return Errorf(pos, "unexpected \"!\", did you mean \"!=\"")
+	}
+
+	end := 0
+	for end < len(rest) {
+		r, size := utf8.DecodeRuneInString(rest[end:])
+		if unicode.IsSpace(r) || strings.ContainsRune(`()"<>=!`, r) || (!value && r == ':') {
+			break
+		}
+
+		end += size
+	}
+
+	if end == 0 {
+		r, _ := utf8.DecodeRuneInString(rest)
+		return Errorf(pos, "unexpected %q", string(r))
+	}
+
+	word := rest[:end]
+	p.offset += end
+	p.token = token{kind: tokenWord, text: word, pos: pos}
+	if !value {
+		switch strings.ToUpper(word) {
+		case "AND":
+			p.token.kind = tokenAnd
+		case "OR":
+			p.token.kind = tokenOr
+		case "NOT":
+			p.token.kind = tokenNot
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) scanString(pos int) error {
+	var b strings.Builder
+	for i := p.offset + 1; i < len(p.input); i++ {
+		switch c := p.input[i]; c {
+		case '"':
+			p.offset = i + 1
+			p.token = token{kind: tokenString, text: b.String(), pos: pos}
+			return nil
+		case '\\':
+			if i+1 < len(p.input) && (p.input[i+1] == '"' || p.input[i+1] == '\\') {
+				i++
+				b.WriteByte(p.input[i])
+				continue
+			}
+
+			return Errorf(p.position(i), "invalid escape in string; only \\\" and \\\\ are allowed")
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return Errorf(pos, "unterminated string")
+}
+
+// position converts a byte offset to a 1-based character position.
+func (p *parser) position(offset int) int {
+	return utf8.RuneCountInString(p.input[:offset]) + 1
+}
@@ -0,0 +1,199 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// format renders a tree with explicit grouping, so tests can compare its shape.
+func format(node Node) string {
+	switch n := node.(type) {
+	case *And:
+		return "(" + format(n.Left) + " AND " + format(n.Right) + ")"
+	case *Or:
+		return "(" + format(n.Left) + " OR " + format(n.Right) + ")"
+	case *Not:
+		return "NOT " + format(n.Expr)
+	case *Comparison:
+		value := n.Value
+		if n.Quoted {
+			value = fmt.Sprintf("%q", value)
+		}
+
+		return n.Field + string(n.Op) + value
+	}
+
+	return fmt.Sprintf("%T", node)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"comparison", "status:done", "status:done"},
+		{"operators", "a=1 b!=2 c<3 d<=4 e>5 f>=6", "(((((a=1 AND b!=2) AND c<3) AND d<=4) AND e>5) AND f>=6)"},
+		{"spaces around operator", "priority >= high", "priority>=high"},
+		{"and binds tighter than or", "a:1 OR b:2 AND c:3", "(a:1 OR (b:2 AND c:3))"},
+		{"or then and", "a:1 AND b:2 OR c:3", "((a:1 AND b:2) OR c:3)"},
+		{"parentheses", "(a:1 OR b:2) AND c:3", "((a:1 OR b:2) AND c:3)"},
+		{"implicit and", "a:1 b:2", "(a:1 AND b:2)"},
+		{"implicit and binds tighter than or", "a:1 b:2 OR c:3", "((a:1 AND b:2) OR c:3)"},
+		{"implicit and before parentheses", "a:1 (b:2 OR c:3)", "(a:1 AND (b:2 OR c:3))"},
+		{"implicit and before not", "a:1 NOT b:2", "(a:1 AND NOT b:2)"},
+		{"not binds tighter than and", "NOT a:1 AND b:2", "(NOT a:1 AND b:2)"},
+		{"not binds tighter than or", "NOT a:1 OR b:2", "(NOT a:1 OR b:2)"},
+		{"not of a group", "NOT (a:1 OR b:2)", "NOT (a:1 OR b:2)"},
+		{"double not", "NOT NOT a:1", "NOT NOT a:1"},
+		{"keywords are case-insensitive", "a:1 and b:2 or not c:3", "((a:1 AND b:2) OR NOT c:3)"},
+		{"keyword as a value", "title:and", "title:and"},
+		{"quoted keyword", `title:"OR"`, `title:"OR"`},
+		{"quoted value", `status:"in progress"`, `status:"in progress"`},
+		{"escaped quote", `title:"say \"hi\""`, `title:"say \"hi\""`},
+		{"escaped backslash", `title:"a\\b"`, `title:"a\\b"`},
+		{"empty string", `title:""`, `title:""`},
+		{"colon in value", "due>=2026-11-01T09:00:00Z", "due>=2026-11-01T09:00:00Z"},
+		{"dotted field", "cf.team:backend", "cf.team:backend"},
+		{"unicode", "title:café", "title:café"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+
+			if got := format(node); got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePositions(t *testing.T) {
+	node, err := Parse(`NOT  é:1 AND status != "to do"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	and := node.(*And)
+	not := and.Left.(*Not)
+	if not.Pos() != 1 {
+		t.Errorf("NOT position = %d, want 1", not.Pos())
+	}
+
+	first := not.Expr.(*Comparison)
+	if first.FieldPos != 6 || first.OpPos != 7 || first.ValuePos != 8 {
+		t.Errorf("first comparison positions = %d, %d, %d, want 6, 7, 8", first.FieldPos, first.OpPos, first.ValuePos)
+	}
+
+	second := and.Right.(*Comparison)
+	if second.FieldPos != 14 || second.OpPos != 21 || second.ValuePos != 24 {
+		t.Errorf("second comparison positions = %d, %d, %d, want 14, 21, 24", second.FieldPos, second.OpPos, second.ValuePos)
+	}
+
+	if !second.Quoted || second.Value != "to do" {
+		t.Errorf("second value = %q (quoted %v), want quoted \"to do\"", second.Value, second.Quoted)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		pos     int
+		message string
+	}{
+		{"empty", "", 1, "filter is empty"},
+		{"blank", "   ", 1, "filter is empty"},
+		{"missing operator", "status", 7, `expected an operator after "status"`},
+		{"operator missing between", "status done", 8, `expected an operator after "status", found "done"`},
+		{"missing value", "status:", 8, `expected a value after "status:"`},
+		{"value is a parenthesis", "status:)", 8, `expected a value after "status:", found ")"`},
+		{"leading operator", ":done", 1, `expected a comparison, found ":"`},
+		{"trailing and", "a:1 AND", 8, "expected a comparison, found end of filter"},
+		{"double and", "a:1 AND AND b:2", 9, `expected a comparison, found "AND"`},
+		{"trailing not", "a:1 NOT", 8, "expected a comparison, found end of filter"},
+		{"unmatched close", "a:1)", 4, `unexpected ")" without matching "("`},
+		{"unclosed group", "a:1 AND (b:2 OR c:3", 9, `missing ")" for this "("`},
+		{"empty group", "a:1 ()", 6, "empty parentheses"},
+		{"bang", "a!1", 2, `unexpected "!", did you mean "!="`},
+		{"stray operator", "a:1 <", 5, `unexpected "<"`},
+		{"unterminated string", `title:"open`, 7, "unterminated string"},
+		{"unterminated after escape", `title:"a\"`, 7, "unterminated string"},
+		{"invalid escape", `title:"a\nb"`, 9, `invalid escape in string; only \" and \\ are allowed`},
+		{"position counts characters", `é:"x`, 3, "unterminated string"},
+		{"string as field", `"status":done`, 1, `expected a comparison, found "status"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var qerr *Error
+			if !errors.As(err, &qerr) {
+				t.Fatalf("Parse(%q) error = %v, want *Error", tt.input, err)
+			}
+
+			if qerr.Pos != tt.pos || qerr.Message != tt.message {
+				t.Errorf("Parse(%q) error = %q at %d, want %q at %d", tt.input, qerr.Message, qerr.Pos, tt.message, tt.pos)
+			}
+		})
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	t.Run("max length", func(t *testing.T) {
+		// multi-byte characters count once
+		input := "title:" + strings.Repeat("é", MaxLength-len("title:"))
+		if _, err := Parse(input); err != nil {
+			t.Fatalf("filter of %d characters: %v", MaxLength, err)
+		}
+
+		_, err := Parse(input + "é")
+		var qerr *Error
+		if !errors.As(err, &qerr) || qerr.Pos != MaxLength+1 {
+			t.Fatalf("filter of %d characters: error = %v, want position %d", MaxLength+1, err, MaxLength+1)
+		}
+	})
+
+	t.Run("max depth of parentheses", func(t *testing.T) {
+		nested := func(n int) string { return strings.Repeat("(", n) + "a:1" + strings.Repeat(")", n) }
+		if _, err := Parse(nested(MaxDepth - 1)); err != nil {
+			t.Fatalf("%d levels: %v", MaxDepth-1, err)
+		}
+
+		_, err := Parse(nested(MaxDepth))
+		var qerr *Error
+		if !errors.As(err, &qerr) || qerr.Pos != MaxDepth+1 || qerr.Message != fmt.Sprintf("filter is nested more than %d levels deep", MaxDepth) {
+			t.Fatalf("%d levels: error = %v", MaxDepth, err)
+		}
+	})
+
+	t.Run("max depth of not", func(t *testing.T) {
+		if _, err := Parse(strings.Repeat("NOT ", MaxDepth-1) + "a:1"); err != nil {
+			t.Fatalf("%d NOTs: %v", MaxDepth-1, err)
+		}
+
+		if _, err := Parse(strings.Repeat("NOT ", MaxDepth) + "a:1"); err == nil {
+			t.Fatalf("%d NOTs: expected an error", MaxDepth)
+		}
+	})
+
+	t.Run("long flat filters are not nested", func(t *testing.T) {
+		input := strings.TrimSuffix(strings.Repeat("a:1 OR ", 100), " OR ")
+		if _, err := Parse(input); err != nil {
+			t.Fatalf("flat filter: %v", err)
+		}
+	})
+}
+
+func TestErrorString(t *testing.T) {
+	err := Errorf(5, "unknown field %q", "x")
+	if got, want := err.Error(), `unknown field "x" at position 5`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
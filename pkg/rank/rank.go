@@ -0,0 +1,282 @@
+// Package rank generates fractional index keys for manually ordered lists.
+//
+// Keys are base-62 strings that sort correctly with plain byte comparison, so a list is
+// ordered by its key column alone. A key has an integer part, whose first character
+// encodes its length, followed by an optional fraction. Appending or prepending only
+// increments or decrements the integer part, which keeps keys short, and inserting
+// between two neighbours extends the fraction. Moving an item therefore only rewrites its
+// own key and never renumbers the rest of the list.
+package rank
+
+import (
+	"fmt"
+	"strings"
+)
+
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// smallestInteger is the lowest integer part; it cannot be decremented.
+var smallestInteger = "A" + strings.Repeat(digits[:1], 26)
+
+// Between returns a key that sorts strictly between a and b. An empty a means the start of
+// the list and an empty b the end, so Between("", "") returns the first key of a list.
+func Between(a, b string) (string, error) {
+	if a != "" {
+		if err := validate(a); err != nil {
+			return "", err
+		}
+	}
+
+	if b != "" {
+		if err := validate(b); err != nil {
+			return "", err
+		}
+	}
+
+	if a != "" && b != "" && a >= b {
+		return "", fmt.Errorf("rank %q must sort before %q", a, b)
+	}
+
+	if a == "" {
+		if b == "" {
+			return "a" + digits[:1], nil
+		}
+
+		ib, _ := integerPart(b)
+		fb := b[len(ib):]
+		if ib == smallestInteger {
+			return ib + midpoint("", fb), nil
+		}
+
+		if ib < b {
+			return ib, nil
+		}
+
+		key, ok := decrement(ib)
+		if !ok {
+			return "", fmt.Errorf("cannot rank before %q", b)
+		}
+
+		// the smallest integer part is not a valid key on its own
+		if key == smallestInteger {
+			return key + midpoint("", ""), nil
+		}
+
+		return key, nil
+	}
+
+	ia, _ := integerPart(a)
+	fa := a[len(ia):]
+	if b == "" {
+		if key, ok := increment(ia); ok {
+			return key, nil
+		}
+
+		return ia + midpoint(fa, ""), nil
+	}
+
+	ib, _ := integerPart(b)
+	fb := b[len(ib):]
+	if ia == ib {
+		return ia + midpoint(fa, fb), nil
+	}
+
+	key, ok := increment(ia)
+	if !ok {
+		return "", fmt.Errorf("cannot rank after %q", a)
+	}
+
+	if key < b {
+		return key, nil
+	}
+
+	return ia + midpoint(fa, ""), nil
+}
+
+// Sequence returns n ascending keys after a, which may be empty for the start of a list.
+func Sequence(a string, n int) ([]string, error) {
+	keys := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		key, err := Between(a, "")
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+		a = key
+	}
+
+	return keys, nil
+}
+
+// midpoint returns a fraction between a and b, where an empty b is unbounded. Fractions
+// never end in the zero digit, which keeps a key below every longer key it prefixes.
+func midpoint(a, b string) string {
+	if b != "" {
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(digits, a[0])
+	}
+
+	digitB := len(digits)
+	if b != "" {
+		digitB = strings.IndexByte(digits, b[0])
+	}
+
+	if digitB-digitA > 1 {
+		return string(digits[(digitA+digitB+1)/2])
+	}
+
+	if len(b) > 1 {
+		return b[:1]
+	}
+
+	rest := ""
+	if a != "" {
+		rest = a[1:]
+	}
+
+	return string(digits[digitA]) + midpoint(rest, "")
+}
+
+func digitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+
+	return digits[0]
+}
+
+// integerLength decodes the length of the integer part from its first character: a-z
+// are the positive lengths 2-27 and Z-A the negative ones.
+func integerLength(head byte) (int, error) {
+	switch {
+	case head >= 'a' && head <= 'z':
+		return int(head-'a') + 2, nil
+	case head >= 'A' && head <= 'Z':
+		return int('Z'-head) + 2, nil
+	}
+
+	return 0, fmt.Errorf("invalid rank head %q", head)
+}
+
+func integerPart(key string) (string, error) {
+	n, err := integerLength(key[0])
+	if err != nil {
+		return "", err
+	}
+
+	if n > len(key) {
+		return "", fmt.Errorf("invalid rank %q", key)
+	}
+
+	return key[:n], nil
+}
+
+func validate(key string) error {
+	if key == smallestInteger {
+		return fmt.Errorf("invalid rank %q", key)
+	}
+
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return fmt.Errorf("invalid rank %q", key)
+		}
+	}
+
+	integer, err := integerPart(key)
+	if err != nil {
+		return err
+	}
+
+	if len(key) > len(integer) && key[len(key)-1] == digits[0] {
+		return fmt.Errorf("invalid rank %q", key)
+	}
+
+	return nil
+}
+
+// increment returns the next integer part, or false past the largest one.
+func increment(integer string) (string, bool) {
+	head, digs := integer[0], []byte(integer[1:])
+	carry := true
+	for i := len(digs) - 1; carry && i >= 0; i-- {
+		d := strings.IndexByte(digits, digs[i]) + 1
+		if d == len(digits) {
+			digs[i] = digits[0]
+		} else {
+			digs[i] = digits[d]
+			carry = false
+		}
+	}
+
+	if !carry {
+		return string(head) + string(digs), true
+	}
+
+	switch head {
+	case 'Z':
+		return "a" + digits[:1], true
+	case 'z':
+		return "", false
+	}
+
+	head++
+	if head > 'a' {
+		digs = append(digs, digits[0])
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+
+	return string(head) + string(digs), true
+}
+
+// decrement returns the previous integer part, or false below the smallest one.
+func decrement(integer string) (string, bool) {
+	head, digs := integer[0], []byte(integer[1:])
+	borrow := true
+	for i := len(digs) - 1; borrow && i >= 0; i-- {
+		d := strings.IndexByte(digits, digs[i]) - 1
+		if d == -1 {
+			digs[i] = digits[len(digits)-1]
+		} else {
+			digs[i] = digits[d]
+			borrow = false
+		}
+	}
+
+	if !borrow {
+		return string(head) + string(digs), true
+	}
+
+	switch head {
+	case 'a':
+		return "Z" + digits[len(digits)-1:], true
+	case 'A':
+		return "", false
+	}
+
+	head--
+	if head < 'Z' {
+		digs = append(digs, digits[len(digits)-1])
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+
+	return string(head) + string(digs), true
+}
@@ -0,0 +1,162 @@
+package rank
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	largestInteger := "z" + strings.Repeat("z", 27)
+
+	tests := []struct {
+		name string
+		a, b string
+		want string
+		err  string
+	}{
+		{name: "empty list", want: "a0"},
+		{name: "append", a: "a0", want: "a1"},
+		{name: "append carries", a: "az", want: "b00"},
+		{name: "append from negative", a: "Zz", want: "a0"},
+		{name: "append past largest integer", a: largestInteger, want: largestInteger + "V"},
+		{name: "prepend", b: "a0", want: "Zz"},
+		{name: "prepend drops fraction", b: "a0V", want: "a0"},
+		{name: "prepend before smallest integer", b: smallestInteger + "1", want: smallestInteger + "0V"},
+		{name: "prepend onto smallest integer", b: "A" + strings.Repeat("0", 25) + "1", want: smallestInteger + "V"},
+		{name: "gap between integers", a: "a0", b: "a2", want: "a1"},
+		{name: "adjacent integers", a: "a0", b: "a1", want: "a0V"},
+		{name: "below fraction", a: "a0", b: "a0V", want: "a0G"},
+		{name: "above fraction", a: "a0V", b: "a1", want: "a0l"},
+		{name: "last digit", a: "a0z", b: "a1", want: "a0zV"},
+		{name: "adjacent fractions", a: "a1V", b: "a1W", want: "a1VV"},
+		{name: "equal keys", a: "a0", b: "a0", err: `rank "a0" must sort before "a0"`},
+		{name: "reversed keys", a: "a1", b: "a0", err: `rank "a1" must sort before "a0"`},
+		{name: "short integer", a: "a", err: `invalid rank "a"`},
+		{name: "bad head", b: "!0", err: `invalid rank "!0"`},
+		{name: "bad digit", a: "a0-", err: `invalid rank "a0-"`},
+		{name: "trailing zero", a: "a00", err: `invalid rank "a00"`},
+		{name: "smallest integer", b: smallestInteger, err: `invalid rank "` + smallestInteger + `"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.a, tt.b)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("Between(%q, %q) error = %v, want %q", tt.a, tt.b, err, tt.err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Between(%q, %q) error = %v", tt.a, tt.b, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Between(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+			}
+
+			if err := validate(got); err != nil {
+				t.Errorf("Between(%q, %q) = %q, which is not a valid key: %v", tt.a, tt.b, got, err)
+			}
+		})
+	}
+}
+
+// TestBetweenExhaustion inserts repeatedly at the same spot, which only a fraction can
+// absorb, and checks every key stays valid and strictly between its neighbours.
+func TestBetweenExhaustion(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		// down inserts each key below the previous one instead of above it
+		down bool
+	}{
+		{name: "towards the lower key", a: "a0", b: "a1", down: true},
+		{name: "towards the upper key", a: "a0", b: "a1"},
+		{name: "prepend at smallest integer", b: smallestInteger + "1", down: true},
+		{name: "prepend across zero", b: "a1", down: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := tt.a, tt.b
+			for i := 0; i < 500; i++ {
+				key, err := Between(a, b)
+				if err != nil {
+					t.Fatalf("insert %d: Between(%q, %q) error = %v", i, a, b, err)
+				}
+
+				if err := validate(key); err != nil {
+					t.Fatalf("insert %d: Between(%q, %q) = %q, which is not a valid key: %v", i, a, b, key, err)
+				}
+
+				if (a != "" && key <= a) || key >= b {
+					t.Fatalf("insert %d: Between(%q, %q) = %q, which is out of order", i, a, b, key)
+				}
+
+				if tt.down {
+					b = key
+				} else {
+					a = key
+				}
+			}
+		})
+	}
+}
+
+func TestSequence(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		n    int
+		want []string
+	}{
+		{name: "none", a: "a5", n: 0, want: []string{}},
+		{name: "start of list", n: 3, want: []string{"a0", "a1", "a2"}},
+		{name: "after key", a: "a0V", n: 2, want: []string{"a1", "a2"}},
+		{name: "across integer length", a: "ay", n: 3, want: []string{"az", "b00", "b01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Sequence(tt.a, tt.n)
+			if err != nil {
+				t.Fatalf("Sequence(%q, %d) error = %v", tt.a, tt.n, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sequence(%q, %d) = %q, want %q", tt.a, tt.n, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Sequence("a00", 1); err == nil {
+		t.Error("Sequence accepted an invalid key")
+	}
+}
+
+// TestSequenceRebalances checks that a long run of fresh keys, as used to space out
+// siblings whose ranks collided, stays short, valid and ascending.
+func TestSequenceRebalances(t *testing.T) {
+	keys, err := Sequence("", 5000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, key := range keys {
+		if err := validate(key); err != nil {
+			t.Fatalf("key %d = %q is not valid: %v", i, key, err)
+		}
+
+		if i > 0 && key <= keys[i-1] {
+			t.Fatalf("key %d = %q does not sort after %q", i, key, keys[i-1])
+		}
+
+		if len(key) > 4 {
+			t.Fatalf("key %d = %q is longer than an integer part needs", i, key)
+		}
+	}
+}
@@ -0,0 +1,579 @@
+// Package rrule implements the subset of RFC 5545 recurrence rules used for recurring
+// tasks: FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, COUNT, UNTIL, BYDAY,
+// BYMONTHDAY, BYMONTH and WKST.
+//
+// Occurrences are expanded in the wall-clock time of the DTSTART location, so a rule
+// keeps its local time of day across DST changes. Dates that do not exist in a period,
+// such as the 31st in a 30-day month, are skipped as the RFC requires.
+package rrule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency int
+
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+// maxPeriods bounds expansion of rules that can never produce another occurrence,
+// such as BYMONTHDAY=31;BYMONTH=2.
+const maxPeriods = 10000
+
+type WeekdayNum struct {
+	// N is the ordinal within the month or year, e.g. 2 for 2MO or -1 for -1FR; 0 means every.
+	N       int
+	Weekday time.Weekday
+}
+
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	Count    int
+	Until    *time.Time
+	// UntilFloating is set when UNTIL had no "Z" suffix, so Until holds a wall-clock time
+	// that is read in the DTSTART location.
+	UntilFloating bool
+	ByDay         []WeekdayNum
+	ByMonthDay    []int
+	ByMonth       []time.Month
+	WeekStart     time.Weekday
+}
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Parse parses an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10". A leading
+// "RRULE:" prefix is accepted.
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("empty rrule")
+	}
+
+	rule := &Rule{Interval: 1, WeekStart: time.Monday}
+	hasFreq := false
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("invalid rrule part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			switch strings.ToUpper(val) {
+			case "DAILY":
+				rule.Freq = Daily
+			case "WEEKLY":
+				rule.Freq = Weekly
+			case "MONTHLY":
+				rule.Freq = Monthly
+			case "YEARLY":
+				rule.Freq = Yearly
+			default:
+				return nil, fmt.Errorf("unsupported FREQ %q", val)
+			}
+			hasFreq = true
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", val)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", val)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, floating, err := parseUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+			rule.UntilFloating = floating
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				wd, err := parseWeekdayNum(day)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(day)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY %q", day)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, month := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(month)
+				if err != nil || n < 1 || n > 12 {
+					return nil, fmt.Errorf("invalid BYMONTH %q", month)
+				}
+				rule.ByMonth = append(rule.ByMonth, time.Month(n))
+			}
+		case "WKST":
+			wd, ok := weekdays[strings.ToUpper(val)]
+			if !ok {
+				return nil, fmt.Errorf("invalid WKST %q", val)
+			}
+			rule.WeekStart = wd
+		default:
+			return nil, fmt.Errorf("unsupported rrule part %q", key)
+		}
+	}
+
+	if !hasFreq {
+		return nil, fmt.Errorf("rrule requires FREQ")
+	}
+
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("rrule cannot contain both COUNT and UNTIL")
+	}
+
+	for _, wd := range rule.ByDay {
+		if wd.N != 0 && rule.Freq != Monthly && rule.Freq != Yearly {
+			return nil, fmt.Errorf("ordinal BYDAY values are only allowed with MONTHLY or YEARLY")
+		}
+	}
+
+	if len(rule.ByMonthDay) > 0 && rule.Freq == Weekly {
+		return nil, fmt.Errorf("BYMONTHDAY is not allowed with WEEKLY")
+	}
+
+	return rule, nil
+}
+
+// parseUntil parses an UNTIL value. Values without a "Z" suffix are floating: they are
+// returned as UTC wall-clock time and reported as such, so the caller can place them in
+// the DTSTART location.
+func parseUntil(val string) (time.Time, bool, error) {
+	if t, err := time.Parse("20060102T150405Z", val); err == nil {
+		return t, false, nil
+	}
+
+	if t, err := time.Parse("20060102T150405", val); err == nil {
+		return t, true, nil
+	}
+
+	if t, err := time.Parse("20060102", val); err == nil {
+		// a date-only UNTIL includes the whole day
+		return t.Add(24*time.Hour - time.Nanosecond), true, nil
+	}
+
+	return time.Time{}, false, fmt.Errorf("invalid UNTIL %q", val)
+}
+
+// until returns the end of the series in the given location, or nil when there is no UNTIL.
+func (r *Rule) until(loc *time.Location) *time.Time {
+	if r.Until == nil || !r.UntilFloating {
+		return r.Until
+	}
+
+	u := *r.Until
+	t := time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), u.Nanosecond(), loc)
+	return &t
+}
+
+func parseWeekdayNum(value string) (WeekdayNum, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", value)
+	}
+
+	wd, ok := weekdays[value[len(value)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", value)
+	}
+
+	n := 0
+	if prefix := value[:len(value)-2]; prefix != "" {
+		var err error
+		n, err = strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -53 || n > 53 {
+			return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", value)
+		}
+	}
+
+	return WeekdayNum{N: n, Weekday: wd}, nil
+}
+
+// Next returns the first occurrence strictly after the given time for a series starting
+// at dtstart. The boolean is false once COUNT or UNTIL has been exhausted.
+func (r *Rule) Next(dtstart, after time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.iterate(dtstart, func(t time.Time) bool {
+		if t.After(after) {
+			next = t
+			found = true
+			return false
+		}
+
+		return true
+	})
+
+	return next, found
+}
+
+// Occurrences returns up to limit occurrences strictly after the given time.
+func (r *Rule) Occurrences(dtstart, after time.Time, limit int) []time.Time {
+	var result []time.Time
+	if limit <= 0 {
+		return result
+	}
+
+	r.iterate(dtstart, func(t time.Time) bool {
+		if t.After(after) {
+			result = append(result, t)
+		}
+
+		return len(result) < limit
+	})
+
+	return result
+}
+
+// iterate calls fn for each occurrence in order, starting with dtstart itself, until fn
+// returns false or the rule ends. DTSTART always counts as the first occurrence.
+func (r *Rule) iterate(dtstart time.Time, fn func(time.Time) bool) {
+	until := r.until(dtstart.Location())
+	count := 0
+	emit := func(t time.Time) bool {
+		if until != nil && t.After(*until) {
+			return false
+		}
+
+		count++
+		if !fn(t) {
+			return false
+		}
+
+		return r.Count == 0 || count < r.Count
+	}
+
+	if !emit(dtstart) {
+		return
+	}
+
+	periodStart := r.periodStart(dtstart)
+	for i := 0; i < maxPeriods; i++ {
+		period := r.addPeriods(periodStart, i*r.Interval)
+		for _, candidate := range r.expand(period, dtstart) {
+			if !candidate.After(dtstart) {
+				continue
+			}
+
+			if !emit(candidate) {
+				return
+			}
+		}
+
+		if until != nil && period.After(*until) {
+			return
+		}
+	}
+}
+
+// periodStart returns midnight of the first day of the period containing t.
+func (r *Rule) periodStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	loc := t.Location()
+	switch r.Freq {
+	case Weekly:
+		offset := (int(t.Weekday()) - int(r.WeekStart) + 7) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, loc)
+	case Monthly:
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc)
+	case Yearly:
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	}
+}
+
+// addPeriods advances by calendar units so month and year steps never overflow into the
+// following month.
+func (r *Rule) addPeriods(start time.Time, n int) time.Time {
+	y, m, d := start.Date()
+	loc := start.Location()
+	switch r.Freq {
+	case Weekly:
+		return time.Date(y, m, d+7*n, 0, 0, 0, 0, loc)
+	case Monthly:
+		return time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, loc)
+	case Yearly:
+		return time.Date(y+n, time.January, 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(y, m, d+n, 0, 0, 0, 0, loc)
+	}
+}
+
+// expand returns the sorted candidate dates within one period, at dtstart's time of day.
+func (r *Rule) expand(period, dtstart time.Time) []time.Time {
+	var days []civilDate
+	switch r.Freq {
+	case Daily:
+		days = []civilDate{dateOf(period)}
+	case Weekly:
+		days = r.expandWeek(period, dtstart)
+	case Monthly:
+		days = r.expandMonth(period.Year(), period.Month(), dtstart)
+	case Yearly:
+		months := r.ByMonth
+		if len(months) == 0 && len(r.ByDay) > 0 && len(r.ByMonthDay) == 0 {
+			days = r.expandYearByDay(period.Year())
+			break
+		}
+
+		if len(months) == 0 {
+			months = []time.Month{dtstart.Month()}
+		}
+
+		for _, month := range months {
+			days = append(days, r.expandMonth(period.Year(), month, dtstart)...)
+		}
+	}
+
+	hour, minute, sec := dtstart.Clock()
+	result := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		if !r.matchesLimits(day) {
+			continue
+		}
+
+		result = append(result, time.Date(day.year, day.month, day.day, hour, minute, sec, dtstart.Nanosecond(), dtstart.Location()))
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return dedupe(result)
+}
+
+func (r *Rule) expandWeek(period, dtstart time.Time) []civilDate {
+	if len(r.ByDay) == 0 {
+		offset := (int(dtstart.Weekday()) - int(r.WeekStart) + 7) % 7
+		return []civilDate{dateOf(period.AddDate(0, 0, offset))}
+	}
+
+	var days []civilDate
+	for i := 0; i < 7; i++ {
+		day := period.AddDate(0, 0, i)
+		for _, wd := range r.ByDay {
+			if wd.Weekday == day.Weekday() {
+				days = append(days, dateOf(day))
+				break
+			}
+		}
+	}
+
+	return days
+}
+
+func (r *Rule) expandMonth(year int, month time.Month, dtstart time.Time) []civilDate {
+	length := daysIn(year, month)
+	var days []civilDate
+
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, n := range r.ByMonthDay {
+			day := n
+			if n < 0 {
+				day = length + n + 1
+			}
+
+			if day < 1 || day > length {
+				continue
+			}
+
+			date := civilDate{year, month, day}
+			if len(r.ByDay) == 0 || r.matchesWeekday(date) {
+				days = append(days, date)
+			}
+		}
+	case len(r.ByDay) > 0:
+		for _, wd := range r.ByDay {
+			days = append(days, nthWeekdays(year, month, 1, length, wd)...)
+		}
+	default:
+		if dtstart.Day() <= length {
+			days = append(days, civilDate{year, month, dtstart.Day()})
+		}
+	}
+
+	return days
+}
+
+// expandYearByDay handles YEARLY rules with BYDAY but no BYMONTH, where ordinals count
+// weeks within the whole year.
+func (r *Rule) expandYearByDay(year int) []civilDate {
+	var days []civilDate
+	for _, wd := range r.ByDay {
+		if wd.N == 0 {
+			for m := time.January; m <= time.December; m++ {
+				days = append(days, nthWeekdays(year, m, 1, daysIn(year, m), wd)...)
+			}
+
+			continue
+		}
+
+		first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		total := 365
+		if daysIn(year, time.February) == 29 {
+			total = 366
+		}
+
+		var matches []time.Time
+		for i := 0; i < total; i++ {
+			day := first.AddDate(0, 0, i)
+			if day.Weekday() == wd.Weekday {
+				matches = append(matches, day)
+			}
+		}
+
+		if idx := ordinalIndex(wd.N, len(matches)); idx >= 0 {
+			days = append(days, dateOf(matches[idx]))
+		}
+	}
+
+	return days
+}
+
+func (r *Rule) matchesWeekday(date civilDate) bool {
+	weekday := date.weekday()
+	for _, wd := range r.ByDay {
+		if wd.N == 0 && wd.Weekday == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesLimits applies BY* parts that restrict rather than expand for the given frequency.
+func (r *Rule) matchesLimits(date civilDate) bool {
+	if len(r.ByMonth) > 0 && r.Freq != Yearly {
+		ok := false
+		for _, m := range r.ByMonth {
+			if m == date.month {
+				ok = true
+				break
+			}
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	if r.Freq == Daily {
+		if len(r.ByMonthDay) > 0 {
+			length := daysIn(date.year, date.month)
+			ok := false
+			for _, n := range r.ByMonthDay {
+				if n == date.day || (n < 0 && length+n+1 == date.day) {
+					ok = true
+					break
+				}
+			}
+
+			if !ok {
+				return false
+			}
+		}
+
+		if len(r.ByDay) > 0 && !r.matchesWeekday(date) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) civilDate {
+	y, m, d := t.Date()
+	return civilDate{y, m, d}
+}
+
+func (d civilDate) weekday() time.Weekday {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC).Weekday()
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nthWeekdays returns every matching weekday in the range, or only the Nth one when wd.N is set.
+func nthWeekdays(year int, month time.Month, from, to int, wd WeekdayNum) []civilDate {
+	var matches []civilDate
+	for day := from; day <= to; day++ {
+		date := civilDate{year, month, day}
+		if date.weekday() == wd.Weekday {
+			matches = append(matches, date)
+		}
+	}
+
+	if wd.N == 0 {
+		return matches
+	}
+
+	if idx := ordinalIndex(wd.N, len(matches)); idx >= 0 {
+		return []civilDate{matches[idx]}
+	}
+
+	return nil
+}
+
+func ordinalIndex(n, length int) int {
+	idx := n - 1
+	if n < 0 {
+		idx = length + n
+	}
+
+	if idx < 0 || idx >= length {
+		return -1
+	}
+
+	return idx
+}
+
+func dedupe(times []time.Time) []time.Time {
+	if len(times) < 2 {
+		return times
+	}
+
+	out := times[:1]
+	for _, t := range times[1:] {
+		if !t.Equal(out[len(out)-1]) {
+			out = append(out, t)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,350 @@
+package rrule
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %s: %v", name, err)
+	}
+
+	return loc
+}
+
+func TestParse(t *testing.T) {
+	until := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	untilDay := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC).Add(24*time.Hour - time.Nanosecond)
+
+	tests := []struct {
+		name  string
+		value string
+		want  *Rule
+		err   string
+	}{
+		{
+			name:  "daily",
+			value: "FREQ=DAILY",
+			want:  &Rule{Freq: Daily, Interval: 1, WeekStart: time.Monday},
+		},
+		{
+			name:  "prefix and lower case",
+			value: "RRULE:freq=weekly;interval=2;byday=mo,fr;wkst=su",
+			want: &Rule{
+				Freq:      Weekly,
+				Interval:  2,
+				ByDay:     []WeekdayNum{{Weekday: time.Monday}, {Weekday: time.Friday}},
+				WeekStart: time.Sunday,
+			},
+		},
+		{
+			name:  "monthly ordinals",
+			value: "FREQ=MONTHLY;BYDAY=2MO,-1FR;COUNT=5",
+			want: &Rule{
+				Freq:      Monthly,
+				Interval:  1,
+				Count:     5,
+				ByDay:     []WeekdayNum{{N: 2, Weekday: time.Monday}, {N: -1, Weekday: time.Friday}},
+				WeekStart: time.Monday,
+			},
+		},
+		{
+			name:  "yearly by month and month day",
+			value: "FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=1,-1",
+			want: &Rule{
+				Freq:       Yearly,
+				Interval:   1,
+				ByMonthDay: []int{1, -1},
+				ByMonth:    []time.Month{time.January, time.July},
+				WeekStart:  time.Monday,
+			},
+		},
+		{
+			name:  "utc until",
+			value: "FREQ=DAILY;UNTIL=20240301T090000Z",
+			want:  &Rule{Freq: Daily, Interval: 1, Until: &until, WeekStart: time.Monday},
+		},
+		{
+			name:  "floating until",
+			value: "FREQ=DAILY;UNTIL=20240301T090000",
+			want:  &Rule{Freq: Daily, Interval: 1, Until: &until, UntilFloating: true, WeekStart: time.Monday},
+		},
+		{
+			name:  "date until covers the day",
+			value: "FREQ=DAILY;UNTIL=20240301",
+			want:  &Rule{Freq: Daily, Interval: 1, Until: &untilDay, UntilFloating: true, WeekStart: time.Monday},
+		},
+		{name: "empty", value: " ", err: "empty rrule"},
+		{name: "missing freq", value: "COUNT=3", err: "rrule requires FREQ"},
+		{name: "unsupported freq", value: "FREQ=HOURLY", err: `unsupported FREQ "HOURLY"`},
+		{name: "part without value", value: "FREQ=DAILY;COUNT", err: `invalid rrule part "COUNT"`},
+		{name: "unknown part", value: "FREQ=DAILY;BYSETPOS=1", err: `unsupported rrule part "BYSETPOS"`},
+		{name: "zero interval", value: "FREQ=DAILY;INTERVAL=0", err: `invalid INTERVAL "0"`},
+		{name: "negative count", value: "FREQ=DAILY;COUNT=-1", err: `invalid COUNT "-1"`},
+		{name: "bad until", value: "FREQ=DAILY;UNTIL=2024-03-01", err: `invalid UNTIL "2024-03-01"`},
+		{name: "bad weekday", value: "FREQ=WEEKLY;BYDAY=XX", err: `invalid BYDAY "XX"`},
+		{name: "zero ordinal", value: "FREQ=MONTHLY;BYDAY=0MO", err: `invalid BYDAY "0MO"`},
+		{name: "month day out of range", value: "FREQ=MONTHLY;BYMONTHDAY=32", err: `invalid BYMONTHDAY "32"`},
+		{name: "month out of range", value: "FREQ=YEARLY;BYMONTH=13", err: `invalid BYMONTH "13"`},
+		{name: "bad week start", value: "FREQ=WEEKLY;WKST=XY", err: `invalid WKST "XY"`},
+		{name: "count and until", value: "FREQ=DAILY;COUNT=2;UNTIL=20240301", err: "rrule cannot contain both COUNT and UNTIL"},
+		{name: "weekly ordinal", value: "FREQ=WEEKLY;BYDAY=1MO", err: "ordinal BYDAY values are only allowed with MONTHLY or YEARLY"},
+		{name: "weekly month day", value: "FREQ=WEEKLY;BYMONTHDAY=1", err: "BYMONTHDAY is not allowed with WEEKLY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.value)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("Parse(%q) error = %v, want %q", tt.value, err, tt.err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.value, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	ny := mustLocation(t, "America/New_York")
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		after   time.Time
+		want    time.Time
+		ok      bool
+	}{
+		{
+			name:    "daily",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+			want:    time.Date(2024, time.January, 2, 9, 0, 0, 0, time.UTC),
+			ok:      true,
+		},
+		{
+			name:    "later occurrence in the series",
+			rule:    "FREQ=DAILY;INTERVAL=3",
+			dtstart: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC),
+			want:    time.Date(2024, time.January, 7, 9, 0, 0, 0, time.UTC),
+			ok:      true,
+		},
+		{
+			name:    "month day 31 skips 30 day months",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=31",
+			dtstart: time.Date(2024, time.March, 31, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, time.March, 31, 9, 0, 0, 0, time.UTC),
+			want:    time.Date(2024, time.May, 31, 9, 0, 0, 0, time.UTC),
+			ok:      true,
+		},
+		{
+			name:    "weekly keeps wall clock across DST",
+			rule:    "FREQ=WEEKLY",
+			dtstart: time.Date(2024, time.March, 4, 9, 0, 0, 0, ny),
+			after:   time.Date(2024, time.March, 4, 9, 0, 0, 0, ny),
+			want:    time.Date(2024, time.March, 11, 9, 0, 0, 0, ny),
+			ok:      true,
+		},
+		{
+			name:    "count ends the series",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, time.January, 3, 9, 0, 0, 0, time.UTC),
+			ok:      false,
+		},
+		{
+			name:    "until ends the series",
+			rule:    "FREQ=DAILY;UNTIL=20240103T090000Z",
+			dtstart: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, time.January, 3, 9, 0, 0, 0, time.UTC),
+			ok:      false,
+		},
+		{
+			name:    "until is inclusive",
+			rule:    "FREQ=DAILY;UNTIL=20240103T090000Z",
+			dtstart: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, time.January, 2, 9, 0, 0, 0, time.UTC),
+			want:    time.Date(2024, time.January, 3, 9, 0, 0, 0, time.UTC),
+			ok:      true,
+		},
+		{
+			// 09:00 in New York is 14:00 UTC, which is after a 10:00 UTC reading of the UNTIL
+			name:    "floating until uses the dtstart location",
+			rule:    "FREQ=DAILY;UNTIL=20240103T100000",
+			dtstart: time.Date(2024, time.January, 1, 9, 0, 0, 0, ny),
+			after:   time.Date(2024, time.January, 2, 9, 0, 0, 0, ny),
+			want:    time.Date(2024, time.January, 3, 9, 0, 0, 0, ny),
+			ok:      true,
+		},
+		{
+			name:    "impossible rule ends",
+			rule:    "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			dtstart: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+			ok:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.rule, err)
+			}
+
+			got, ok := rule.Next(tt.dtstart, tt.after)
+			if ok != tt.ok {
+				t.Fatalf("Next() ok = %v, want %v (got %v)", ok, tt.ok, got)
+			}
+
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+
+			if ok && got.Location() != tt.dtstart.Location() {
+				t.Errorf("Next() location = %v, want %v", got.Location(), tt.dtstart.Location())
+			}
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	ny := mustLocation(t, "America/New_York")
+	utc := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 9, 0, 0, 0, time.UTC) }
+	local := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 9, 0, 0, 0, ny) }
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		after   time.Time
+		limit   int
+		want    []time.Time
+	}{
+		{
+			name:    "month day 31",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=31",
+			dtstart: utc(2024, time.January, 31),
+			after:   utc(2024, time.January, 31),
+			limit:   4,
+			want:    []time.Time{utc(2024, time.March, 31), utc(2024, time.May, 31), utc(2024, time.July, 31), utc(2024, time.August, 31)},
+		},
+		{
+			name:    "last day of month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: utc(2024, time.January, 31),
+			after:   utc(2024, time.January, 31),
+			limit:   3,
+			want:    []time.Time{utc(2024, time.February, 29), utc(2024, time.March, 31), utc(2024, time.April, 30)},
+		},
+		{
+			name:    "weekly across spring forward",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,TH",
+			dtstart: local(2024, time.March, 4),
+			after:   local(2024, time.March, 4),
+			limit:   4,
+			want:    []time.Time{local(2024, time.March, 7), local(2024, time.March, 11), local(2024, time.March, 14), local(2024, time.March, 18)},
+		},
+		{
+			name:    "weekly across fall back",
+			rule:    "FREQ=WEEKLY",
+			dtstart: local(2024, time.October, 28),
+			after:   local(2024, time.October, 28),
+			limit:   2,
+			want:    []time.Time{local(2024, time.November, 4), local(2024, time.November, 11)},
+		},
+		{
+			name:    "second monday",
+			rule:    "FREQ=MONTHLY;BYDAY=2MO",
+			dtstart: utc(2024, time.January, 8),
+			after:   utc(2024, time.January, 8),
+			limit:   3,
+			want:    []time.Time{utc(2024, time.February, 12), utc(2024, time.March, 11), utc(2024, time.April, 8)},
+		},
+		{
+			name:    "leap day yearly",
+			rule:    "FREQ=YEARLY",
+			dtstart: utc(2024, time.February, 29),
+			after:   utc(2024, time.February, 29),
+			limit:   2,
+			want:    []time.Time{utc(2028, time.February, 29), utc(2032, time.February, 29)},
+		},
+		{
+			name:    "count counts dtstart",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: utc(2024, time.January, 1),
+			after:   utc(2023, time.December, 31),
+			limit:   10,
+			want:    []time.Time{utc(2024, time.January, 1), utc(2024, time.January, 2), utc(2024, time.January, 3)},
+		},
+		{
+			name:    "until stops the series",
+			rule:    "FREQ=WEEKLY;UNTIL=20240122T090000Z",
+			dtstart: utc(2024, time.January, 1),
+			after:   utc(2024, time.January, 1),
+			limit:   10,
+			want:    []time.Time{utc(2024, time.January, 8), utc(2024, time.January, 15), utc(2024, time.January, 22)},
+		},
+		{
+			name:    "date until includes the day",
+			rule:    "FREQ=DAILY;UNTIL=20240103",
+			dtstart: local(2024, time.January, 1),
+			after:   local(2024, time.January, 1),
+			limit:   10,
+			want:    []time.Time{local(2024, time.January, 2), local(2024, time.January, 3)},
+		},
+		{
+			name:    "limit",
+			rule:    "FREQ=DAILY",
+			dtstart: utc(2024, time.January, 1),
+			after:   utc(2024, time.January, 1),
+			limit:   2,
+			want:    []time.Time{utc(2024, time.January, 2), utc(2024, time.January, 3)},
+		},
+		{
+			name:    "zero limit",
+			rule:    "FREQ=DAILY",
+			dtstart: utc(2024, time.January, 1),
+			after:   utc(2024, time.January, 1),
+			limit:   0,
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.rule, err)
+			}
+
+			got := rule.Occurrences(tt.dtstart, tt.after, tt.limit)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Occurrences()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Package textdiff computes line-level differences between two texts.
+//
+// The diff is the longest common subsequence of lines, so unchanged lines are kept and
+// everything else is reported as deleted from the old text or inserted into the new one.
+// Deletions come before insertions at each change.
+package textdiff
+
+import "strings"
+
+type Op string
+
+const (
+	Equal  Op = "equal"
+	Delete Op = "delete"
+	Insert Op = "insert"
+)
+
+type Line struct {
+	Op   Op     `json:"op"`
+	Text string `json:"text"`
+}
+
+// maxCells bounds the LCS table. Texts beyond it are reported as replaced as a whole.
+const maxCells = 4_000_000
+
+// Lines returns the line diff that turns a into b.
+func Lines(a, b string) []Line {
+	x, y := split(a), split(b)
+
+	// common prefix and suffix need no table
+	prefix := 0
+	for prefix < len(x) && prefix < len(y) && x[prefix] == y[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(x)-prefix && suffix < len(y)-prefix && x[len(x)-1-suffix] == y[len(y)-1-suffix] {
+		suffix++
+	}
+
+	diff := make([]Line, 0, len(x)+len(y))
+	for _, text := range x[:prefix] {
+		diff = append(diff, Line{Op: Equal, Text: text})
+	}
+
+	diff = append(diff, middle(x[prefix:len(x)-suffix], y[prefix:len(y)-suffix])...)
+	for _, text := range x[len(x)-suffix:] {
+		diff = append(diff, Line{Op: Equal, Text: text})
+	}
+
+	return diff
+}
+
+// Changed reports whether the diff contains any deleted or inserted line.
+func Changed(diff []Line) bool {
+	for _, line := range diff {
+		if line.Op != Equal {
+			return true
+		}
+	}
+
+	return false
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
+
+func middle(x, y []string) []Line {
+	var diff []Line
+	if len(x) == 0 || len(y) == 0 || (len(x)+1)*(len(y)+1) > maxCells {
+		for _, text := range x {
+			diff = append(diff, Line{Op: Delete, Text: text})
+		}
+
+		for _, text := range y {
+			diff = append(diff, Line{Op: Insert, Text: text})
+		}
+
+		return diff
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			diff = append(diff, Line{Op: Equal, Text: x[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, Line{Op: Delete, Text: x[i]})
+			i++
+		default:
+			diff = append(diff, Line{Op: Insert, Text: y[j]})
+			j++
+		}
+	}
+
+	for ; i < len(x); i++ {
+		diff = append(diff, Line{Op: Delete, Text: x[i]})
+	}
+
+	for ; j < len(y); j++ {
+		diff = append(diff, Line{Op: Insert, Text: y[j]})
+	}
+
+	return diff
+}